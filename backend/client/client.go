@@ -1,6 +1,8 @@
 package client
 
 import (
+	"sync"
+
 	"github.com/go-resty/resty/v2"
 	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
 	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
@@ -10,6 +12,10 @@ type BaseClient struct {
 	Client *resty.Client
 	Logger *logger.Logger
 	JWT    string
+
+	mutex    sync.Mutex
+	onLogin  []func()
+	onLogout []func()
 }
 
 // NewBaseClient creates a new base HTTP client
@@ -29,9 +35,35 @@ func NewBaseClient(logger *logger.Logger, config *config.Config) *BaseClient {
 func (b *BaseClient) SetJWT(jwt string) {
 	b.JWT = jwt
 	b.Client.SetHeader("Authorization", "Bearer "+jwt)
+	for _, callback := range b.callbacks(&b.onLogin) {
+		go callback()
+	}
 }
 
 func (b *BaseClient) ClearJWT() {
 	b.JWT = ""
 	b.Client.Header.Del("Authorization")
+	for _, callback := range b.callbacks(&b.onLogout) {
+		callback()
+	}
+}
+
+// OnLogin registers a callback that runs in the background every time a JWT is set
+func (b *BaseClient) OnLogin(callback func()) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.onLogin = append(b.onLogin, callback)
+}
+
+// OnLogout registers a callback that runs when the JWT is cleared
+func (b *BaseClient) OnLogout(callback func()) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.onLogout = append(b.onLogout, callback)
+}
+
+func (b *BaseClient) callbacks(registered *[]func()) []func() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return append([]func(){}, (*registered)...)
 }
@@ -0,0 +1,135 @@
+// Command catalogsums adds the sha256 of every skin and chroma to the skin catalog. It downloads each file of
+// the catalog and writes its hash next to the downloadUrl, the rest of the file is left as is. Skins the
+// catalog already has a hash for are skipped unless -force is set
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/tools/lolskin"
+)
+
+var (
+	downloadURLLine = regexp.MustCompile(`^(\s*)"downloadUrl": "([^"]+)",$`)
+	sha256Line      = regexp.MustCompile(`^\s*"sha256": "([0-9a-f]*)",$`)
+)
+
+func main() {
+	catalogPath := flag.String("catalog", lolskin.CatalogPath, "Path of the catalog to update")
+	workers := flag.Int("workers", 8, "Number of parallel downloads")
+	force := flag.Bool("force", false, "Hash the skins that already have a sha256 again")
+	flag.Parse()
+
+	data, err := os.ReadFile(*catalogPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read catalog: %v\n", err)
+		os.Exit(1)
+	}
+	lines := strings.Split(string(data), "\n")
+
+	var urls []string
+	for i, line := range lines {
+		match := downloadURLLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if !*force && i+1 < len(lines) && sha256Line.MatchString(lines[i+1]) {
+			continue
+		}
+		urls = append(urls, match[2])
+	}
+	fmt.Printf("Hashing %d files\n", len(urls))
+
+	sums, failed := hashAll(urls, max(*workers, 1))
+
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		out = append(out, lines[i])
+		match := downloadURLLine.FindStringSubmatch(lines[i])
+		if match == nil {
+			continue
+		}
+		sum, ok := sums[match[2]]
+		if !ok {
+			continue
+		}
+		// A previous hash is replaced in place
+		if i+1 < len(lines) && sha256Line.MatchString(lines[i+1]) {
+			i++
+		}
+		out = append(out, fmt.Sprintf(`%s"sha256": "%s",`, match[1], sum))
+	}
+
+	if err := os.WriteFile(*catalogPath, []byte(strings.Join(out, "\n")), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write catalog: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Hashed %d files, %d failed\n", len(sums), len(failed))
+	for _, url := range failed {
+		fmt.Fprintf(os.Stderr, "no sha256 for %s\n", url)
+	}
+	if len(failed) > 0 {
+		os.Exit(1)
+	}
+}
+
+// hashAll downloads the files in parallel, the ones that fail are returned so the catalog is never given a
+// hash it can't back
+func hashAll(urls []string, workers int) (map[string]string, []string) {
+	client := &http.Client{Timeout: 5 * time.Minute}
+	jobs := make(chan string)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	sums := make(map[string]string, len(urls))
+	var failed []string
+
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range jobs {
+				sum, err := hashURL(client, url)
+				mutex.Lock()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", url, err)
+					failed = append(failed, url)
+				} else {
+					sums[url] = sum
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	for _, url := range urls {
+		jobs <- url
+	}
+	close(jobs)
+	wg.Wait()
+	return sums, failed
+}
+
+func hashURL(client *http.Client, url string) (string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("bad status: %s", resp.Status)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, resp.Body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
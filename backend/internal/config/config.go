@@ -6,6 +6,7 @@ import (
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"path/filepath"
 )
 
 var (
@@ -130,3 +131,13 @@ func loadFromFile(file string, config *Config) error {
 	}
 	return json.Unmarshal(data, config)
 }
+
+// DataPath returns the path of name in the app data directory, the parent of the directory holding the
+// executable. The lolskin tools are extracted to the same place
+func DataPath(name string) (string, error) {
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to get executable path: %w", err)
+	}
+	return filepath.Join(filepath.Dir(filepath.Dir(exePath)), name), nil
+}
@@ -0,0 +1,250 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/account/events"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/riot/region"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"github.com/wailsapp/wails/v3/pkg/application"
+	"go.uber.org/zap"
+)
+
+// ErrCatalogNotModified is a 304 answered to a query that has no cached page
+var ErrCatalogNotModified = errors.New("accounts page not modified without a cached page")
+
+// CatalogClient defines the backend calls needed by the accounts catalog
+type CatalogClient interface {
+	GetAvailablePage(query types.AccountsQuery, page int, etag string) (*types.AccountsPageResponse, string, bool, error)
+}
+
+// catalogEntry holds the pages loaded so far for a single backend query, entries are never modified
+// once stored so they can be read without the lock
+type catalogEntry struct {
+	accounts   []types.SummonerBase
+	etag       string
+	pagination types.Pagination
+	fetchedAt  time.Time
+	generation int
+}
+
+// catalogTTL is how long a query is served without asking the backend. Rentals by other users aren't pushed
+// to the app, only the local account events invalidate the cache, so the first page is revalidated with its
+// ETag this often and their rentals show up quickly. An unchanged page only costs a 304
+const catalogTTL = 15 * time.Second
+
+// Catalog caches the available accounts per backend query and filters them locally. The backend is
+// called without holding the lock so slow requests don't block the other queries
+type Catalog struct {
+	logger      *logger.Logger
+	client      CatalogClient
+	ttl         time.Duration
+	mutex       sync.Mutex
+	entries     map[types.AccountsQuery]*catalogEntry
+	generation  int
+	unsubscribe []func()
+	now         func() time.Time
+}
+
+func NewCatalog(logger *logger.Logger, client CatalogClient) *Catalog {
+	return &Catalog{
+		logger:  logger,
+		client:  client,
+		ttl:     catalogTTL,
+		entries: make(map[types.AccountsQuery]*catalogEntry),
+		now:     time.Now,
+	}
+}
+
+func (c *Catalog) OnStartup(ctx context.Context, options application.ServiceOptions) error {
+	app := application.Get()
+	// Any change on an account can make it available or unavailable
+	for _, event := range []string{events.AccountStateChanged, "nexusAccount:state"} {
+		c.unsubscribe = append(c.unsubscribe, app.OnEvent(event, func(event *application.CustomEvent) {
+			c.Invalidate()
+		}))
+	}
+	return nil
+}
+
+func (c *Catalog) OnShutdown(ctx context.Context, options application.ServiceOptions) error {
+	for _, unsubscribe := range c.unsubscribe {
+		unsubscribe()
+	}
+	c.unsubscribe = nil
+	return nil
+}
+
+// GetAccounts returns the accounts loaded for the query, revalidating the first page once the cache expires
+func (c *Catalog) GetAccounts(query types.AccountsQuery, filter types.AccountsFilter) (*types.AccountsCatalogPage, error) {
+	entry, err := c.entry(query)
+	if err != nil {
+		return nil, err
+	}
+	return entry.page(filter), nil
+}
+
+// LoadMore fetches the next page of the query and returns everything loaded so far
+func (c *Catalog) LoadMore(query types.AccountsQuery, filter types.AccountsFilter) (*types.AccountsCatalogPage, error) {
+	entry, err := c.entry(query)
+	if err != nil {
+		return nil, err
+	}
+	if entry.pagination.Page >= entry.pagination.PageCount {
+		return entry.page(filter), nil
+	}
+
+	nextPage := entry.pagination.Page + 1
+	response, _, _, err := c.client.GetAvailablePage(query, nextPage, "")
+	if err != nil {
+		return nil, err
+	}
+	loaded := *entry
+	loaded.accounts = slices.Concat(entry.accounts, response.Data)
+	loaded.pagination = response.Meta.Pagination
+	return c.store(query, entry, &loaded).page(filter), nil
+}
+
+// Invalidate marks every cached query as expired, the next read revalidates it with the backend
+func (c *Catalog) Invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.logger.Debug("Invalidating accounts catalog", zap.Int("queries", len(c.entries)))
+	c.generation++
+}
+
+// entry returns the cached entry of the query, revalidating its first page once it expires. When the first
+// page changed the pages loaded before are fetched again so the list doesn't shrink
+func (c *Catalog) entry(query types.AccountsQuery) (*catalogEntry, error) {
+	c.mutex.Lock()
+	cached, ok := c.entries[query]
+	generation := c.generation
+	c.mutex.Unlock()
+	if ok && cached.generation == generation && c.now().Sub(cached.fetchedAt) < c.ttl {
+		return cached, nil
+	}
+
+	etag := ""
+	if ok {
+		etag = cached.etag
+	}
+	response, newEtag, notModified, err := c.client.GetAvailablePage(query, 1, etag)
+	if err == nil && notModified && !ok {
+		// There's nothing cached to revalidate, the page is fetched again without the ETag
+		response, newEtag, notModified, err = c.client.GetAvailablePage(query, 1, "")
+		if err == nil && notModified {
+			err = ErrCatalogNotModified
+		}
+	}
+	if err != nil {
+		if ok {
+			c.logger.Warn("Failed to revalidate accounts catalog, serving stale data", zap.Error(err))
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	var entry *catalogEntry
+	if notModified {
+		revalidated := *cached
+		revalidated.fetchedAt = c.now()
+		revalidated.generation = generation
+		entry = &revalidated
+	} else {
+		entry = &catalogEntry{
+			accounts:   response.Data,
+			etag:       newEtag,
+			pagination: response.Meta.Pagination,
+			fetchedAt:  c.now(),
+			generation: generation,
+		}
+		if ok {
+			c.reloadPages(query, entry, cached.pagination.Page)
+		}
+	}
+	return c.store(query, cached, entry), nil
+}
+
+// reloadPages fetches the pages after the first one up to lastPage into an entry that isn't stored yet,
+// stopping at the first error
+func (c *Catalog) reloadPages(query types.AccountsQuery, entry *catalogEntry, lastPage int) {
+	for page := 2; page <= lastPage && page <= entry.pagination.PageCount; page++ {
+		response, _, _, err := c.client.GetAvailablePage(query, page, "")
+		if err != nil {
+			c.logger.Warn("Failed to reload accounts catalog page", zap.Int("page", page), zap.Error(err))
+			return
+		}
+		entry.accounts = append(entry.accounts, response.Data...)
+		entry.pagination = response.Meta.Pagination
+	}
+}
+
+// store saves entry as the new state of the query unless another call replaced previous meanwhile, it
+// returns the entry that's kept
+func (c *Catalog) store(query types.AccountsQuery, previous *catalogEntry, entry *catalogEntry) *catalogEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if current, ok := c.entries[query]; ok && current != previous {
+		return current
+	}
+	c.entries[query] = entry
+	return entry
+}
+
+func (e *catalogEntry) page(filter types.AccountsFilter) *types.AccountsCatalogPage {
+	accounts := make([]types.SummonerBase, 0, len(e.accounts))
+	for _, account := range e.accounts {
+		if matchesFilter(account, filter) {
+			accounts = append(accounts, account)
+		}
+	}
+	return &types.AccountsCatalogPage{
+		Accounts: accounts,
+		Loaded:   len(e.accounts),
+		Total:    e.pagination.Total,
+		HasMore:  e.pagination.Page < e.pagination.PageCount,
+	}
+}
+
+func matchesFilter(account types.SummonerBase, filter types.AccountsFilter) bool {
+	if filter.Server != "" && !region.Equal(account.Server, filter.Server) {
+		return false
+	}
+	if len(filter.Tiers) > 0 {
+		tier := account.Rankings.RankedSolo5x5.Tier
+		found := false
+		for _, t := range filter.Tiers {
+			if strings.EqualFold(t, tier) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.ExcludeBanned && len(account.Ban.Restrictions) > 0 {
+		return false
+	}
+	if !ownsAll(account.OwnedChampionIDs(), filter.ChampionIDs) {
+		return false
+	}
+	return ownsAll(account.OwnedSkinIDs(), filter.SkinIDs)
+}
+
+func ownsAll(owned map[int]struct{}, ids []int) bool {
+	for _, id := range ids {
+		if _, ok := owned[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
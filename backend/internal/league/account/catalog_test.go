@@ -0,0 +1,207 @@
+package account
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/account/mocks"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accountsPage returns page of pageCount holding one account per id
+func accountsPage(page int, pageCount int, ids ...int) *types.AccountsPageResponse {
+	response := &types.AccountsPageResponse{}
+	for _, id := range ids {
+		response.Data = append(response.Data, types.SummonerBase{ID: id, Server: "BR1"})
+	}
+	response.Meta.Pagination = types.Pagination{Page: page, PageSize: len(ids), PageCount: pageCount, Total: pageCount * len(ids)}
+	return response
+}
+
+func accountIDs(page *types.AccountsCatalogPage) []int {
+	ids := make([]int, 0, len(page.Accounts))
+	for _, account := range page.Accounts {
+		ids = append(ids, account.ID)
+	}
+	return ids
+}
+
+func TestCatalog(t *testing.T) {
+	newLogger := logger.New("TestCatalog", &config.Config{LogLevel: "error"})
+	startedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	query := types.AccountsQuery{Server: "BR1"}
+
+	t.Run("Pages are cached for the TTL", func(t *testing.T) {
+		mockClient := mocks.NewCatalogClient(t)
+		catalog := NewCatalog(newLogger, mockClient)
+		now := startedAt
+		catalog.now = func() time.Time { return now }
+		mockClient.EXPECT().GetAvailablePage(query, 1, "").Return(accountsPage(1, 2, 1, 2), "v1", false, nil).Once()
+		mockClient.EXPECT().GetAvailablePage(query, 2, "").Return(accountsPage(2, 2, 3, 4), "", false, nil).Once()
+
+		page, err := catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, accountIDs(page))
+		assert.True(t, page.HasMore)
+
+		page, err = catalog.LoadMore(query, types.AccountsFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4}, accountIDs(page))
+		assert.False(t, page.HasMore)
+
+		now = now.Add(catalog.ttl - time.Second)
+		page, err = catalog.LoadMore(query, types.AccountsFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, 4, page.Loaded, "the last page doesn't call the backend")
+	})
+
+	t.Run("Unchanged first page keeps the loaded pages", func(t *testing.T) {
+		mockClient := mocks.NewCatalogClient(t)
+		catalog := NewCatalog(newLogger, mockClient)
+		now := startedAt
+		catalog.now = func() time.Time { return now }
+		mockClient.EXPECT().GetAvailablePage(query, 1, "").Return(accountsPage(1, 3, 1, 2), "v1", false, nil).Once()
+		mockClient.EXPECT().GetAvailablePage(query, 2, "").Return(accountsPage(2, 3, 3, 4), "", false, nil).Once()
+		_, err := catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err)
+		_, err = catalog.LoadMore(query, types.AccountsFilter{})
+		require.NoError(t, err)
+
+		now = now.Add(catalog.ttl)
+		mockClient.EXPECT().GetAvailablePage(query, 1, "v1").Return(nil, "v1", true, nil).Once()
+		page, err := catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4}, accountIDs(page))
+		assert.True(t, page.HasMore)
+
+		_, err = catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err, "the revalidation restarts the TTL")
+	})
+
+	t.Run("Changed first page reloads the loaded pages", func(t *testing.T) {
+		mockClient := mocks.NewCatalogClient(t)
+		catalog := NewCatalog(newLogger, mockClient)
+		now := startedAt
+		catalog.now = func() time.Time { return now }
+		mockClient.EXPECT().GetAvailablePage(query, 1, "").Return(accountsPage(1, 3, 1, 2), "v1", false, nil).Once()
+		mockClient.EXPECT().GetAvailablePage(query, 2, "").Return(accountsPage(2, 3, 3, 4), "", false, nil).Once()
+		_, err := catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err)
+		_, err = catalog.LoadMore(query, types.AccountsFilter{})
+		require.NoError(t, err)
+
+		now = now.Add(catalog.ttl)
+		mockClient.EXPECT().GetAvailablePage(query, 1, "v1").Return(accountsPage(1, 3, 5, 1), "v2", false, nil).Once()
+		mockClient.EXPECT().GetAvailablePage(query, 2, "").Return(accountsPage(2, 3, 2, 3), "", false, nil).Once()
+		page, err := catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []int{5, 1, 2, 3}, accountIDs(page))
+		assert.True(t, page.HasMore)
+
+		now = now.Add(catalog.ttl)
+		mockClient.EXPECT().GetAvailablePage(query, 1, "v2").Return(nil, "v2", true, nil).Once()
+		_, err = catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err, "the new etag is kept")
+	})
+
+	t.Run("Invalidate revalidates the next read", func(t *testing.T) {
+		mockClient := mocks.NewCatalogClient(t)
+		catalog := NewCatalog(newLogger, mockClient)
+		mockClient.EXPECT().GetAvailablePage(query, 1, "").Return(accountsPage(1, 1, 1), "v1", false, nil).Once()
+		_, err := catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err)
+
+		catalog.Invalidate()
+		mockClient.EXPECT().GetAvailablePage(query, 1, "v1").Return(nil, "v1", true, nil).Once()
+		_, err = catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err)
+		_, err = catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err)
+	})
+
+	t.Run("Not modified without a cached page is fetched again", func(t *testing.T) {
+		mockClient := mocks.NewCatalogClient(t)
+		catalog := NewCatalog(newLogger, mockClient)
+		mockClient.EXPECT().GetAvailablePage(query, 1, "").Return(nil, "v1", true, nil).Once()
+		mockClient.EXPECT().GetAvailablePage(query, 1, "").Return(accountsPage(1, 1, 1, 2), "v1", false, nil).Once()
+
+		page, err := catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, accountIDs(page))
+
+		otherQuery := types.AccountsQuery{Server: "NA1"}
+		mockClient.EXPECT().GetAvailablePage(otherQuery, 1, "").Return(nil, "v1", true, nil).Twice()
+		_, err = catalog.GetAccounts(otherQuery, types.AccountsFilter{})
+		assert.ErrorIs(t, err, ErrCatalogNotModified)
+	})
+
+	t.Run("Backend errors serve stale data", func(t *testing.T) {
+		mockClient := mocks.NewCatalogClient(t)
+		catalog := NewCatalog(newLogger, mockClient)
+		now := startedAt
+		catalog.now = func() time.Time { return now }
+		mockClient.EXPECT().GetAvailablePage(query, 1, "").Return(nil, "", false, errors.New("offline")).Once()
+		_, err := catalog.GetAccounts(query, types.AccountsFilter{})
+		assert.Error(t, err, "nothing is cached yet")
+
+		mockClient.EXPECT().GetAvailablePage(query, 1, "").Return(accountsPage(1, 2, 1), "v1", false, nil).Once()
+		_, err = catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err)
+
+		now = now.Add(catalog.ttl)
+		mockClient.EXPECT().GetAvailablePage(query, 1, "v1").Return(nil, "", false, errors.New("offline")).Once()
+		page, err := catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, accountIDs(page))
+
+		mockClient.EXPECT().GetAvailablePage(query, 1, "v1").Return(accountsPage(1, 2, 1), "v1", false, nil).Once()
+		mockClient.EXPECT().GetAvailablePage(query, 2, "").Return(nil, "", false, errors.New("offline")).Once()
+		_, err = catalog.LoadMore(query, types.AccountsFilter{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Backend calls don't block other queries", func(t *testing.T) {
+		mockClient := mocks.NewCatalogClient(t)
+		catalog := NewCatalog(newLogger, mockClient)
+		slowQuery := types.AccountsQuery{Server: "NA1"}
+		started := make(chan struct{})
+		release := make(chan struct{})
+		mockClient.EXPECT().GetAvailablePage(slowQuery, 1, "").Run(func(types.AccountsQuery, int, string) {
+			close(started)
+			<-release
+		}).Return(accountsPage(1, 1, 9), "", false, nil).Once()
+		mockClient.EXPECT().GetAvailablePage(query, 1, "").Return(accountsPage(1, 1, 1), "", false, nil).Once()
+
+		done := make(chan error)
+		go func() {
+			_, err := catalog.GetAccounts(slowQuery, types.AccountsFilter{})
+			done <- err
+		}()
+		<-started
+		page, err := catalog.GetAccounts(query, types.AccountsFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, accountIDs(page))
+		catalog.Invalidate()
+
+		close(release)
+		require.NoError(t, <-done)
+	})
+
+	t.Run("Accounts are filtered locally", func(t *testing.T) {
+		mockClient := mocks.NewCatalogClient(t)
+		catalog := NewCatalog(newLogger, mockClient)
+		response := accountsPage(1, 1, 1, 2)
+		response.Data[1].Server = "NA1"
+		mockClient.EXPECT().GetAvailablePage(query, 1, "").Return(response, "", false, nil).Once()
+
+		page, err := catalog.GetAccounts(query, types.AccountsFilter{Server: "NA1"})
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, accountIDs(page))
+		assert.Equal(t, 2, page.Loaded)
+	})
+}
@@ -1,12 +1,21 @@
 package account
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hex-boost/hex-nexus-app/backend/client"
 	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/tools/lolskin"
 	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/riot/region"
 	"github.com/hex-boost/hex-nexus-app/backend/types"
 	"go.uber.org/zap"
 )
@@ -15,6 +24,17 @@ type Client struct {
 	api    *client.HTTPClient
 	logger *logger.Logger
 	cfg    *config.Config
+
+	saltMutex    sync.Mutex
+	usernameSalt string
+
+	userMutex     sync.Mutex
+	userListeners []UserListener
+}
+
+// UserListener is told which Nexus user is logged in, the user id is 0 after a logout
+type UserListener interface {
+	SetActiveUser(userID int)
 }
 
 func NewClient(logger *logger.Logger, cfg *config.Config, api *client.HTTPClient) *Client {
@@ -38,6 +58,8 @@ func (s *Client) Save(summoner types.PartialSummonerRented) (*types.SummonerResp
 	if summoner.Username == "" {
 		return nil, fmt.Errorf("username is required")
 	}
+	// Credentials are never sent back to the backend
+	summoner.Password = nil
 	apiTokenClient := s.GetApiTokenClient()
 	var refreshResponseData types.RefreshResponseData
 	req := apiTokenClient.R().SetBody(summoner).SetResult(&refreshResponseData)
@@ -72,18 +94,153 @@ func (s *Client) GetAll() ([]types.SummonerBase, error) {
 	}
 	return response.Data, nil
 }
+
+// GetAvailablePage fetches a page of available accounts, notModified is true when the etag still matches
+func (s *Client) GetAvailablePage(query types.AccountsQuery, page int, etag string) (response *types.AccountsPageResponse, newEtag string, notModified bool, err error) {
+	params := map[string]string{
+		"pagination[page]": strconv.Itoa(page),
+	}
+	if query.PageSize > 0 {
+		params["pagination[pageSize]"] = strconv.Itoa(query.PageSize)
+	}
+	if query.Server != "" {
+		params["filters[server][$eq]"] = region.PlatformID(query.Server)
+	}
+	if query.Tier != "" {
+		params["filters[rankedStats][RANKED_SOLO_5x5][tier][$eqi]"] = query.Tier
+	}
+	if query.Search != "" {
+		params["filters[gamename][$containsi]"] = query.Search
+	}
+	if query.Sort != "" {
+		params["sort"] = query.Sort
+	}
+
+	var result types.AccountsPageResponse
+	req := s.api.Client.R().SetQueryParams(params).SetResult(&result)
+	if etag != "" {
+		req.SetHeader("If-None-Match", etag)
+	}
+	resp, err := req.Get("/api/accounts/available")
+	if err != nil {
+		s.logger.Error("error fetching available accounts", zap.Int("page", page), zap.Error(err))
+		return nil, "", false, err
+	}
+	if resp.StatusCode() == http.StatusNotModified {
+		return nil, etag, true, nil
+	}
+	if resp.IsError() {
+		s.logger.Error("error fetching available accounts", zap.Int("statusCode", resp.StatusCode()), zap.Any("body", resp.String()))
+		return nil, "", false, fmt.Errorf("error fetching available accounts: %d - %s", resp.StatusCode(), resp.String())
+	}
+	return &result, resp.Header().Get("ETag"), false, nil
+}
+
+// SaveSessionSummary uploads the usage summary of a finished rental session
+func (s *Client) SaveSessionSummary(summary types.SessionSummary) error {
+	var response map[string]interface{}
+	_, err := s.api.Post("/api/accounts/sessions", summary, &response)
+	if err != nil {
+		s.logger.Error("error saving session summary", zap.String("sessionId", summary.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// SaveSkinProfiles replaces the skin profiles of the logged in user
+func (s *Client) SaveSkinProfiles(profiles []lolskin.SkinProfile) error {
+	var response map[string]interface{}
+	_, err := s.api.Put("/api/users/me/skin-profiles", map[string]interface{}{"profiles": profiles}, &response)
+	if err != nil {
+		s.logger.Error("error saving skin profiles", zap.Int("count", len(profiles)), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetSkinSources returns the ordered skin sources of the remote config and the checksums of the skins
+func (s *Client) GetSkinSources() (*lolskin.SkinSourcesConfig, error) {
+	var response lolskin.SkinSourcesConfig
+	_, err := s.api.Get("/api/skin-sources", &response)
+	if err != nil {
+		s.logger.Error("error fetching skin sources", zap.Error(err))
+		return nil, err
+	}
+	return &response, nil
+}
+
+// AddUserListener registers a listener of the logged in Nexus user, it's told on every UserMe
+func (s *Client) AddUserListener(listener UserListener) {
+	s.userMutex.Lock()
+	defer s.userMutex.Unlock()
+	s.userListeners = append(s.userListeners, listener)
+}
+
 func (s *Client) UserMe() (*types.User, error) {
 	var response types.User
 	_, err := s.api.Get("/api/users/me", &response)
 	if err != nil {
 		return nil, err
 	}
+	s.setActiveUser(response.Id)
 	return &response, nil
 }
+
+// ClearUser tells the listeners that no Nexus user is logged in
+func (s *Client) ClearUser() {
+	s.setActiveUser(0)
+}
+
+func (s *Client) setActiveUser(userID int) {
+	s.userMutex.Lock()
+	defer s.userMutex.Unlock()
+	for _, listener := range s.userListeners {
+		listener.SetActiveUser(userID)
+	}
+}
+
+// HashUsername returns the keyed hash used to look up a username without sending it in plaintext,
+// the key is the salt issued by the backend
+func HashUsername(salt string, username string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(username))))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// getUsernameSalt returns the salt of the username lookups, it's fetched once and kept until the
+// backend rotates it
+func (s *Client) getUsernameSalt() (string, error) {
+	s.saltMutex.Lock()
+	defer s.saltMutex.Unlock()
+	if s.usernameSalt != "" {
+		return s.usernameSalt, nil
+	}
+
+	var result struct {
+		Salt string `json:"salt"`
+	}
+	response, err := s.GetApiTokenClient().R().SetResult(&result).Get("/api/accounts/usernames/salt")
+	if err != nil {
+		return "", err
+	}
+	if response.IsError() {
+		return "", fmt.Errorf("error getting username salt: %d - %s", response.StatusCode(), response.String())
+	}
+	if result.Salt == "" {
+		return "", fmt.Errorf("backend returned an empty username salt")
+	}
+	s.usernameSalt = result.Salt
+	return s.usernameSalt, nil
+}
+
 func (s *Client) UsernameExistsInDatabase(username string) (bool, error) {
+	salt, err := s.getUsernameSalt()
+	if err != nil {
+		return false, fmt.Errorf("failed to get username salt: %w", err)
+	}
 	var result bool
 	apiTokenClient := s.GetApiTokenClient()
-	endpoint := fmt.Sprintf("/api/accounts/usernames/%s", username)
+	endpoint := fmt.Sprintf("/api/accounts/usernames/hashed/%s", HashUsername(salt, username))
 	response, err := apiTokenClient.R().SetResult(&result).Post(endpoint)
 	if err != nil {
 		return false, err
@@ -93,6 +250,12 @@ func (s *Client) UsernameExistsInDatabase(username string) (bool, error) {
 		if response.StatusCode() == 404 {
 			return false, nil
 		}
+		if response.StatusCode() == http.StatusConflict {
+			// The salt was rotated, the next lookup uses the new one
+			s.saltMutex.Lock()
+			s.usernameSalt = ""
+			s.saltMutex.Unlock()
+		}
 		s.logger.Warn("error checking if username exists in database", zap.Int("statusCode", response.StatusCode()), zap.Any("body", response.String()))
 		return false, fmt.Errorf("error checking if username exists in database: %d - %s", response.StatusCode(), response.String())
 	}
@@ -0,0 +1,185 @@
+package account
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hex-boost/hex-nexus-app/backend/client"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/account/mocks"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// usernameBackend serves the salt and the hashed username lookups of the backend, hashes made with
+// previousSalt are a conflict
+type usernameBackend struct {
+	salt         atomic.Value
+	previousSalt string
+	saltStatus   int
+	saltFetches  atomic.Int32
+	usernames    []string
+}
+
+func (b *usernameBackend) hashes(salt string, hash string) bool {
+	for _, username := range b.usernames {
+		mac := hmac.New(sha256.New, []byte(salt))
+		mac.Write([]byte(username))
+		if hex.EncodeToString(mac.Sum(nil)) == hash {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *usernameBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer api-key" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.URL.Path == "/api/accounts/usernames/salt" {
+		b.saltFetches.Add(1)
+		if b.saltStatus != 0 {
+			w.WriteHeader(b.saltStatus)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"salt": "` + b.salt.Load().(string) + `"}`))
+		return
+	}
+	hash, ok := strings.CutPrefix(r.URL.Path, "/api/accounts/usernames/hashed/")
+	if !ok || r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if b.hashes(b.salt.Load().(string), hash) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("true"))
+		return
+	}
+	if b.previousSalt != "" && b.hashes(b.previousSalt, hash) {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func TestHashUsername(t *testing.T) {
+	t.Run("Usernames are normalized", func(t *testing.T) {
+		assert.Equal(t, HashUsername("salt", "nexus1"), HashUsername("salt", " Nexus1 "))
+	})
+
+	t.Run("Salt keys the hash", func(t *testing.T) {
+		assert.NotEqual(t, HashUsername("salt", "nexus1"), HashUsername("other", "nexus1"))
+		assert.NotEqual(t, HashUsername("", "nexus1"), HashUsername("salt", "nexus1"))
+		assert.Len(t, HashUsername("salt", "nexus1"), sha256.Size*2)
+	})
+}
+
+func TestClient_UsernameExistsInDatabase(t *testing.T) {
+	newLogger := logger.New("TestAccountClient", &config.Config{LogLevel: "error"})
+
+	t.Run("Lookups use the salt issued by the backend", func(t *testing.T) {
+		backend := &usernameBackend{usernames: []string{"nexus1"}}
+		backend.salt.Store("server-salt")
+		server := httptest.NewServer(backend)
+		defer server.Close()
+		cfg := &config.Config{LogLevel: "error", BackendURL: server.URL, RefreshApiKey: "api-key"}
+		client := NewClient(newLogger, cfg, nil)
+
+		exists, err := client.UsernameExistsInDatabase("Nexus1")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = client.UsernameExistsInDatabase("personal")
+		require.NoError(t, err)
+		assert.False(t, exists, "unknown usernames are a 404")
+		assert.Equal(t, int32(1), backend.saltFetches.Load(), "the salt is fetched once")
+	})
+
+	t.Run("Lookup fails without a salt", func(t *testing.T) {
+		backend := &usernameBackend{saltStatus: http.StatusServiceUnavailable}
+		server := httptest.NewServer(backend)
+		defer server.Close()
+		cfg := &config.Config{LogLevel: "error", BackendURL: server.URL, RefreshApiKey: "api-key"}
+		client := NewClient(newLogger, cfg, nil)
+
+		_, err := client.UsernameExistsInDatabase("nexus1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get username salt")
+
+		backend.saltStatus = 0
+		backend.salt.Store("")
+		_, err = client.UsernameExistsInDatabase("nexus1")
+		assert.ErrorContains(t, err, "empty username salt")
+	})
+
+	t.Run("Rotated salt is fetched again", func(t *testing.T) {
+		backend := &usernameBackend{usernames: []string{"nexus1"}}
+		backend.salt.Store("old-salt")
+		server := httptest.NewServer(backend)
+		defer server.Close()
+		cfg := &config.Config{LogLevel: "error", BackendURL: server.URL, RefreshApiKey: "api-key"}
+		client := NewClient(newLogger, cfg, nil)
+		_, err := client.UsernameExistsInDatabase("nexus1")
+		require.NoError(t, err)
+
+		backend.previousSalt = "old-salt"
+		backend.salt.Store("new-salt")
+		_, err = client.UsernameExistsInDatabase("nexus1")
+		assert.Error(t, err)
+
+		exists, err := client.UsernameExistsInDatabase("nexus1")
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, int32(2), backend.saltFetches.Load())
+	})
+}
+
+func TestClient_UserMe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/users/me" || r.Header.Get("Authorization") != "Bearer user-jwt" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 42, "username": "nexus"}`))
+	}))
+	t.Cleanup(server.Close)
+	cfg := &config.Config{LogLevel: "error", BackendURL: server.URL}
+	newLogger := logger.New("TestAccountClient", cfg)
+	baseClient := client.NewBaseClient(newLogger, cfg)
+
+	t.Run("Listeners follow the logged in user", func(t *testing.T) {
+		mockListener := mocks.NewUserListener(t)
+		mockListener.EXPECT().SetActiveUser(42).Return().Once()
+		mockListener.EXPECT().SetActiveUser(0).Return().Once()
+
+		accountClient := NewClient(newLogger, cfg, client.NewHTTPClient(baseClient))
+		accountClient.AddUserListener(mockListener)
+		baseClient.SetJWT("user-jwt")
+
+		user, err := accountClient.UserMe()
+		require.NoError(t, err)
+		assert.Equal(t, 42, user.Id)
+		accountClient.ClearUser()
+	})
+
+	t.Run("Listeners are not told about a failed request", func(t *testing.T) {
+		mockListener := mocks.NewUserListener(t)
+
+		accountClient := NewClient(newLogger, cfg, client.NewHTTPClient(baseClient))
+		accountClient.AddUserListener(mockListener)
+		baseClient.SetJWT("expired-jwt")
+
+		_, err := accountClient.UserMe()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,148 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccountClient is an autogenerated mock type for the AccountClient type
+type AccountClient struct {
+	mock.Mock
+}
+
+type AccountClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AccountClient) EXPECT() *AccountClient_Expecter {
+	return &AccountClient_Expecter{mock: &_m.Mock}
+}
+
+// GetAll provides a mock function with no fields
+func (_m *AccountClient) GetAll() ([]types.SummonerBase, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []types.SummonerBase
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]types.SummonerBase, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []types.SummonerBase); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.SummonerBase)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccountClient_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type AccountClient_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+func (_e *AccountClient_Expecter) GetAll() *AccountClient_GetAll_Call {
+	return &AccountClient_GetAll_Call{Call: _e.mock.On("GetAll")}
+}
+
+func (_c *AccountClient_GetAll_Call) Run(run func()) *AccountClient_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AccountClient_GetAll_Call) Return(_a0 []types.SummonerBase, _a1 error) *AccountClient_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccountClient_GetAll_Call) RunAndReturn(run func() ([]types.SummonerBase, error)) *AccountClient_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsernameExistsInDatabase provides a mock function with given fields: username
+func (_m *AccountClient) UsernameExistsInDatabase(username string) (bool, error) {
+	ret := _m.Called(username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsernameExistsInDatabase")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(username)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccountClient_UsernameExistsInDatabase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsernameExistsInDatabase'
+type AccountClient_UsernameExistsInDatabase_Call struct {
+	*mock.Call
+}
+
+// UsernameExistsInDatabase is a helper method to define mock.On call
+//   - username string
+func (_e *AccountClient_Expecter) UsernameExistsInDatabase(username interface{}) *AccountClient_UsernameExistsInDatabase_Call {
+	return &AccountClient_UsernameExistsInDatabase_Call{Call: _e.mock.On("UsernameExistsInDatabase", username)}
+}
+
+func (_c *AccountClient_UsernameExistsInDatabase_Call) Run(run func(username string)) *AccountClient_UsernameExistsInDatabase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *AccountClient_UsernameExistsInDatabase_Call) Return(_a0 bool, _a1 error) *AccountClient_UsernameExistsInDatabase_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccountClient_UsernameExistsInDatabase_Call) RunAndReturn(run func(string) (bool, error)) *AccountClient_UsernameExistsInDatabase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAccountClient creates a new instance of AccountClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountClient {
+	mock := &AccountClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
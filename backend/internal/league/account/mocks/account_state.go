@@ -0,0 +1,231 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccountState is an autogenerated mock type for the AccountState type
+type AccountState struct {
+	mock.Mock
+}
+
+type AccountState_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AccountState) EXPECT() *AccountState_Expecter {
+	return &AccountState_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with no fields
+func (_m *AccountState) Get() *types.PartialSummonerRented {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *types.PartialSummonerRented
+	if rf, ok := ret.Get(0).(func() *types.PartialSummonerRented); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.PartialSummonerRented)
+		}
+	}
+
+	return r0
+}
+
+// AccountState_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type AccountState_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+func (_e *AccountState_Expecter) Get() *AccountState_Get_Call {
+	return &AccountState_Get_Call{Call: _e.mock.On("Get")}
+}
+
+func (_c *AccountState_Get_Call) Run(run func()) *AccountState_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AccountState_Get_Call) Return(_a0 *types.PartialSummonerRented) *AccountState_Get_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AccountState_Get_Call) RunAndReturn(run func() *types.PartialSummonerRented) *AccountState_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsNexusAccount provides a mock function with no fields
+func (_m *AccountState) IsNexusAccount() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsNexusAccount")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// AccountState_IsNexusAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsNexusAccount'
+type AccountState_IsNexusAccount_Call struct {
+	*mock.Call
+}
+
+// IsNexusAccount is a helper method to define mock.On call
+func (_e *AccountState_Expecter) IsNexusAccount() *AccountState_IsNexusAccount_Call {
+	return &AccountState_IsNexusAccount_Call{Call: _e.mock.On("IsNexusAccount")}
+}
+
+func (_c *AccountState_IsNexusAccount_Call) Run(run func()) *AccountState_IsNexusAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AccountState_IsNexusAccount_Call) Return(_a0 bool) *AccountState_IsNexusAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AccountState_IsNexusAccount_Call) RunAndReturn(run func() bool) *AccountState_IsNexusAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNexusAccount provides a mock function with given fields: _a0
+func (_m *AccountState) SetNexusAccount(_a0 bool) bool {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNexusAccount")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(bool) bool); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// AccountState_SetNexusAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNexusAccount'
+type AccountState_SetNexusAccount_Call struct {
+	*mock.Call
+}
+
+// SetNexusAccount is a helper method to define mock.On call
+//   - _a0 bool
+func (_e *AccountState_Expecter) SetNexusAccount(_a0 interface{}) *AccountState_SetNexusAccount_Call {
+	return &AccountState_SetNexusAccount_Call{Call: _e.mock.On("SetNexusAccount", _a0)}
+}
+
+func (_c *AccountState_SetNexusAccount_Call) Run(run func(_a0 bool)) *AccountState_SetNexusAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(bool))
+	})
+	return _c
+}
+
+func (_c *AccountState_SetNexusAccount_Call) Return(_a0 bool) *AccountState_SetNexusAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AccountState_SetNexusAccount_Call) RunAndReturn(run func(bool) bool) *AccountState_SetNexusAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: summonerRented
+func (_m *AccountState) Update(summonerRented *types.PartialSummonerRented) (*types.PartialSummonerRented, error) {
+	ret := _m.Called(summonerRented)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *types.PartialSummonerRented
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*types.PartialSummonerRented) (*types.PartialSummonerRented, error)); ok {
+		return rf(summonerRented)
+	}
+	if rf, ok := ret.Get(0).(func(*types.PartialSummonerRented) *types.PartialSummonerRented); ok {
+		r0 = rf(summonerRented)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.PartialSummonerRented)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*types.PartialSummonerRented) error); ok {
+		r1 = rf(summonerRented)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccountState_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type AccountState_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - summonerRented *types.PartialSummonerRented
+func (_e *AccountState_Expecter) Update(summonerRented interface{}) *AccountState_Update_Call {
+	return &AccountState_Update_Call{Call: _e.mock.On("Update", summonerRented)}
+}
+
+func (_c *AccountState_Update_Call) Run(run func(summonerRented *types.PartialSummonerRented)) *AccountState_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*types.PartialSummonerRented))
+	})
+	return _c
+}
+
+func (_c *AccountState_Update_Call) Return(_a0 *types.PartialSummonerRented, _a1 error) *AccountState_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccountState_Update_Call) RunAndReturn(run func(*types.PartialSummonerRented) (*types.PartialSummonerRented, error)) *AccountState_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAccountState creates a new instance of AccountState. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountState(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountState {
+	mock := &AccountState{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,109 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CatalogClient is an autogenerated mock type for the CatalogClient type
+type CatalogClient struct {
+	mock.Mock
+}
+
+type CatalogClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CatalogClient) EXPECT() *CatalogClient_Expecter {
+	return &CatalogClient_Expecter{mock: &_m.Mock}
+}
+
+// GetAvailablePage provides a mock function with given fields: query, page, etag
+func (_m *CatalogClient) GetAvailablePage(query types.AccountsQuery, page int, etag string) (*types.AccountsPageResponse, string, bool, error) {
+	ret := _m.Called(query, page, etag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAvailablePage")
+	}
+
+	var r0 *types.AccountsPageResponse
+	var r1 string
+	var r2 bool
+	var r3 error
+	if rf, ok := ret.Get(0).(func(types.AccountsQuery, int, string) (*types.AccountsPageResponse, string, bool, error)); ok {
+		return rf(query, page, etag)
+	}
+	if rf, ok := ret.Get(0).(func(types.AccountsQuery, int, string) *types.AccountsPageResponse); ok {
+		r0 = rf(query, page, etag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.AccountsPageResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(types.AccountsQuery, int, string) string); ok {
+		r1 = rf(query, page, etag)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(types.AccountsQuery, int, string) bool); ok {
+		r2 = rf(query, page, etag)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	if rf, ok := ret.Get(3).(func(types.AccountsQuery, int, string) error); ok {
+		r3 = rf(query, page, etag)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// CatalogClient_GetAvailablePage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAvailablePage'
+type CatalogClient_GetAvailablePage_Call struct {
+	*mock.Call
+}
+
+// GetAvailablePage is a helper method to define mock.On call
+//   - query types.AccountsQuery
+//   - page int
+//   - etag string
+func (_e *CatalogClient_Expecter) GetAvailablePage(query interface{}, page interface{}, etag interface{}) *CatalogClient_GetAvailablePage_Call {
+	return &CatalogClient_GetAvailablePage_Call{Call: _e.mock.On("GetAvailablePage", query, page, etag)}
+}
+
+func (_c *CatalogClient_GetAvailablePage_Call) Run(run func(query types.AccountsQuery, page int, etag string)) *CatalogClient_GetAvailablePage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(types.AccountsQuery), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *CatalogClient_GetAvailablePage_Call) Return(_a0 *types.AccountsPageResponse, _a1 string, _a2 bool, _a3 error) *CatalogClient_GetAvailablePage_Call {
+	_c.Call.Return(_a0, _a1, _a2, _a3)
+	return _c
+}
+
+func (_c *CatalogClient_GetAvailablePage_Call) RunAndReturn(run func(types.AccountsQuery, int, string) (*types.AccountsPageResponse, string, bool, error)) *CatalogClient_GetAvailablePage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCatalogClient creates a new instance of CatalogClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCatalogClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CatalogClient {
+	mock := &CatalogClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,137 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	resty "github.com/go-resty/resty/v2"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LCUConnection is an autogenerated mock type for the LCUConnection type
+type LCUConnection struct {
+	mock.Mock
+}
+
+type LCUConnection_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LCUConnection) EXPECT() *LCUConnection_Expecter {
+	return &LCUConnection_Expecter{mock: &_m.Mock}
+}
+
+// GetClient provides a mock function with no fields
+func (_m *LCUConnection) GetClient() (*resty.Client, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClient")
+	}
+
+	var r0 *resty.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*resty.Client, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *resty.Client); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*resty.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LCUConnection_GetClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClient'
+type LCUConnection_GetClient_Call struct {
+	*mock.Call
+}
+
+// GetClient is a helper method to define mock.On call
+func (_e *LCUConnection_Expecter) GetClient() *LCUConnection_GetClient_Call {
+	return &LCUConnection_GetClient_Call{Call: _e.mock.On("GetClient")}
+}
+
+func (_c *LCUConnection_GetClient_Call) Run(run func()) *LCUConnection_GetClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *LCUConnection_GetClient_Call) Return(_a0 *resty.Client, _a1 error) *LCUConnection_GetClient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LCUConnection_GetClient_Call) RunAndReturn(run func() (*resty.Client, error)) *LCUConnection_GetClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsClientInitialized provides a mock function with no fields
+func (_m *LCUConnection) IsClientInitialized() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsClientInitialized")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// LCUConnection_IsClientInitialized_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsClientInitialized'
+type LCUConnection_IsClientInitialized_Call struct {
+	*mock.Call
+}
+
+// IsClientInitialized is a helper method to define mock.On call
+func (_e *LCUConnection_Expecter) IsClientInitialized() *LCUConnection_IsClientInitialized_Call {
+	return &LCUConnection_IsClientInitialized_Call{Call: _e.mock.On("IsClientInitialized")}
+}
+
+func (_c *LCUConnection_IsClientInitialized_Call) Run(run func()) *LCUConnection_IsClientInitialized_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *LCUConnection_IsClientInitialized_Call) Return(_a0 bool) *LCUConnection_IsClientInitialized_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LCUConnection_IsClientInitialized_Call) RunAndReturn(run func() bool) *LCUConnection_IsClientInitialized_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLCUConnection creates a new instance of LCUConnection. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLCUConnection(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LCUConnection {
+	mock := &LCUConnection{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
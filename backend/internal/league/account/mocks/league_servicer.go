@@ -0,0 +1,122 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// LeagueServicer is an autogenerated mock type for the LeagueServicer type
+type LeagueServicer struct {
+	mock.Mock
+}
+
+type LeagueServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LeagueServicer) EXPECT() *LeagueServicer_Expecter {
+	return &LeagueServicer_Expecter{mock: &_m.Mock}
+}
+
+// IsPlaying provides a mock function with no fields
+func (_m *LeagueServicer) IsPlaying() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsPlaying")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// LeagueServicer_IsPlaying_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsPlaying'
+type LeagueServicer_IsPlaying_Call struct {
+	*mock.Call
+}
+
+// IsPlaying is a helper method to define mock.On call
+func (_e *LeagueServicer_Expecter) IsPlaying() *LeagueServicer_IsPlaying_Call {
+	return &LeagueServicer_IsPlaying_Call{Call: _e.mock.On("IsPlaying")}
+}
+
+func (_c *LeagueServicer_IsPlaying_Call) Run(run func()) *LeagueServicer_IsPlaying_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *LeagueServicer_IsPlaying_Call) Return(_a0 bool) *LeagueServicer_IsPlaying_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LeagueServicer_IsPlaying_Call) RunAndReturn(run func() bool) *LeagueServicer_IsPlaying_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsRunning provides a mock function with no fields
+func (_m *LeagueServicer) IsRunning() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsRunning")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// LeagueServicer_IsRunning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRunning'
+type LeagueServicer_IsRunning_Call struct {
+	*mock.Call
+}
+
+// IsRunning is a helper method to define mock.On call
+func (_e *LeagueServicer_Expecter) IsRunning() *LeagueServicer_IsRunning_Call {
+	return &LeagueServicer_IsRunning_Call{Call: _e.mock.On("IsRunning")}
+}
+
+func (_c *LeagueServicer_IsRunning_Call) Run(run func()) *LeagueServicer_IsRunning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *LeagueServicer_IsRunning_Call) Return(_a0 bool) *LeagueServicer_IsRunning_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LeagueServicer_IsRunning_Call) RunAndReturn(run func() bool) *LeagueServicer_IsRunning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLeagueServicer creates a new instance of LeagueServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLeagueServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LeagueServicer {
+	mock := &LeagueServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,320 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RiotAuthenticator is an autogenerated mock type for the RiotAuthenticator type
+type RiotAuthenticator struct {
+	mock.Mock
+}
+
+type RiotAuthenticator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RiotAuthenticator) EXPECT() *RiotAuthenticator_Expecter {
+	return &RiotAuthenticator_Expecter{mock: &_m.Mock}
+}
+
+// GetAuthenticationState provides a mock function with no fields
+func (_m *RiotAuthenticator) GetAuthenticationState() (*types.RiotIdentityResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthenticationState")
+	}
+
+	var r0 *types.RiotIdentityResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.RiotIdentityResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.RiotIdentityResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RiotIdentityResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RiotAuthenticator_GetAuthenticationState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthenticationState'
+type RiotAuthenticator_GetAuthenticationState_Call struct {
+	*mock.Call
+}
+
+// GetAuthenticationState is a helper method to define mock.On call
+func (_e *RiotAuthenticator_Expecter) GetAuthenticationState() *RiotAuthenticator_GetAuthenticationState_Call {
+	return &RiotAuthenticator_GetAuthenticationState_Call{Call: _e.mock.On("GetAuthenticationState")}
+}
+
+func (_c *RiotAuthenticator_GetAuthenticationState_Call) Run(run func()) *RiotAuthenticator_GetAuthenticationState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RiotAuthenticator_GetAuthenticationState_Call) Return(_a0 *types.RiotIdentityResponse, _a1 error) *RiotAuthenticator_GetAuthenticationState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RiotAuthenticator_GetAuthenticationState_Call) RunAndReturn(run func() (*types.RiotIdentityResponse, error)) *RiotAuthenticator_GetAuthenticationState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserinfo provides a mock function with no fields
+func (_m *RiotAuthenticator) GetUserinfo() (*types.UserInfo, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserinfo")
+	}
+
+	var r0 *types.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.UserInfo, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.UserInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RiotAuthenticator_GetUserinfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserinfo'
+type RiotAuthenticator_GetUserinfo_Call struct {
+	*mock.Call
+}
+
+// GetUserinfo is a helper method to define mock.On call
+func (_e *RiotAuthenticator_Expecter) GetUserinfo() *RiotAuthenticator_GetUserinfo_Call {
+	return &RiotAuthenticator_GetUserinfo_Call{Call: _e.mock.On("GetUserinfo")}
+}
+
+func (_c *RiotAuthenticator_GetUserinfo_Call) Run(run func()) *RiotAuthenticator_GetUserinfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RiotAuthenticator_GetUserinfo_Call) Return(_a0 *types.UserInfo, _a1 error) *RiotAuthenticator_GetUserinfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RiotAuthenticator_GetUserinfo_Call) RunAndReturn(run func() (*types.UserInfo, error)) *RiotAuthenticator_GetUserinfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InitializeClient provides a mock function with no fields
+func (_m *RiotAuthenticator) InitializeClient() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitializeClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RiotAuthenticator_InitializeClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InitializeClient'
+type RiotAuthenticator_InitializeClient_Call struct {
+	*mock.Call
+}
+
+// InitializeClient is a helper method to define mock.On call
+func (_e *RiotAuthenticator_Expecter) InitializeClient() *RiotAuthenticator_InitializeClient_Call {
+	return &RiotAuthenticator_InitializeClient_Call{Call: _e.mock.On("InitializeClient")}
+}
+
+func (_c *RiotAuthenticator_InitializeClient_Call) Run(run func()) *RiotAuthenticator_InitializeClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RiotAuthenticator_InitializeClient_Call) Return(_a0 error) *RiotAuthenticator_InitializeClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RiotAuthenticator_InitializeClient_Call) RunAndReturn(run func() error) *RiotAuthenticator_InitializeClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsClientInitialized provides a mock function with no fields
+func (_m *RiotAuthenticator) IsClientInitialized() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsClientInitialized")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// RiotAuthenticator_IsClientInitialized_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsClientInitialized'
+type RiotAuthenticator_IsClientInitialized_Call struct {
+	*mock.Call
+}
+
+// IsClientInitialized is a helper method to define mock.On call
+func (_e *RiotAuthenticator_Expecter) IsClientInitialized() *RiotAuthenticator_IsClientInitialized_Call {
+	return &RiotAuthenticator_IsClientInitialized_Call{Call: _e.mock.On("IsClientInitialized")}
+}
+
+func (_c *RiotAuthenticator_IsClientInitialized_Call) Run(run func()) *RiotAuthenticator_IsClientInitialized_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RiotAuthenticator_IsClientInitialized_Call) Return(_a0 bool) *RiotAuthenticator_IsClientInitialized_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RiotAuthenticator_IsClientInitialized_Call) RunAndReturn(run func() bool) *RiotAuthenticator_IsClientInitialized_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsRunning provides a mock function with no fields
+func (_m *RiotAuthenticator) IsRunning() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsRunning")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// RiotAuthenticator_IsRunning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRunning'
+type RiotAuthenticator_IsRunning_Call struct {
+	*mock.Call
+}
+
+// IsRunning is a helper method to define mock.On call
+func (_e *RiotAuthenticator_Expecter) IsRunning() *RiotAuthenticator_IsRunning_Call {
+	return &RiotAuthenticator_IsRunning_Call{Call: _e.mock.On("IsRunning")}
+}
+
+func (_c *RiotAuthenticator_IsRunning_Call) Run(run func()) *RiotAuthenticator_IsRunning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RiotAuthenticator_IsRunning_Call) Return(_a0 bool) *RiotAuthenticator_IsRunning_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RiotAuthenticator_IsRunning_Call) RunAndReturn(run func() bool) *RiotAuthenticator_IsRunning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchAuthentication provides a mock function with given fields: ctx, onChange
+func (_m *RiotAuthenticator) WatchAuthentication(ctx context.Context, onChange func()) {
+	_m.Called(ctx, onChange)
+}
+
+// RiotAuthenticator_WatchAuthentication_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchAuthentication'
+type RiotAuthenticator_WatchAuthentication_Call struct {
+	*mock.Call
+}
+
+// WatchAuthentication is a helper method to define mock.On call
+//   - ctx context.Context
+//   - onChange func()
+func (_e *RiotAuthenticator_Expecter) WatchAuthentication(ctx interface{}, onChange interface{}) *RiotAuthenticator_WatchAuthentication_Call {
+	return &RiotAuthenticator_WatchAuthentication_Call{Call: _e.mock.On("WatchAuthentication", ctx, onChange)}
+}
+
+func (_c *RiotAuthenticator_WatchAuthentication_Call) Run(run func(ctx context.Context, onChange func())) *RiotAuthenticator_WatchAuthentication_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func()))
+	})
+	return _c
+}
+
+func (_c *RiotAuthenticator_WatchAuthentication_Call) Return() *RiotAuthenticator_WatchAuthentication_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *RiotAuthenticator_WatchAuthentication_Call) RunAndReturn(run func(context.Context, func())) *RiotAuthenticator_WatchAuthentication_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewRiotAuthenticator creates a new instance of RiotAuthenticator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRiotAuthenticator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RiotAuthenticator {
+	mock := &RiotAuthenticator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// SessionRecorder is an autogenerated mock type for the SessionRecorder type
+type SessionRecorder struct {
+	mock.Mock
+}
+
+type SessionRecorder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SessionRecorder) EXPECT() *SessionRecorder_Expecter {
+	return &SessionRecorder_Expecter{mock: &_m.Mock}
+}
+
+// End provides a mock function with no fields
+func (_m *SessionRecorder) End() {
+	_m.Called()
+}
+
+// SessionRecorder_End_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'End'
+type SessionRecorder_End_Call struct {
+	*mock.Call
+}
+
+// End is a helper method to define mock.On call
+func (_e *SessionRecorder_Expecter) End() *SessionRecorder_End_Call {
+	return &SessionRecorder_End_Call{Call: _e.mock.On("End")}
+}
+
+func (_c *SessionRecorder_End_Call) Run(run func()) *SessionRecorder_End_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *SessionRecorder_End_Call) Return() *SessionRecorder_End_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *SessionRecorder_End_Call) RunAndReturn(run func()) *SessionRecorder_End_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: username
+func (_m *SessionRecorder) Start(username string) {
+	_m.Called(username)
+}
+
+// SessionRecorder_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type SessionRecorder_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - username string
+func (_e *SessionRecorder_Expecter) Start(username interface{}) *SessionRecorder_Start_Call {
+	return &SessionRecorder_Start_Call{Call: _e.mock.On("Start", username)}
+}
+
+func (_c *SessionRecorder_Start_Call) Run(run func(username string)) *SessionRecorder_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *SessionRecorder_Start_Call) Return() *SessionRecorder_Start_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *SessionRecorder_Start_Call) RunAndReturn(run func(string)) *SessionRecorder_Start_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewSessionRecorder creates a new instance of SessionRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessionRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SessionRecorder {
+	mock := &SessionRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
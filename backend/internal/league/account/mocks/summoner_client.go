@@ -0,0 +1,149 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SummonerClient is an autogenerated mock type for the SummonerClient type
+type SummonerClient struct {
+	mock.Mock
+}
+
+type SummonerClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SummonerClient) EXPECT() *SummonerClient_Expecter {
+	return &SummonerClient_Expecter{mock: &_m.Mock}
+}
+
+// GetCurrentSummoner provides a mock function with no fields
+func (_m *SummonerClient) GetCurrentSummoner() (*types.CurrentSummoner, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrentSummoner")
+	}
+
+	var r0 *types.CurrentSummoner
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.CurrentSummoner, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.CurrentSummoner); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CurrentSummoner)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SummonerClient_GetCurrentSummoner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCurrentSummoner'
+type SummonerClient_GetCurrentSummoner_Call struct {
+	*mock.Call
+}
+
+// GetCurrentSummoner is a helper method to define mock.On call
+func (_e *SummonerClient_Expecter) GetCurrentSummoner() *SummonerClient_GetCurrentSummoner_Call {
+	return &SummonerClient_GetCurrentSummoner_Call{Call: _e.mock.On("GetCurrentSummoner")}
+}
+
+func (_c *SummonerClient_GetCurrentSummoner_Call) Run(run func()) *SummonerClient_GetCurrentSummoner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *SummonerClient_GetCurrentSummoner_Call) Return(_a0 *types.CurrentSummoner, _a1 error) *SummonerClient_GetCurrentSummoner_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SummonerClient_GetCurrentSummoner_Call) RunAndReturn(run func() (*types.CurrentSummoner, error)) *SummonerClient_GetCurrentSummoner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoginSession provides a mock function with no fields
+func (_m *SummonerClient) GetLoginSession() (*types.LoginSession, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoginSession")
+	}
+
+	var r0 *types.LoginSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.LoginSession, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.LoginSession); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.LoginSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SummonerClient_GetLoginSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoginSession'
+type SummonerClient_GetLoginSession_Call struct {
+	*mock.Call
+}
+
+// GetLoginSession is a helper method to define mock.On call
+func (_e *SummonerClient_Expecter) GetLoginSession() *SummonerClient_GetLoginSession_Call {
+	return &SummonerClient_GetLoginSession_Call{Call: _e.mock.On("GetLoginSession")}
+}
+
+func (_c *SummonerClient_GetLoginSession_Call) Run(run func()) *SummonerClient_GetLoginSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *SummonerClient_GetLoginSession_Call) Return(_a0 *types.LoginSession, _a1 error) *SummonerClient_GetLoginSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SummonerClient_GetLoginSession_Call) RunAndReturn(run func() (*types.LoginSession, error)) *SummonerClient_GetLoginSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSummonerClient creates a new instance of SummonerClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSummonerClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SummonerClient {
+	mock := &SummonerClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,65 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// UserListener is an autogenerated mock type for the UserListener type
+type UserListener struct {
+	mock.Mock
+}
+
+type UserListener_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserListener) EXPECT() *UserListener_Expecter {
+	return &UserListener_Expecter{mock: &_m.Mock}
+}
+
+// SetActiveUser provides a mock function with given fields: userID
+func (_m *UserListener) SetActiveUser(userID int) {
+	_m.Called(userID)
+}
+
+// UserListener_SetActiveUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActiveUser'
+type UserListener_SetActiveUser_Call struct {
+	*mock.Call
+}
+
+// SetActiveUser is a helper method to define mock.On call
+//   - userID int
+func (_e *UserListener_Expecter) SetActiveUser(userID interface{}) *UserListener_SetActiveUser_Call {
+	return &UserListener_SetActiveUser_Call{Call: _e.mock.On("SetActiveUser", userID)}
+}
+
+func (_c *UserListener_SetActiveUser_Call) Run(run func(userID int)) *UserListener_SetActiveUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *UserListener_SetActiveUser_Call) Return() *UserListener_SetActiveUser_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *UserListener_SetActiveUser_Call) RunAndReturn(run func(int)) *UserListener_SetActiveUser_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewUserListener creates a new instance of UserListener. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserListener(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserListener {
+	mock := &UserListener{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,78 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// WatchdogUpdater is an autogenerated mock type for the WatchdogUpdater type
+type WatchdogUpdater struct {
+	mock.Mock
+}
+
+type WatchdogUpdater_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WatchdogUpdater) EXPECT() *WatchdogUpdater_Expecter {
+	return &WatchdogUpdater_Expecter{mock: &_m.Mock}
+}
+
+// Update provides a mock function with given fields: active
+func (_m *WatchdogUpdater) Update(active bool) error {
+	ret := _m.Called(active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bool) error); ok {
+		r0 = rf(active)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WatchdogUpdater_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type WatchdogUpdater_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - active bool
+func (_e *WatchdogUpdater_Expecter) Update(active interface{}) *WatchdogUpdater_Update_Call {
+	return &WatchdogUpdater_Update_Call{Call: _e.mock.On("Update", active)}
+}
+
+func (_c *WatchdogUpdater_Update_Call) Run(run func(active bool)) *WatchdogUpdater_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(bool))
+	})
+	return _c
+}
+
+func (_c *WatchdogUpdater_Update_Call) Return(_a0 error) *WatchdogUpdater_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WatchdogUpdater_Update_Call) RunAndReturn(run func(bool) error) *WatchdogUpdater_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWatchdogUpdater creates a new instance of WatchdogUpdater. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWatchdogUpdater(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WatchdogUpdater {
+	mock := &WatchdogUpdater{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
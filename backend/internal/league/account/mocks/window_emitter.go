@@ -0,0 +1,76 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// WindowEmitter is an autogenerated mock type for the WindowEmitter type
+type WindowEmitter struct {
+	mock.Mock
+}
+
+type WindowEmitter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WindowEmitter) EXPECT() *WindowEmitter_Expecter {
+	return &WindowEmitter_Expecter{mock: &_m.Mock}
+}
+
+// EmitEvent provides a mock function with given fields: eventName, data
+func (_m *WindowEmitter) EmitEvent(eventName string, data ...interface{}) {
+	var _ca []interface{}
+	_ca = append(_ca, eventName)
+	_ca = append(_ca, data...)
+	_m.Called(_ca...)
+}
+
+// WindowEmitter_EmitEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EmitEvent'
+type WindowEmitter_EmitEvent_Call struct {
+	*mock.Call
+}
+
+// EmitEvent is a helper method to define mock.On call
+//   - eventName string
+//   - data ...interface{}
+func (_e *WindowEmitter_Expecter) EmitEvent(eventName interface{}, data ...interface{}) *WindowEmitter_EmitEvent_Call {
+	return &WindowEmitter_EmitEvent_Call{Call: _e.mock.On("EmitEvent",
+		append([]interface{}{eventName}, data...)...)}
+}
+
+func (_c *WindowEmitter_EmitEvent_Call) Run(run func(eventName string, data ...interface{})) *WindowEmitter_EmitEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *WindowEmitter_EmitEvent_Call) Return() *WindowEmitter_EmitEvent_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *WindowEmitter_EmitEvent_Call) RunAndReturn(run func(string, ...interface{})) *WindowEmitter_EmitEvent_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewWindowEmitter creates a new instance of WindowEmitter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWindowEmitter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WindowEmitter {
+	mock := &WindowEmitter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -29,6 +29,7 @@ type RiotAuthenticator interface {
 	InitializeClient() error
 	GetAuthenticationState() (*types.RiotIdentityResponse, error)
 	GetUserinfo() (*types.UserInfo, error)
+	WatchAuthentication(ctx context.Context, onChange func())
 }
 
 // LeagueServiceInterface defines methods needed from LeagueService
@@ -68,6 +69,19 @@ type LeagueServicer interface {
 	IsRunning() bool
 	IsPlaying() bool
 }
+
+// SessionRecorder defines methods needed to track rental sessions
+type SessionRecorder interface {
+	Start(username string)
+	End()
+}
+
+// usernameLookup is a cached result of UsernameExistsInDatabase
+type usernameLookup struct {
+	isNexusAccount bool
+	fetchedAt      time.Time
+}
+
 type Monitor struct {
 	riotAuth          RiotAuthenticator
 	accountClient     AccountClient
@@ -86,6 +100,11 @@ type Monitor struct {
 	LCUConnection     LCUConnection
 	eventChan         chan EventPayload
 	ctx               context.Context
+	sessionRecorder   SessionRecorder
+	usernameCache     map[string]usernameLookup
+	cacheMutex        sync.Mutex
+	checkMutex        sync.Mutex
+	cancelWatch       context.CancelFunc
 }
 
 type WatchdogUpdater interface {
@@ -113,7 +132,8 @@ func NewMonitor(
 		accountClient:   accountClient,
 		accountCacheTTL: 1 * time.Hour,
 		accountState:    accountState,
-		checkInterval:   1 * time.Second,
+		checkInterval:   30 * time.Second, // Fallback only, changes are detected through websocket events
+		usernameCache:   make(map[string]usernameLookup),
 		stopChan:        make(chan struct{}),
 		eventChan:       make(chan EventPayload, 5), // Buffer for 100 events
 		ctx:             context.Background(),
@@ -136,6 +156,10 @@ func (m *Monitor) OnShutdown(ctx context.Context, options application.ServiceOpt
 func (m *Monitor) SetWindow(window WindowEmitter) {
 	m.window = window
 }
+
+func (m *Monitor) SetSessionRecorder(recorder SessionRecorder) {
+	m.sessionRecorder = recorder
+}
 func (m *Monitor) Start(window WindowEmitter) {
 	m.window = window
 	m.logger.Debug("Starting account monitor")
@@ -148,8 +172,11 @@ func (m *Monitor) Start(window WindowEmitter) {
 
 	m.running = true
 	m.stopChan = make(chan struct{})
+	watchCtx, cancel := context.WithCancel(m.ctx)
+	m.cancelWatch = cancel
 
 	go m.monitorLoop()
+	go m.riotAuth.WatchAuthentication(watchCtx, m.onRiotAuthenticationChanged)
 	m.logger.Debug("State monitor started")
 }
 
@@ -162,6 +189,9 @@ func (m *Monitor) Stop() {
 	}
 
 	close(m.stopChan)
+	if m.cancelWatch != nil {
+		m.cancelWatch()
+	}
 	m.running = false
 	m.logger.Info("State monitor stopped")
 }
@@ -172,6 +202,8 @@ func (m *Monitor) monitorLoop() {
 
 	m.logger.Debug("State monitor loop started", zap.Duration("checkInterval", m.checkInterval))
 
+	// Events only report changes, resolve whoever is already logged in
+	m.checkCurrentAccount()
 	for {
 		select {
 		case <-ticker.C:
@@ -250,11 +282,28 @@ func (m *Monitor) GetLoggedInUsername(lastUsername string) string {
 	return strings.ToLower(currentUsername)
 }
 
+// checkCurrentAccount polls the clients for the logged in username, used as a fallback for missed events
 func (m *Monitor) checkCurrentAccount() {
-
 	currentAccount := m.accountState.Get()
+	m.OnUsernameDetected(m.GetLoggedInUsername(currentAccount.Username))
+}
+
+// onRiotAuthenticationChanged is called by the Riot client websocket, the league client login
+// session events take precedence while it is running
+func (m *Monitor) onRiotAuthenticationChanged() {
+	if m.leagueService.IsRunning() {
+		return
+	}
+	m.OnUsernameDetected(strings.ToLower(m.getSummonerNameByRiotClient()))
+}
+
+// OnUsernameDetected updates the account state when the logged in username changes
+func (m *Monitor) OnUsernameDetected(loggedInUsername string) {
+	m.checkMutex.Lock()
+	defer m.checkMutex.Unlock()
 
-	loggedInUsername := m.GetLoggedInUsername(currentAccount.Username)
+	loggedInUsername = strings.ToLower(loggedInUsername)
+	currentAccount := m.accountState.Get()
 	if loggedInUsername == "" || currentAccount.Username == loggedInUsername {
 		return
 	} else {
@@ -266,7 +315,7 @@ func (m *Monitor) checkCurrentAccount() {
 		zap.String("current", loggedInUsername))
 	currentAccount, _ = m.accountState.Update(&types.PartialSummonerRented{Username: loggedInUsername})
 
-	isNexusAccount, err := m.accountClient.UsernameExistsInDatabase(strings.ToLower(currentAccount.Username))
+	isNexusAccount, err := m.isNexusUsername(currentAccount.Username)
 	if err != nil {
 		m.logger.Warn("Failed to check if username exists in database", zap.Error(err))
 		return
@@ -275,6 +324,27 @@ func (m *Monitor) checkCurrentAccount() {
 	m.SetNexusAccount(isNexusAccount)
 }
 
+// isNexusUsername checks the backend for the username, caching the answer for accountCacheTTL
+func (m *Monitor) isNexusUsername(username string) (bool, error) {
+	m.cacheMutex.Lock()
+	cached, ok := m.usernameCache[username]
+	m.cacheMutex.Unlock()
+	if ok && time.Since(cached.fetchedAt) < m.accountCacheTTL {
+		return cached.isNexusAccount, nil
+	}
+
+	isNexusAccount, err := m.accountClient.UsernameExistsInDatabase(username)
+	if err != nil {
+		return false, err
+	}
+
+	m.cacheMutex.Lock()
+	m.usernameCache[username] = usernameLookup{isNexusAccount: isNexusAccount, fetchedAt: time.Now()}
+	m.lastAccountsFetch = time.Now()
+	m.cacheMutex.Unlock()
+	return isNexusAccount, nil
+}
+
 func (m *Monitor) IsNexusAccount() bool {
 	return m.accountState.IsNexusAccount()
 }
@@ -326,4 +396,12 @@ func (m *Monitor) SetNexusAccount(isNexusAccount bool) {
 				zap.Bool("isNexusAccount", currentStatus))
 		}
 	}
+
+	if m.sessionRecorder != nil {
+		if currentStatus {
+			m.sessionRecorder.Start(m.accountState.Get().Username)
+		} else {
+			m.sessionRecorder.End()
+		}
+	}
 }
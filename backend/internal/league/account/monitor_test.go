@@ -18,12 +18,10 @@ import (
 )
 
 func TestAccountMonitor_CheckCurrentAccount(t *testing.T) {
-	// Setup environment and logger
 	cfg := &config.Config{LogLevel: "debug"}
 	newLogger := logger.New("TestAccountMonitor", cfg)
 
 	t.Run("No clients running - should skip check", func(t *testing.T) {
-		// Setup
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
@@ -31,7 +29,6 @@ func TestAccountMonitor_CheckCurrentAccount(t *testing.T) {
 		mockLCU := mocks.NewLCUConnection(t)
 		mockWatchdog := mocks.NewWatchdogUpdater(t)
 		mockAccountState := mocks.NewAccountState(t)
-		mockWindow := mocks.NewWindowEmitter(t)
 
 		am := NewMonitor(
 			newLogger,
@@ -44,33 +41,24 @@ func TestAccountMonitor_CheckCurrentAccount(t *testing.T) {
 			mockAccountState,
 		)
 
-		// Setup window
-		am.window = mockWindow
-		am.mutex = sync.Mutex{}
-
-		// Setup expected behavior
-		mockRiot.On("IsRunning").Return(false)
-		mockAccountState.On("SetNexusAccount", false).Return(false)
-		mockAccountState.On("IsNexusAccount").Return(false) // Add this line - no params needed
+		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "testuser"})
 		mockLeague.On("IsRunning").Return(false)
 		mockLeague.On("IsPlaying").Return(false)
+		mockRiot.On("IsRunning").Return(false)
 
-		// Execute function under test
 		am.checkCurrentAccount()
 
-		// These should not be called
-		mockRepo.AssertNotCalled(t, "GetAllRented")
-		mockWindow.AssertNotCalled(t, "EmitEvent")
+		mockRepo.AssertNotCalled(t, "UsernameExistsInDatabase", mock.Anything)
+		mockAccountState.AssertNotCalled(t, "Update", mock.Anything)
 	})
+
 	t.Run("No username found - should skip check", func(t *testing.T) {
-		// Setup
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
 		mockSummoner := mocks.NewSummonerClient(t)
 		mockLCU := mocks.NewLCUConnection(t)
 		mockWatchdog := mocks.NewWatchdogUpdater(t)
-		mockWindow := mocks.NewWindowEmitter(t)
 		mockAccountState := mocks.NewAccountState(t)
 
 		am := NewMonitor(
@@ -84,34 +72,25 @@ func TestAccountMonitor_CheckCurrentAccount(t *testing.T) {
 			mockAccountState,
 		)
 
-		// Setup window
-		am.window = mockWindow
-		am.mutex = sync.Mutex{}
-
+		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: ""})
+		mockLeague.On("IsRunning").Return(false)
+		mockLeague.On("IsPlaying").Return(false)
 		mockRiot.On("IsRunning").Return(true)
 		mockRiot.On("IsClientInitialized").Return(true)
-
-		mockAccountState.On("SetNexusAccount", false).Return(false)
-		mockAccountState.On("IsNexusAccount").Return(false)
 		mockRiot.On("GetAuthenticationState").Return(&types.RiotIdentityResponse{Type: "error"}, nil)
-		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: ""})
 
-		// Execute function under test
 		am.checkCurrentAccount()
 
-		// Verify expectations
-		mockRepo.AssertNotCalled(t, "GetAllRented")
+		mockRepo.AssertNotCalled(t, "UsernameExistsInDatabase", mock.Anything)
 	})
 
-	t.Run("Error getting accounts from repository", func(t *testing.T) {
-		// Setup
+	t.Run("Playing keeps the last username", func(t *testing.T) {
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
 		mockSummoner := mocks.NewSummonerClient(t)
 		mockLCU := mocks.NewLCUConnection(t)
 		mockWatchdog := mocks.NewWatchdogUpdater(t)
-		mockWindow := mocks.NewWindowEmitter(t)
 		mockAccountState := mocks.NewAccountState(t)
 
 		am := NewMonitor(
@@ -125,43 +104,23 @@ func TestAccountMonitor_CheckCurrentAccount(t *testing.T) {
 			mockAccountState,
 		)
 
-		// Setup window and additional fields
-		am.window = mockWindow
-		am.mutex = sync.Mutex{}
-		am.lastAccountsFetch = time.Time{} // Empty time to force cache refresh
-		am.accountCacheTTL = 5 * time.Minute
-
-		// Setup expected behavior
-		mockRiot.On("IsRunning").Return(true)
-		mockRiot.On("IsClientInitialized").Return(true)
-		mockRiot.On("GetAuthenticationState").Return(&types.RiotIdentityResponse{Type: "success"}, nil)
-		mockRiot.On("GetUserinfo").Return(&types.UserInfo{Username: "testuser"}, nil)
-		mockRepo.On("GetAllRented").Return([]types.SummonerRented{}, errors.New("database error"))
-		mockAccountState.On("Update", mock.AnythingOfType("*types.PartialSummonerRented")).Return(&types.PartialSummonerRented{Username: "testuser"}, nil)
-
-		// Add the missing expectations:
-		mockAccountState.On("SetNexusAccount", false).Return(false)
-		mockAccountState.On("IsNexusAccount").Return(false)
-		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "fsda"})
+		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "testuser"})
+		mockLeague.On("IsRunning").Return(false)
+		mockLeague.On("IsPlaying").Return(true)
 
-		// Execute function under test
 		am.checkCurrentAccount()
 
-		// Verify expectations
-		mockRiot.AssertExpectations(t)
-		mockRepo.AssertExpectations(t)
-		mockAccountState.AssertExpectations(t)
-		assert.False(t, am.IsNexusAccount())
+		mockRepo.AssertNotCalled(t, "UsernameExistsInDatabase", mock.Anything)
+		mockAccountState.AssertNotCalled(t, "Update", mock.Anything)
 	})
-	t.Run("Account is not a Nexus account", func(t *testing.T) {
-		// Setup
+
+	t.Run("Riot client username is checked against the backend", func(t *testing.T) {
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
 		mockSummoner := mocks.NewSummonerClient(t)
 		mockLCU := mocks.NewLCUConnection(t)
 		mockWatchdog := mocks.NewWatchdogUpdater(t)
-		mockWindow := mocks.NewWindowEmitter(t)
 		mockAccountState := mocks.NewAccountState(t)
 
 		am := NewMonitor(
@@ -175,44 +134,37 @@ func TestAccountMonitor_CheckCurrentAccount(t *testing.T) {
 			mockAccountState,
 		)
 
-		// Setup window and additional fields
-		am.window = mockWindow
-		am.mutex = sync.Mutex{}
-		am.lastAccountsFetch = time.Time{} // Empty time to force cache refresh
-		am.accountCacheTTL = 5 * time.Minute
-
-		// Setup expected behavior
+		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "previous"})
+		mockLeague.On("IsRunning").Return(false)
+		mockLeague.On("IsPlaying").Return(false)
 		mockRiot.On("IsRunning").Return(true)
 		mockRiot.On("IsClientInitialized").Return(true)
 		mockRiot.On("GetAuthenticationState").Return(&types.RiotIdentityResponse{Type: "success"}, nil)
-		mockRiot.On("GetUserinfo").Return(&types.UserInfo{Username: "testuser"}, nil)
-		mockRepo.On("GetAllRented").Return([]types.SummonerRented{
-			{Username: "otheruser"},
-			{Username: "anotheruser"},
-		}, nil)
-		mockAccountState.On("SetNexusAccount", false).Return(false)
-		mockAccountState.On("IsNexusAccount").Return(false)
-		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "fodase"})
-		mockAccountState.On("Update", mock.AnythingOfType("*types.PartialSummonerRented")).Return(&types.PartialSummonerRented{Username: "testuser"}, nil)
+		mockRiot.On("GetUserinfo").Return(&types.UserInfo{Username: "TestUser"}, nil)
+		mockAccountState.On("Update", &types.PartialSummonerRented{}).Return(&types.PartialSummonerRented{}, nil).Once()
+		mockAccountState.On("Update", &types.PartialSummonerRented{Username: "testuser"}).Return(&types.PartialSummonerRented{Username: "testuser"}, nil).Once()
+		mockRepo.On("UsernameExistsInDatabase", "testuser").Return(true, nil).Once()
+		mockAccountState.On("SetNexusAccount", true).Return(true)
+		mockAccountState.On("IsNexusAccount").Return(true)
+		mockWatchdog.On("Update", true).Return(nil)
 
-		// Execute function under test
 		am.checkCurrentAccount()
 
-		// Verify expectations
-		mockRiot.AssertExpectations(t)
-		mockRepo.AssertExpectations(t)
-		assert.False(t, am.IsNexusAccount())
+		assert.Equal(t, EventPayload{EventName: "nexusAccount:state", Data: []interface{}{true}}, <-am.eventChan)
 	})
+}
 
-	t.Run("Account is a Nexus account - state change triggers event", func(t *testing.T) {
-		// Setup
+func TestMonitor_OnUsernameDetected(t *testing.T) {
+	cfg := &config.Config{LogLevel: "debug"}
+	newLogger := logger.New("TestAccountMonitor", cfg)
+
+	t.Run("Same username is ignored", func(t *testing.T) {
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
 		mockSummoner := mocks.NewSummonerClient(t)
 		mockLCU := mocks.NewLCUConnection(t)
 		mockWatchdog := mocks.NewWatchdogUpdater(t)
-		mockWindow := mocks.NewWindowEmitter(t)
 		mockAccountState := mocks.NewAccountState(t)
 
 		am := NewMonitor(
@@ -225,52 +177,22 @@ func TestAccountMonitor_CheckCurrentAccount(t *testing.T) {
 			mockRepo,
 			mockAccountState,
 		)
+		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "testuser"})
 
-		// Setup window and additional fields
-		am.window = mockWindow
-		am.mutex = sync.Mutex{}
-		am.lastAccountsFetch = time.Time{} // Empty time to force cache refresh
-		am.accountCacheTTL = 5 * time.Minute
-
-		// Setup expected behavior
-		mockRiot.On("IsRunning").Return(true)
-		mockRiot.On("IsClientInitialized").Return(true)
-		mockRiot.On("GetAuthenticationState").Return(&types.RiotIdentityResponse{Type: "success"}, nil)
-		mockRiot.On("GetUserinfo").Return(&types.UserInfo{Username: "testuser"}, nil)
-		mockRepo.On("GetAllRented").Return([]types.SummonerRented{
-			{Username: "otheruser"},
-			{Username: "testuser"}, // Match!
-			{Username: "anotheruser"},
-		}, nil)
-		mockWindow.On("EmitEvent", "nexusAccount:state", true).Return()
-		mockWatchdog.On("Update", true).Return(nil)
-		mockAccountState.On("SetNexusAccount", true).Return(true)
-		mockAccountState.On("IsNexusAccount").Return(true)
-		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "foadse"})
-		mockAccountState.On("Update", mock.AnythingOfType("*types.PartialSummonerRented")).Return(&types.PartialSummonerRented{Username: "testuser"}, nil)
-
-		// Execute function under test
-		am.checkCurrentAccount()
+		am.OnUsernameDetected("TestUser")
 
-		// Verify expectations
-		mockRiot.AssertExpectations(t)
-		mockRepo.AssertExpectations(t)
-		mockWindow.AssertExpectations(t)
-		mockWatchdog.AssertExpectations(t) // Verify watchdog expectations too
-		assert.True(t, am.IsNexusAccount())
+		mockAccountState.AssertNotCalled(t, "Update", mock.Anything)
+		mockRepo.AssertNotCalled(t, "UsernameExistsInDatabase", mock.Anything)
 	})
 
-	t.Run("Use cached accounts when cache is fresh", func(t *testing.T) {
-		// Setup
+	t.Run("Empty username is ignored", func(t *testing.T) {
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
 		mockSummoner := mocks.NewSummonerClient(t)
 		mockLCU := mocks.NewLCUConnection(t)
 		mockWatchdog := mocks.NewWatchdogUpdater(t)
-
 		mockAccountState := mocks.NewAccountState(t)
-		mockWindow := mocks.NewWindowEmitter(t)
 
 		am := NewMonitor(
 			newLogger,
@@ -282,47 +204,21 @@ func TestAccountMonitor_CheckCurrentAccount(t *testing.T) {
 			mockRepo,
 			mockAccountState,
 		)
-
-		cachedAccounts := []types.SummonerRented{
-			{Username: "testuser"}, // This matches the username returned by mock Riot client
-		}
-
-		// Setup window and additional fields
-		am.SetWindow(mockWindow)
-		am.mutex = sync.Mutex{}
-		am.cachedAccounts = cachedAccounts
-		am.lastAccountsFetch = time.Now() // Fresh cache
-		am.accountCacheTTL = 5 * time.Minute
-
-		// Setup expected behavior
-		mockRiot.On("IsRunning").Return(true)
-		mockRiot.On("IsClientInitialized").Return(true)
-		mockRiot.On("GetAuthenticationState").Return(&types.RiotIdentityResponse{Type: "success"}, nil)
-		mockRiot.On("GetUserinfo").Return(&types.UserInfo{Username: "testuser"}, nil)
-
-		// Change this to match the Riot username to avoid triggering cache refresh
 		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "testuser"})
-		mockAccountState.On("SetNexusAccount", true).Return(false)
-		mockAccountState.On("IsNexusAccount").Return(true)
 
-		// Execute function under test
-		am.checkCurrentAccount()
+		am.OnUsernameDetected("")
 
-		// Verify expectations
-		mockRiot.AssertExpectations(t)
-		mockWatchdog.AssertExpectations(t)
-		mockRepo.AssertNotCalled(t, "GetAllRented") // Should use cache
-		assert.True(t, am.IsNexusAccount())
+		mockAccountState.AssertNotCalled(t, "Update", mock.Anything)
+		mockRepo.AssertNotCalled(t, "UsernameExistsInDatabase", mock.Anything)
 	})
-	t.Run("Reset cache when user is disconnected", func(t *testing.T) {
-		// Setup
+
+	t.Run("Nexus username marks the account and starts the session", func(t *testing.T) {
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
 		mockSummoner := mocks.NewSummonerClient(t)
 		mockLCU := mocks.NewLCUConnection(t)
 		mockWatchdog := mocks.NewWatchdogUpdater(t)
-		mockWindow := mocks.NewWindowEmitter(t)
 		mockAccountState := mocks.NewAccountState(t)
 
 		am := NewMonitor(
@@ -335,47 +231,25 @@ func TestAccountMonitor_CheckCurrentAccount(t *testing.T) {
 			mockRepo,
 			mockAccountState,
 		)
+		mockRecorder := mocks.NewSessionRecorder(t)
+		am.SetSessionRecorder(mockRecorder)
 
-		// Create some initial cached accounts
-		initialCache := []types.SummonerRented{
-			{Username: "cacheduser1"},
-			{Username: "cacheduser2"},
-		}
-
-		// Set a past time for lastAccountsFetch
-		pastTime := time.Now().Add(-1 * time.Hour)
-		// Setup window and additional fields
-		am.window = mockWindow
-		am.mutex = sync.Mutex{}
-		am.cachedAccounts = initialCache // Set initial cache
-		am.lastAccountsFetch = pastTime  // Set initial fetch time
-		am.accountCacheTTL = 5 * time.Minute
-
-		// Setup expected behavior - all clients are not running
-		mockRiot.On("IsRunning").Return(false)
-		mockLeague.On("IsRunning").Return(false)
-		mockLeague.On("IsPlaying").Return(false)
-		mockAccountState.On("SetNexusAccount", false).Return(false)
-		mockAccountState.On("IsNexusAccount").Return(false) // Add this line to fix the error
-
-		// Execute function under test
-		am.checkCurrentAccount()
+		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "previous"}).Once()
+		mockAccountState.On("Update", &types.PartialSummonerRented{}).Return(&types.PartialSummonerRented{}, nil).Once()
+		mockAccountState.On("Update", &types.PartialSummonerRented{Username: "nexus1"}).Return(&types.PartialSummonerRented{Username: "nexus1"}, nil).Once()
+		mockRepo.On("UsernameExistsInDatabase", "nexus1").Return(true, nil).Once()
+		mockAccountState.On("SetNexusAccount", true).Return(true)
+		mockAccountState.On("IsNexusAccount").Return(true)
+		mockWatchdog.On("Update", true).Return(nil)
+		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "nexus1"}).Once()
+		mockRecorder.On("Start", "nexus1").Return().Once()
 
-		// Verify expectations
-		mockRiot.AssertExpectations(t)
-		mockLeague.AssertExpectations(t)
+		am.OnUsernameDetected("Nexus1")
 
-		// Verify cache was reset
-		assert.Empty(t, am.cachedAccounts, "Cache should be empty when user disconnects")
-		assert.NotEqual(t, pastTime, am.lastAccountsFetch, "Last fetch time should be updated when user disconnects")
+		assert.Equal(t, EventPayload{EventName: "nexusAccount:state", Data: []interface{}{true}}, <-am.eventChan)
 	})
-}
-
-func TestMonitor_GetAccountsWithCache(t *testing.T) {
-	cfg := &config.Config{LogLevel: "debug"}
-	newLogger := logger.New("TestGetAccountsWithCache", cfg)
 
-	t.Run("Empty cache should fetch from repository", func(t *testing.T) {
+	t.Run("Other username ends the session", func(t *testing.T) {
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
@@ -394,26 +268,24 @@ func TestMonitor_GetAccountsWithCache(t *testing.T) {
 			mockRepo,
 			mockAccountState,
 		)
+		mockRecorder := mocks.NewSessionRecorder(t)
+		am.SetSessionRecorder(mockRecorder)
+
+		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "nexus1"})
+		mockAccountState.On("Update", &types.PartialSummonerRented{}).Return(&types.PartialSummonerRented{}, nil).Once()
+		mockAccountState.On("Update", &types.PartialSummonerRented{Username: "personal"}).Return(&types.PartialSummonerRented{Username: "personal"}, nil).Once()
+		mockRepo.On("UsernameExistsInDatabase", "personal").Return(false, nil).Once()
+		mockAccountState.On("SetNexusAccount", false).Return(true)
+		mockAccountState.On("IsNexusAccount").Return(false)
+		mockWatchdog.On("Update", false).Return(nil)
+		mockRecorder.On("End").Return().Once()
 
-		mockRepo.On("GetAllRented").Return([]types.SummonerRented{{Username: "user1"}}, nil)
-
-		am.cachedAccounts = []types.SummonerRented{} // Empty cache
-		am.lastAccountsFetch = time.Time{}           // Zero time
-		am.accountCacheTTL = 5 * time.Minute
-		am.mutex = sync.Mutex{}
-
-		// Execute
-		accounts, err := am.getAccountsWithCache()
+		am.OnUsernameDetected("personal")
 
-		// Verify
-		assert.NoError(t, err)
-		assert.Len(t, accounts, 1)
-		assert.Equal(t, "user1", accounts[0].Username)
-		mockRepo.AssertExpectations(t)
-		assert.False(t, am.lastAccountsFetch.IsZero()) // Should be updated
+		assert.Equal(t, EventPayload{EventName: "nexusAccount:state", Data: []interface{}{false}}, <-am.eventChan)
 	})
 
-	t.Run("Expired cache should fetch from repository", func(t *testing.T) {
+	t.Run("Backend error leaves the nexus status", func(t *testing.T) {
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
@@ -433,23 +305,23 @@ func TestMonitor_GetAccountsWithCache(t *testing.T) {
 			mockAccountState,
 		)
 
-		mockRepo.On("GetAllRented").Return([]types.SummonerRented{{Username: "user2"}}, nil)
+		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "previous"})
+		mockAccountState.On("Update", &types.PartialSummonerRented{}).Return(&types.PartialSummonerRented{}, nil).Once()
+		mockAccountState.On("Update", &types.PartialSummonerRented{Username: "testuser"}).Return(&types.PartialSummonerRented{Username: "testuser"}, nil).Once()
+		mockRepo.On("UsernameExistsInDatabase", "testuser").Return(false, errors.New("backend error")).Once()
 
-		am.lastAccountsFetch = time.Now().Add(-10 * time.Minute)
-		am.accountCacheTTL = 5 * time.Minute
-		am.mutex = sync.Mutex{}
-
-		// Execute
-		accounts, err := am.getAccountsWithCache()
+		am.OnUsernameDetected("testuser")
 
-		// Verify
-		assert.NoError(t, err)
-		assert.Len(t, accounts, 1)
-		assert.Equal(t, "user2", accounts[0].Username)
-		mockRepo.AssertExpectations(t)
+		mockAccountState.AssertNotCalled(t, "SetNexusAccount", mock.Anything)
+		assert.Empty(t, am.eventChan)
 	})
+}
+
+func TestMonitor_OnRiotAuthenticationChanged(t *testing.T) {
+	cfg := &config.Config{LogLevel: "debug"}
+	newLogger := logger.New("TestAccountMonitor", cfg)
 
-	t.Run("Valid cache should not fetch from repository", func(t *testing.T) {
+	t.Run("League client running takes precedence", func(t *testing.T) {
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
@@ -468,25 +340,15 @@ func TestMonitor_GetAccountsWithCache(t *testing.T) {
 			mockRepo,
 			mockAccountState,
 		)
-		cachedData := []types.SummonerRented{{Username: "cached-user"}}
-
-		am.cachedAccounts = cachedData
-		am.lastAccountsFetch = time.Now().Add(-1 * time.Minute)
-		am.accountCacheTTL = 5 * time.Minute
-		am.mutex = sync.Mutex{}
+		mockLeague.On("IsRunning").Return(true)
 
-		// Execute
-		accounts, err := am.getAccountsWithCache()
+		am.onRiotAuthenticationChanged()
 
-		// Verify
-		assert.NoError(t, err)
-		assert.Len(t, accounts, 1)
-		assert.Equal(t, "cached-user", accounts[0].Username)
-		assert.Same(t, &cachedData[0], &accounts[0]) // Verify same slice reference
-		mockRepo.AssertNotCalled(t, "GetAllRented")
+		mockRiot.AssertNotCalled(t, "GetAuthenticationState")
+		mockAccountState.AssertNotCalled(t, "Get")
 	})
 
-	t.Run("Repository error should be propagated", func(t *testing.T) {
+	t.Run("Riot client username is detected", func(t *testing.T) {
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
@@ -505,31 +367,23 @@ func TestMonitor_GetAccountsWithCache(t *testing.T) {
 			mockRepo,
 			mockAccountState,
 		)
-		mockRepo.On("GetAllRented").Return([]types.SummonerRented{}, errors.New("repo error"))
-
-		am.cachedAccounts = []types.SummonerRented{} // Empty cache
-		am.lastAccountsFetch = time.Time{}           // Zero time
-		am.accountCacheTTL = 5 * time.Minute
-		am.mutex = sync.Mutex{}
+		mockLeague.On("IsRunning").Return(false)
+		mockRiot.On("IsClientInitialized").Return(true)
+		mockRiot.On("GetAuthenticationState").Return(&types.RiotIdentityResponse{Type: "success"}, nil)
+		mockRiot.On("GetUserinfo").Return(&types.UserInfo{Username: "TestUser"}, nil)
+		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "testuser"})
 
-		// Execute
-		accounts, err := am.getAccountsWithCache()
+		am.onRiotAuthenticationChanged()
 
-		// Verify
-		assert.Error(t, err)
-		assert.Equal(t, "repo error", err.Error())
-		assert.Empty(t, accounts)
-		mockRepo.AssertExpectations(t)
-		assert.Empty(t, am.cachedAccounts) // Cache should remain empty
+		mockRepo.AssertNotCalled(t, "UsernameExistsInDatabase", mock.Anything)
 	})
 }
 
-func TestMonitor_RefreshAccountCache(t *testing.T) {
+func TestMonitor_IsNexusUsername(t *testing.T) {
 	cfg := &config.Config{LogLevel: "debug"}
-	newLogger := logger.New("TestRefreshAccountCache", cfg)
+	newLogger := logger.New("TestAccountMonitor", cfg)
 
-	t.Run("Successful refresh", func(t *testing.T) {
-		// Setup
+	t.Run("Lookups are cached for the TTL", func(t *testing.T) {
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
@@ -548,24 +402,47 @@ func TestMonitor_RefreshAccountCache(t *testing.T) {
 			mockRepo,
 			mockAccountState,
 		)
-		accounts := []types.SummonerRented{{Username: "fresh-user"}}
-		mockRepo.On("GetAllRented").Return(accounts, nil)
+		mockRepo.On("UsernameExistsInDatabase", "testuser").Return(true, nil).Once()
 
-		oldTime := time.Now().Add(-1 * time.Hour)
+		for i := 0; i < 3; i++ {
+			isNexusAccount, err := am.isNexusUsername("testuser")
+			assert.NoError(t, err)
+			assert.True(t, isNexusAccount)
+		}
+		assert.False(t, am.lastAccountsFetch.IsZero())
+	})
 
-		am.cachedAccounts = []types.SummonerRented{{Username: "old-user"}}
-		am.lastAccountsFetch = oldTime
-		am.mutex = sync.Mutex{}
+	t.Run("Usernames are cached separately", func(t *testing.T) {
+		mockRepo := mocks.NewAccountClient(t)
+		mockLeague := mocks.NewLeagueServicer(t)
+		mockRiot := mocks.NewRiotAuthenticator(t)
+		mockSummoner := mocks.NewSummonerClient(t)
+		mockLCU := mocks.NewLCUConnection(t)
+		mockWatchdog := mocks.NewWatchdogUpdater(t)
+		mockAccountState := mocks.NewAccountState(t)
 
-		err := am.refreshAccountCache()
+		am := NewMonitor(
+			newLogger,
+			mockLeague,
+			mockRiot,
+			mockSummoner,
+			mockLCU,
+			mockWatchdog,
+			mockRepo,
+			mockAccountState,
+		)
+		mockRepo.On("UsernameExistsInDatabase", "nexus1").Return(true, nil).Once()
+		mockRepo.On("UsernameExistsInDatabase", "personal").Return(false, nil).Once()
 
+		isNexusAccount, err := am.isNexusUsername("nexus1")
+		assert.NoError(t, err)
+		assert.True(t, isNexusAccount)
+		isNexusAccount, err = am.isNexusUsername("personal")
 		assert.NoError(t, err)
-		assert.Equal(t, accounts, am.cachedAccounts)
-		assert.True(t, am.lastAccountsFetch.After(oldTime))
-		mockRepo.AssertExpectations(t)
+		assert.False(t, isNexusAccount)
 	})
 
-	t.Run("Repository error", func(t *testing.T) {
+	t.Run("Expired lookups are fetched again", func(t *testing.T) {
 		mockRepo := mocks.NewAccountClient(t)
 		mockLeague := mocks.NewLeagueServicer(t)
 		mockRiot := mocks.NewRiotAuthenticator(t)
@@ -584,23 +461,44 @@ func TestMonitor_RefreshAccountCache(t *testing.T) {
 			mockRepo,
 			mockAccountState,
 		)
+		am.usernameCache["testuser"] = usernameLookup{isNexusAccount: false, fetchedAt: time.Now().Add(-am.accountCacheTTL)}
+		mockRepo.On("UsernameExistsInDatabase", "testuser").Return(true, nil).Once()
 
-		mockRepo.On("GetAllRented").Return([]types.SummonerRented{}, errors.New("repo error"))
-
-		oldAccounts := []types.SummonerRented{{Username: "old-user"}}
-		oldTime := time.Now().Add(-1 * time.Hour)
+		isNexusAccount, err := am.isNexusUsername("testuser")
+		assert.NoError(t, err)
+		assert.True(t, isNexusAccount)
+		assert.True(t, am.usernameCache["testuser"].isNexusAccount)
+	})
 
-		am.cachedAccounts = oldAccounts
-		am.lastAccountsFetch = oldTime
-		am.mutex = sync.Mutex{}
+	t.Run("Errors are not cached", func(t *testing.T) {
+		mockRepo := mocks.NewAccountClient(t)
+		mockLeague := mocks.NewLeagueServicer(t)
+		mockRiot := mocks.NewRiotAuthenticator(t)
+		mockSummoner := mocks.NewSummonerClient(t)
+		mockLCU := mocks.NewLCUConnection(t)
+		mockWatchdog := mocks.NewWatchdogUpdater(t)
+		mockAccountState := mocks.NewAccountState(t)
 
-		err := am.refreshAccountCache()
+		am := NewMonitor(
+			newLogger,
+			mockLeague,
+			mockRiot,
+			mockSummoner,
+			mockLCU,
+			mockWatchdog,
+			mockRepo,
+			mockAccountState,
+		)
+		mockRepo.On("UsernameExistsInDatabase", "testuser").Return(false, errors.New("backend error")).Once()
+		mockRepo.On("UsernameExistsInDatabase", "testuser").Return(true, nil).Once()
 
+		_, err := am.isNexusUsername("testuser")
 		assert.Error(t, err)
-		assert.Equal(t, "repo error", err.Error())
-		assert.Equal(t, oldAccounts, am.cachedAccounts) // Should not change on error
-		assert.Equal(t, oldTime, am.lastAccountsFetch)  // Should not change on error
-		mockRepo.AssertExpectations(t)
+		assert.NotContains(t, am.usernameCache, "testuser")
+
+		isNexusAccount, err := am.isNexusUsername("testuser")
+		assert.NoError(t, err)
+		assert.True(t, isNexusAccount)
 	})
 }
 
@@ -629,6 +527,7 @@ func TestMonitor_GetSummonerNameByRiotClient(t *testing.T) {
 			mockAccountState,
 		)
 		mockRiot.On("IsClientInitialized").Return(false)
+		mockRiot.On("IsRunning").Return(true)
 		mockRiot.On("InitializeClient").Return(errors.New("initialization error"))
 
 		username := am.getSummonerNameByRiotClient()
@@ -658,6 +557,7 @@ func TestMonitor_GetSummonerNameByRiotClient(t *testing.T) {
 		)
 
 		mockRiot.On("IsClientInitialized").Return(false)
+		mockRiot.On("IsRunning").Return(true)
 		mockRiot.On("InitializeClient").Return(nil)
 		mockRiot.On("GetAuthenticationState").Return(&types.RiotIdentityResponse{Type: "success"}, nil)
 		mockRiot.On("GetUserinfo").Return(&types.UserInfo{Username: "testuser"}, nil)
@@ -811,7 +711,7 @@ func TestMonitor_GetUsernameByLeagueClient(t *testing.T) {
 		)
 
 		mockLCU.On("IsClientInitialized").Return(false)
-		mockLCU.On("Initialize").Return(errors.New("initialization error"))
+		mockLCU.On("GetClient").Return(nil, errors.New("initialization error"))
 
 		username, err := am.getUsernameByLeagueClient()
 		assert.Equal(t, "", username)
@@ -842,7 +742,7 @@ func TestMonitor_GetUsernameByLeagueClient(t *testing.T) {
 
 		// Use .Once() to specify that this expectation should only match once
 		mockLCU.On("IsClientInitialized").Return(false).Once()
-		mockLCU.On("Initialize").Return(nil)
+		mockLCU.On("GetClient").Return(nil, nil)
 		// Second call should return true
 		mockLCU.On("IsClientInitialized").Return(true).Once()
 		mockSummoner.On("GetLoginSession").Return(&types.LoginSession{Username: "testuser"}, nil)
@@ -909,6 +809,8 @@ func TestMonitor_GetLoggedInUsername(t *testing.T) {
 			mockAccountState,
 		)
 
+		mockLeague.On("IsRunning").Return(false)
+		mockLeague.On("IsPlaying").Return(false)
 		mockRiot.On("IsRunning").Return(true)
 		mockRiot.On("IsClientInitialized").Return(true)
 		mockRiot.On("GetAuthenticationState").Return(&types.RiotIdentityResponse{Type: "success"}, nil)
@@ -940,7 +842,6 @@ func TestMonitor_GetLoggedInUsername(t *testing.T) {
 			mockAccountState,
 		)
 
-		mockRiot.On("IsRunning").Return(false)
 		mockLeague.On("IsRunning").Return(true)
 		mockLCU.On("IsClientInitialized").Return(true)
 		mockSummoner.On("GetLoginSession").Return(&types.LoginSession{Username: "LeagueUser"}, nil)
@@ -972,7 +873,6 @@ func TestMonitor_GetLoggedInUsername(t *testing.T) {
 			mockAccountState,
 		)
 
-		mockRiot.On("IsRunning").Return(false)
 		mockLeague.On("IsRunning").Return(false)
 		mockLeague.On("IsPlaying").Return(true)
 
@@ -1040,7 +940,6 @@ func TestMonitor_SetNexusAccount(t *testing.T) {
 		)
 		mockAccountState.On("SetNexusAccount", true).Return(true)
 		mockAccountState.On("IsNexusAccount").Return(true)
-		mockWindow.On("EmitEvent", "nexusAccount:state", true).Return()
 		mockWatchdog.On("Update", true).Return(nil)
 
 		am.window = mockWindow
@@ -1049,7 +948,7 @@ func TestMonitor_SetNexusAccount(t *testing.T) {
 		am.SetNexusAccount(true)
 
 		assert.True(t, am.IsNexusAccount())
-		mockWindow.AssertExpectations(t)
+		assert.Equal(t, EventPayload{EventName: "nexusAccount:state", Data: []interface{}{true}}, <-am.eventChan)
 		mockWatchdog.AssertExpectations(t)
 	})
 
@@ -1081,7 +980,7 @@ func TestMonitor_SetNexusAccount(t *testing.T) {
 
 		am.SetNexusAccount(true)
 
-		mockWindow.AssertNotCalled(t, "EmitEvent")
+		assert.Empty(t, am.eventChan)
 		mockWatchdog.AssertNotCalled(t, "Update")
 	})
 
@@ -1108,7 +1007,6 @@ func TestMonitor_SetNexusAccount(t *testing.T) {
 
 		mockAccountState.On("SetNexusAccount", true).Return(true)
 		mockAccountState.On("IsNexusAccount").Return(true)
-		mockWindow.On("EmitEvent", "nexusAccount:state", true).Return()
 		mockWatchdog.On("Update", true).Return(errors.New("watchdog error"))
 
 		am.window = mockWindow
@@ -1117,7 +1015,7 @@ func TestMonitor_SetNexusAccount(t *testing.T) {
 		am.SetNexusAccount(true)
 
 		assert.True(t, am.IsNexusAccount())
-		mockWindow.AssertExpectations(t)
+		assert.Equal(t, EventPayload{EventName: "nexusAccount:state", Data: []interface{}{true}}, <-am.eventChan)
 		mockWatchdog.AssertExpectations(t)
 	})
 }
@@ -1152,6 +1050,12 @@ func TestMonitor_StartStop(t *testing.T) {
 		am.mutex = sync.Mutex{}
 		am.checkInterval = 1 * time.Second
 
+		// The first check of the loop finds the same account and the watcher waits for the context
+		mockRiot.On("WatchAuthentication", mock.Anything, mock.Anything).Return().Maybe()
+		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "testuser"}).Maybe()
+		mockLeague.On("IsRunning").Return(false).Maybe()
+		mockLeague.On("IsPlaying").Return(true).Maybe()
+
 		am.Start(mockWindow)
 
 		am.mutex.Lock()
@@ -1188,6 +1092,12 @@ func TestMonitor_StartStop(t *testing.T) {
 		am.mutex = sync.Mutex{}
 
 		// Start first
+		// The first check of the loop finds the same account and the watcher waits for the context
+		mockRiot.On("WatchAuthentication", mock.Anything, mock.Anything).Return().Maybe()
+		mockAccountState.On("Get").Return(&types.PartialSummonerRented{Username: "testuser"}).Maybe()
+		mockLeague.On("IsRunning").Return(false).Maybe()
+		mockLeague.On("IsPlaying").Return(true).Maybe()
+
 		am.Start(mockWindow)
 
 		// Then stop
@@ -45,7 +45,7 @@ func (s *Service) GetPath() string {
 		programData = "C:\\ProgramData"
 	}
 
-	leaguePath := filepath.Join(programData, "Riot Games", "Metadata", "league_of_legends.live", "league_of_legends.live.product_settings.yaml")
+	leaguePath := s.riotService.LaunchProfile().ProductSettingsPath(programData)
 	fileContent, err := os.ReadFile(leaguePath)
 	if err != nil {
 		s.logger.Error("Failed to read League settings file", zap.Error(err), zap.String("path", leaguePath))
@@ -0,0 +1,110 @@
+package leaverbuster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+)
+
+// Analyze parses the leaver buster entries into a typed penalty and predicts the next queue
+func Analyze(response *types.LeaverBusterResponse, now time.Time) types.LeaverBusterPenalty {
+	if response == nil {
+		penalty := types.LeaverBusterPenalty{Status: types.LeaverBusterClean}
+		penalty.NextQueue = forecast(penalty, now)
+		return penalty
+	}
+	entry := response.LeaverBusterEntryDto
+	leaverPenalty := entry.LeaverPenalty
+	ranked := response.RankedRestrictionEntryDto
+
+	penalty := types.LeaverBusterPenalty{
+		Tier:                     entry.LeaverLevel,
+		LeaverScore:              entry.LeaverScore,
+		PunishmentStep:           entry.CurrentPunishmentStep,
+		Tainted:                  entry.Tainted,
+		WarningSentAt:            millis(entry.WarnSentMillis),
+		AckNeeded:                entry.PreLockoutAckNeeded || entry.OnLockoutAckNeeded || ranked.RankedRestrictionAckNeeded,
+		PunishedGamesRemaining:   entry.PunishedGamesRemaining,
+		QueueLockoutUntil:        millis(int64(leaverPenalty.QueueLockoutTimerExpiryUtcMillis)),
+		RankedRestrictedUntil:    millis(int64(leaverPenalty.RankRestrictedTimerExpiryUtcMillis)),
+		TotalPunishedGamesPlayed: entry.TotalPunishedGamesPlayed,
+		LastPunishmentGameID:     entry.LastPunishmentIncurredGameId,
+		LastPunishmentAt:         millis(entry.LastPunishmentIncurredTimeMillis),
+	}
+	if penalty.WarningSentAt != nil {
+		penalty.WarningAcknowledged = entry.WarnAckedMillis >= entry.WarnSentMillis
+	}
+	if leaverPenalty.HasActivePenalty && leaverPenalty.DelayTime > 0 {
+		// The delay is sent in seconds, a started minute is shown as a full one
+		penalty.QueueDelayMinutes = (leaverPenalty.DelayTime + 59) / 60
+	}
+	penalty.RankedRestrictedGamesRemaining = max(leaverPenalty.RankRestrictedGamesRemaining, ranked.RestrictedGamesRemaining)
+	if penalty.RankedRestrictedUntil != nil && !penalty.RankedRestrictedUntil.After(now) {
+		penalty.RankedRestrictedUntil = nil
+	}
+	if penalty.QueueLockoutUntil != nil && !penalty.QueueLockoutUntil.After(now) {
+		penalty.QueueLockoutUntil = nil
+	}
+
+	penalty.Status = status(penalty)
+	penalty.NextQueue = forecast(penalty, now)
+	return penalty
+}
+
+// status returns the most severe state of the penalty
+func status(penalty types.LeaverBusterPenalty) string {
+	switch {
+	case penalty.QueueLockoutUntil != nil:
+		return types.LeaverBusterLockedOut
+	case penalty.PunishedGamesRemaining > 0 || penalty.QueueDelayMinutes > 0:
+		return types.LeaverBusterQueueDelay
+	case penalty.RankedRestrictedGamesRemaining > 0 || penalty.RankedRestrictedUntil != nil:
+		return types.LeaverBusterRankedRestricted
+	case penalty.WarningSentAt != nil && !penalty.WarningAcknowledged:
+		return types.LeaverBusterWarning
+	default:
+		return types.LeaverBusterClean
+	}
+}
+
+func forecast(penalty types.LeaverBusterPenalty, now time.Time) types.LeaverBusterForecast {
+	next := types.LeaverBusterForecast{
+		CanQueue:      true,
+		DelayMinutes:  penalty.QueueDelayMinutes,
+		RankedAllowed: penalty.RankedRestrictedGamesRemaining == 0 && penalty.RankedRestrictedUntil == nil,
+	}
+	switch penalty.Status {
+	case types.LeaverBusterLockedOut:
+		next.CanQueue = false
+		next.RankedAllowed = false
+		next.AvailableAt = penalty.QueueLockoutUntil
+		next.Message = fmt.Sprintf("Queue is locked for %d more minutes", minutesUntil(*penalty.QueueLockoutUntil, now))
+	case types.LeaverBusterQueueDelay:
+		next.Message = fmt.Sprintf("Next queue waits %d minutes in low priority, %d games remaining",
+			penalty.QueueDelayMinutes, penalty.PunishedGamesRemaining)
+	case types.LeaverBusterRankedRestricted:
+		next.Message = fmt.Sprintf("Ranked is blocked, %d normal games remaining", penalty.RankedRestrictedGamesRemaining)
+	case types.LeaverBusterWarning:
+		next.Message = "Warning received, leaving another game starts a queue delay"
+	default:
+		next.Message = "No leaver buster penalty"
+	}
+	if penalty.AckNeeded && next.CanQueue {
+		next.Message += ", the penalty must be acknowledged in the client first"
+	}
+	return next
+}
+
+func minutesUntil(until time.Time, now time.Time) int {
+	return int((until.Sub(now) + time.Minute - 1) / time.Minute)
+}
+
+// millis converts a unix millisecond timestamp, zero means no timestamp
+func millis(ms int64) *time.Time {
+	if ms <= 0 {
+		return nil
+	}
+	t := time.UnixMilli(ms).UTC()
+	return &t
+}
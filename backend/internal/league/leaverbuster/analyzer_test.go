@@ -0,0 +1,122 @@
+package leaverbuster
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// response decodes a leaver buster body, the entries are anonymous structs in the LCU types
+func response(t *testing.T, body string) *types.LeaverBusterResponse {
+	var leaverBuster types.LeaverBusterResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &leaverBuster))
+	return &leaverBuster
+}
+
+func TestAnalyze(t *testing.T) {
+	future := now.Add(10 * time.Minute).UnixMilli()
+	past := now.Add(-time.Minute).UnixMilli()
+
+	tests := []struct {
+		name          string
+		body          string
+		status        string
+		canQueue      bool
+		rankedAllowed bool
+		delayMinutes  int
+	}{
+		{
+			name:          "Clean account",
+			body:          `{"leaverBusterEntryDto":{"leaverLevel":0}}`,
+			status:        types.LeaverBusterClean,
+			canQueue:      true,
+			rankedAllowed: true,
+		},
+		{
+			name:          "Unacknowledged warning",
+			body:          `{"leaverBusterEntryDto":{"leaverLevel":1,"warnSentMillis":1000,"warnAckedMillis":0}}`,
+			status:        types.LeaverBusterWarning,
+			canQueue:      true,
+			rankedAllowed: true,
+		},
+		{
+			name:          "Acknowledged warning",
+			body:          `{"leaverBusterEntryDto":{"leaverLevel":1,"warnSentMillis":1000,"warnAckedMillis":2000}}`,
+			status:        types.LeaverBusterClean,
+			canQueue:      true,
+			rankedAllowed: true,
+		},
+		{
+			name:          "Ranked restricted from the ranked entry",
+			body:          `{"rankedRestrictionEntryDto":{"restrictedGamesRemaining":5},"leaverBusterEntryDto":{"leaverLevel":2}}`,
+			status:        types.LeaverBusterRankedRestricted,
+			canQueue:      true,
+			rankedAllowed: false,
+		},
+		{
+			name:          "Queue delay rounds started minutes up",
+			body:          `{"leaverBusterEntryDto":{"leaverLevel":3,"punishedGamesRemaining":3,"leaverPenalty":{"hasActivePenalty":true,"delayTime":301}}}`,
+			status:        types.LeaverBusterQueueDelay,
+			canQueue:      true,
+			rankedAllowed: true,
+			delayMinutes:  6,
+		},
+		{
+			name:          "Queue lockout",
+			body:          fmt.Sprintf(`{"leaverBusterEntryDto":{"leaverLevel":4,"leaverPenalty":{"queueLockoutTimerExpiryUtcMillis":%d}}}`, future),
+			status:        types.LeaverBusterLockedOut,
+			canQueue:      false,
+			rankedAllowed: false,
+		},
+		{
+			name:          "Expired lockout is ignored",
+			body:          fmt.Sprintf(`{"leaverBusterEntryDto":{"leaverLevel":4,"leaverPenalty":{"queueLockoutTimerExpiryUtcMillis":%d}}}`, past),
+			status:        types.LeaverBusterClean,
+			canQueue:      true,
+			rankedAllowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			penalty := Analyze(response(t, tt.body), now)
+			assert.Equal(t, tt.status, penalty.Status)
+			assert.Equal(t, tt.canQueue, penalty.NextQueue.CanQueue)
+			assert.Equal(t, tt.rankedAllowed, penalty.NextQueue.RankedAllowed)
+			assert.Equal(t, tt.delayMinutes, penalty.NextQueue.DelayMinutes)
+			assert.NotEmpty(t, penalty.NextQueue.Message)
+		})
+	}
+
+	t.Run("Nil response is clean", func(t *testing.T) {
+		penalty := Analyze(nil, now)
+		assert.Equal(t, types.LeaverBusterClean, penalty.Status)
+		assert.True(t, penalty.NextQueue.CanQueue)
+	})
+
+	t.Run("Lockout forecast is available when the timer expires", func(t *testing.T) {
+		body := fmt.Sprintf(`{"leaverBusterEntryDto":{"leaverPenalty":{"queueLockoutTimerExpiryUtcMillis":%d}}}`, future)
+		penalty := Analyze(response(t, body), now)
+		require.NotNil(t, penalty.NextQueue.AvailableAt)
+		assert.Equal(t, future, penalty.NextQueue.AvailableAt.UnixMilli())
+		assert.Contains(t, penalty.NextQueue.Message, "10 more minutes")
+	})
+
+	t.Run("Ranked games take the highest of both entries", func(t *testing.T) {
+		body := `{"rankedRestrictionEntryDto":{"restrictedGamesRemaining":2},"leaverBusterEntryDto":{"leaverPenalty":{"rankRestrictedGamesRemaining":4}}}`
+		assert.Equal(t, 4, Analyze(response(t, body), now).RankedRestrictedGamesRemaining)
+	})
+
+	t.Run("Pending acknowledgement is part of the message", func(t *testing.T) {
+		body := `{"rankedRestrictionEntryDto":{"restrictedGamesRemaining":2,"rankedRestrictionAckNeeded":true}}`
+		penalty := Analyze(response(t, body), now)
+		assert.True(t, penalty.AckNeeded)
+		assert.Contains(t, penalty.NextQueue.Message, "acknowledged")
+	})
+}
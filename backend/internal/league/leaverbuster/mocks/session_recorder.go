@@ -0,0 +1,68 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionRecorder is an autogenerated mock type for the SessionRecorder type
+type SessionRecorder struct {
+	mock.Mock
+}
+
+type SessionRecorder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SessionRecorder) EXPECT() *SessionRecorder_Expecter {
+	return &SessionRecorder_Expecter{mock: &_m.Mock}
+}
+
+// RecordLeaverBuster provides a mock function with given fields: penalty
+func (_m *SessionRecorder) RecordLeaverBuster(penalty types.LeaverBusterPenalty) {
+	_m.Called(penalty)
+}
+
+// SessionRecorder_RecordLeaverBuster_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordLeaverBuster'
+type SessionRecorder_RecordLeaverBuster_Call struct {
+	*mock.Call
+}
+
+// RecordLeaverBuster is a helper method to define mock.On call
+//   - penalty types.LeaverBusterPenalty
+func (_e *SessionRecorder_Expecter) RecordLeaverBuster(penalty interface{}) *SessionRecorder_RecordLeaverBuster_Call {
+	return &SessionRecorder_RecordLeaverBuster_Call{Call: _e.mock.On("RecordLeaverBuster", penalty)}
+}
+
+func (_c *SessionRecorder_RecordLeaverBuster_Call) Run(run func(penalty types.LeaverBusterPenalty)) *SessionRecorder_RecordLeaverBuster_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(types.LeaverBusterPenalty))
+	})
+	return _c
+}
+
+func (_c *SessionRecorder_RecordLeaverBuster_Call) Return() *SessionRecorder_RecordLeaverBuster_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *SessionRecorder_RecordLeaverBuster_Call) RunAndReturn(run func(types.LeaverBusterPenalty)) *SessionRecorder_RecordLeaverBuster_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewSessionRecorder creates a new instance of SessionRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessionRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SessionRecorder {
+	mock := &SessionRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,148 @@
+package leaverbuster
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"go.uber.org/zap"
+)
+
+// EventPenaltyChanged carries a types.LeaverBusterPenalty every time the penalty of the account changes
+const EventPenaltyChanged = "leaverbuster:penalty"
+
+// Source defines the call used to fetch the leaver buster of the logged in account
+type Source interface {
+	GetLeaverBuster(platformID string) (*types.LeaverBusterResponse, error)
+}
+
+// AccountState defines the current account and its last stored leaver buster
+type AccountState interface {
+	Get() *types.PartialSummonerRented
+}
+
+// SessionRecorder defines how the penalty progression is attributed to the rental session
+type SessionRecorder interface {
+	RecordLeaverBuster(penalty types.LeaverBusterPenalty)
+}
+
+// AppEmitter defines the interface for emitting events
+type AppEmitter interface {
+	EmitEvent(name string, data ...any)
+}
+
+// Service analyzes every leaver buster read, records it on the session and notifies the frontend of changes
+type Service struct {
+	logger       *logger.Logger
+	source       Source
+	accountState AccountState
+	recorder     SessionRecorder
+	app          AppEmitter
+	now          func() time.Time
+
+	mutex    sync.Mutex
+	username string
+	penalty  *types.LeaverBusterPenalty
+}
+
+func NewService(logger *logger.Logger, source Source, accountState AccountState) *Service {
+	return &Service{
+		logger:       logger,
+		source:       source,
+		accountState: accountState,
+		now:          time.Now,
+	}
+}
+
+func (s *Service) SetApp(app AppEmitter) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.app = app
+}
+
+func (s *Service) SetSessionRecorder(recorder SessionRecorder) {
+	s.recorder = recorder
+}
+
+// GetPenalty returns the penalty of the logged in account, falling back to the leaver buster stored on it
+func (s *Service) GetPenalty() *types.LeaverBusterPenalty {
+	account := s.accountState.Get()
+	if account == nil || account.Username == "" {
+		return nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.penalty != nil && s.username == strings.ToLower(account.Username) {
+		penalty := *s.penalty
+		penalty.NextQueue = forecast(penalty, s.now())
+		return &penalty
+	}
+	penalty := Analyze(account.LeaverBuster, s.now())
+	return &penalty
+}
+
+// GetLeaverBuster fetches the leaver buster and analyzes it, other services read it through here so every
+// read is recorded
+func (s *Service) GetLeaverBuster(platformID string) (*types.LeaverBusterResponse, error) {
+	response, err := s.source.GetLeaverBuster(platformID)
+	if err != nil {
+		return nil, err
+	}
+	s.Observe(response)
+	return response, nil
+}
+
+// Refresh fetches the leaver buster of the logged in account
+func (s *Service) Refresh() error {
+	account := s.accountState.Get()
+	if account == nil || account.Server == nil || *account.Server == "" {
+		return errors.New("the server of the logged in account is unknown")
+	}
+	_, err := s.GetLeaverBuster(*account.Server)
+	return err
+}
+
+// Observe analyzes a leaver buster read of the logged in account
+func (s *Service) Observe(response *types.LeaverBusterResponse) types.LeaverBusterPenalty {
+	penalty := Analyze(response, s.now())
+	account := s.accountState.Get()
+	if account == nil || account.Username == "" {
+		return penalty
+	}
+	username := strings.ToLower(account.Username)
+
+	s.mutex.Lock()
+	changed := s.penalty == nil || s.username != username || !samePenalty(*s.penalty, penalty)
+	s.username = username
+	s.penalty = &penalty
+	app := s.app
+	s.mutex.Unlock()
+
+	if s.recorder != nil {
+		s.recorder.RecordLeaverBuster(penalty)
+	}
+	if !changed {
+		return penalty
+	}
+	s.logger.Info("Leaver buster penalty changed",
+		zap.String("username", username),
+		zap.String("status", penalty.Status),
+		zap.Int("tier", penalty.Tier),
+		zap.Int("punishedGamesRemaining", penalty.PunishedGamesRemaining),
+		zap.Int("rankedRestrictedGamesRemaining", penalty.RankedRestrictedGamesRemaining))
+	if app != nil {
+		app.EmitEvent(EventPenaltyChanged, penalty)
+	}
+	return penalty
+}
+
+// samePenalty compares two penalties ignoring the forecast, its message changes with time
+func samePenalty(a, b types.LeaverBusterPenalty) bool {
+	a.NextQueue = types.LeaverBusterForecast{}
+	b.NextQueue = types.LeaverBusterForecast{}
+	return reflect.DeepEqual(a, b)
+}
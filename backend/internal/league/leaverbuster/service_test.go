@@ -0,0 +1,86 @@
+package leaverbuster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/leaverbuster/mocks"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	newLogger := logger.New("TestLeaverBuster", &config.Config{LogLevel: "error"})
+	server := "BR1"
+	account := &types.PartialSummonerRented{Username: "Rented", Server: &server}
+
+	t.Run("Every read is recorded and only changes are emitted", func(t *testing.T) {
+		mockSource := mocks.NewSource(t)
+		mockAccountState := mocks.NewAccountState(t)
+		mockRecorder := mocks.NewSessionRecorder(t)
+		mockApp := mocks.NewAppEmitter(t)
+
+		mockAccountState.EXPECT().Get().Return(account)
+		mockSource.EXPECT().GetLeaverBuster("BR1").Return(response(t, `{"leaverBusterEntryDto":{"leaverLevel":1}}`), nil).Times(2)
+		mockRecorder.EXPECT().RecordLeaverBuster(mock.Anything).Return().Times(3)
+		mockApp.EXPECT().EmitEvent(EventPenaltyChanged, mock.MatchedBy(func(penalty types.LeaverBusterPenalty) bool {
+			return penalty.Tier == 1
+		})).Return().Once()
+
+		service := NewService(newLogger, mockSource, mockAccountState)
+		service.SetSessionRecorder(mockRecorder)
+		service.SetApp(mockApp)
+		service.now = func() time.Time { return now }
+
+		require.NoError(t, service.Refresh())
+		require.NoError(t, service.Refresh())
+
+		mockSource.EXPECT().GetLeaverBuster("BR1").Return(response(t, `{"leaverBusterEntryDto":{"leaverLevel":2,"punishedGamesRemaining":3}}`), nil).Once()
+		mockApp.EXPECT().EmitEvent(EventPenaltyChanged, mock.MatchedBy(func(penalty types.LeaverBusterPenalty) bool {
+			return penalty.Tier == 2 && penalty.Status == types.LeaverBusterQueueDelay
+		})).Return().Once()
+		require.NoError(t, service.Refresh())
+	})
+
+	t.Run("Refresh needs the server of the account", func(t *testing.T) {
+		mockSource := mocks.NewSource(t)
+		mockAccountState := mocks.NewAccountState(t)
+		mockRecorder := mocks.NewSessionRecorder(t)
+		mockAccountState.EXPECT().Get().Return(&types.PartialSummonerRented{Username: "Rented"})
+
+		service := NewService(newLogger, mockSource, mockAccountState)
+		service.SetSessionRecorder(mockRecorder)
+
+		assert.Error(t, service.Refresh())
+		mockSource.AssertNotCalled(t, "GetLeaverBuster", mock.Anything)
+		mockRecorder.AssertNotCalled(t, "RecordLeaverBuster", mock.Anything)
+	})
+
+	t.Run("Penalty falls back to the stored leaver buster", func(t *testing.T) {
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountState.EXPECT().Get().Return(&types.PartialSummonerRented{
+			Username:     "Rented",
+			LeaverBuster: response(t, `{"rankedRestrictionEntryDto":{"restrictedGamesRemaining":4}}`),
+		})
+
+		service := NewService(newLogger, mocks.NewSource(t), mockAccountState)
+		service.now = func() time.Time { return now }
+
+		penalty := service.GetPenalty()
+		require.NotNil(t, penalty)
+		assert.Equal(t, types.LeaverBusterRankedRestricted, penalty.Status)
+	})
+
+	t.Run("No penalty without a logged in account", func(t *testing.T) {
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountState.EXPECT().Get().Return(&types.PartialSummonerRented{})
+
+		service := NewService(newLogger, mocks.NewSource(t), mockAccountState)
+
+		assert.Nil(t, service.GetPenalty())
+	})
+}
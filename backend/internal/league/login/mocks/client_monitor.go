@@ -0,0 +1,234 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+)
+
+// ClientMonitor is an autogenerated mock type for the ClientMonitor type
+type ClientMonitor struct {
+	mock.Mock
+}
+
+type ClientMonitor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ClientMonitor) EXPECT() *ClientMonitor_Expecter {
+	return &ClientMonitor_Expecter{mock: &_m.Mock}
+}
+
+// HandleLogin provides a mock function with given fields: ctx, username, password, captchaToken
+func (_m *ClientMonitor) HandleLogin(ctx context.Context, username string, password *types.Credential, captchaToken string) error {
+	ret := _m.Called(ctx, username, password, captchaToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleLogin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *types.Credential, string) error); ok {
+		r0 = rf(ctx, username, password, captchaToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientMonitor_HandleLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleLogin'
+type ClientMonitor_HandleLogin_Call struct {
+	*mock.Call
+}
+
+// HandleLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - password *types.Credential
+//   - captchaToken string
+func (_e *ClientMonitor_Expecter) HandleLogin(ctx interface{}, username interface{}, password interface{}, captchaToken interface{}) *ClientMonitor_HandleLogin_Call {
+	return &ClientMonitor_HandleLogin_Call{Call: _e.mock.On("HandleLogin", ctx, username, password, captchaToken)}
+}
+
+func (_c *ClientMonitor_HandleLogin_Call) Run(run func(ctx context.Context, username string, password *types.Credential, captchaToken string)) *ClientMonitor_HandleLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*types.Credential), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ClientMonitor_HandleLogin_Call) Return(_a0 error) *ClientMonitor_HandleLogin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientMonitor_HandleLogin_Call) RunAndReturn(run func(context.Context, string, *types.Credential, string) error) *ClientMonitor_HandleLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsLoginReady provides a mock function with no fields
+func (_m *ClientMonitor) IsLoginReady() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsLoginReady")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ClientMonitor_IsLoginReady_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLoginReady'
+type ClientMonitor_IsLoginReady_Call struct {
+	*mock.Call
+}
+
+// IsLoginReady is a helper method to define mock.On call
+func (_e *ClientMonitor_Expecter) IsLoginReady() *ClientMonitor_IsLoginReady_Call {
+	return &ClientMonitor_IsLoginReady_Call{Call: _e.mock.On("IsLoginReady")}
+}
+
+func (_c *ClientMonitor_IsLoginReady_Call) Run(run func()) *ClientMonitor_IsLoginReady_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ClientMonitor_IsLoginReady_Call) Return(_a0 bool) *ClientMonitor_IsLoginReady_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientMonitor_IsLoginReady_Call) RunAndReturn(run func() bool) *ClientMonitor_IsLoginReady_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenWebviewAndGetToken provides a mock function with given fields: ctx
+func (_m *ClientMonitor) OpenWebviewAndGetToken(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenWebviewAndGetToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientMonitor_OpenWebviewAndGetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenWebviewAndGetToken'
+type ClientMonitor_OpenWebviewAndGetToken_Call struct {
+	*mock.Call
+}
+
+// OpenWebviewAndGetToken is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ClientMonitor_Expecter) OpenWebviewAndGetToken(ctx interface{}) *ClientMonitor_OpenWebviewAndGetToken_Call {
+	return &ClientMonitor_OpenWebviewAndGetToken_Call{Call: _e.mock.On("OpenWebviewAndGetToken", ctx)}
+}
+
+func (_c *ClientMonitor_OpenWebviewAndGetToken_Call) Run(run func(ctx context.Context)) *ClientMonitor_OpenWebviewAndGetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ClientMonitor_OpenWebviewAndGetToken_Call) Return(_a0 string, _a1 error) *ClientMonitor_OpenWebviewAndGetToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClientMonitor_OpenWebviewAndGetToken_Call) RunAndReturn(run func(context.Context) (string, error)) *ClientMonitor_OpenWebviewAndGetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WaitForMultifactor provides a mock function with given fields: ctx
+func (_m *ClientMonitor) WaitForMultifactor(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForMultifactor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientMonitor_WaitForMultifactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WaitForMultifactor'
+type ClientMonitor_WaitForMultifactor_Call struct {
+	*mock.Call
+}
+
+// WaitForMultifactor is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ClientMonitor_Expecter) WaitForMultifactor(ctx interface{}) *ClientMonitor_WaitForMultifactor_Call {
+	return &ClientMonitor_WaitForMultifactor_Call{Call: _e.mock.On("WaitForMultifactor", ctx)}
+}
+
+func (_c *ClientMonitor_WaitForMultifactor_Call) Run(run func(ctx context.Context)) *ClientMonitor_WaitForMultifactor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ClientMonitor_WaitForMultifactor_Call) Return(_a0 error) *ClientMonitor_WaitForMultifactor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientMonitor_WaitForMultifactor_Call) RunAndReturn(run func(context.Context) error) *ClientMonitor_WaitForMultifactor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewClientMonitor creates a new instance of ClientMonitor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClientMonitor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ClientMonitor {
+	mock := &ClientMonitor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,122 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// LeagueClient is an autogenerated mock type for the LeagueClient type
+type LeagueClient struct {
+	mock.Mock
+}
+
+type LeagueClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LeagueClient) EXPECT() *LeagueClient_Expecter {
+	return &LeagueClient_Expecter{mock: &_m.Mock}
+}
+
+// IsLCUConnectionReady provides a mock function with no fields
+func (_m *LeagueClient) IsLCUConnectionReady() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsLCUConnectionReady")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// LeagueClient_IsLCUConnectionReady_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLCUConnectionReady'
+type LeagueClient_IsLCUConnectionReady_Call struct {
+	*mock.Call
+}
+
+// IsLCUConnectionReady is a helper method to define mock.On call
+func (_e *LeagueClient_Expecter) IsLCUConnectionReady() *LeagueClient_IsLCUConnectionReady_Call {
+	return &LeagueClient_IsLCUConnectionReady_Call{Call: _e.mock.On("IsLCUConnectionReady")}
+}
+
+func (_c *LeagueClient_IsLCUConnectionReady_Call) Run(run func()) *LeagueClient_IsLCUConnectionReady_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *LeagueClient_IsLCUConnectionReady_Call) Return(_a0 bool) *LeagueClient_IsLCUConnectionReady_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LeagueClient_IsLCUConnectionReady_Call) RunAndReturn(run func() bool) *LeagueClient_IsLCUConnectionReady_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateFromLCU provides a mock function with no fields
+func (_m *LeagueClient) UpdateFromLCU() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFromLCU")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LeagueClient_UpdateFromLCU_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFromLCU'
+type LeagueClient_UpdateFromLCU_Call struct {
+	*mock.Call
+}
+
+// UpdateFromLCU is a helper method to define mock.On call
+func (_e *LeagueClient_Expecter) UpdateFromLCU() *LeagueClient_UpdateFromLCU_Call {
+	return &LeagueClient_UpdateFromLCU_Call{Call: _e.mock.On("UpdateFromLCU")}
+}
+
+func (_c *LeagueClient_UpdateFromLCU_Call) Run(run func()) *LeagueClient_UpdateFromLCU_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *LeagueClient_UpdateFromLCU_Call) Return(_a0 error) *LeagueClient_UpdateFromLCU_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LeagueClient_UpdateFromLCU_Call) RunAndReturn(run func() error) *LeagueClient_UpdateFromLCU_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLeagueClient creates a new instance of LeagueClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLeagueClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LeagueClient {
+	mock := &LeagueClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
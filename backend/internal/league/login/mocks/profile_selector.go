@@ -0,0 +1,65 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// ProfileSelector is an autogenerated mock type for the ProfileSelector type
+type ProfileSelector struct {
+	mock.Mock
+}
+
+type ProfileSelector_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ProfileSelector) EXPECT() *ProfileSelector_Expecter {
+	return &ProfileSelector_Expecter{mock: &_m.Mock}
+}
+
+// SetActiveAccount provides a mock function with given fields: username
+func (_m *ProfileSelector) SetActiveAccount(username string) {
+	_m.Called(username)
+}
+
+// ProfileSelector_SetActiveAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActiveAccount'
+type ProfileSelector_SetActiveAccount_Call struct {
+	*mock.Call
+}
+
+// SetActiveAccount is a helper method to define mock.On call
+//   - username string
+func (_e *ProfileSelector_Expecter) SetActiveAccount(username interface{}) *ProfileSelector_SetActiveAccount_Call {
+	return &ProfileSelector_SetActiveAccount_Call{Call: _e.mock.On("SetActiveAccount", username)}
+}
+
+func (_c *ProfileSelector_SetActiveAccount_Call) Run(run func(username string)) *ProfileSelector_SetActiveAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileSelector_SetActiveAccount_Call) Return() *ProfileSelector_SetActiveAccount_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ProfileSelector_SetActiveAccount_Call) RunAndReturn(run func(string)) *ProfileSelector_SetActiveAccount_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewProfileSelector creates a new instance of ProfileSelector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProfileSelector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProfileSelector {
+	mock := &ProfileSelector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,274 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RiotClient is an autogenerated mock type for the RiotClient type
+type RiotClient struct {
+	mock.Mock
+}
+
+type RiotClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RiotClient) EXPECT() *RiotClient_Expecter {
+	return &RiotClient_Expecter{mock: &_m.Mock}
+}
+
+// CheckAccountBanned provides a mock function with given fields: username
+func (_m *RiotClient) CheckAccountBanned(username string) error {
+	ret := _m.Called(username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAccountBanned")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RiotClient_CheckAccountBanned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckAccountBanned'
+type RiotClient_CheckAccountBanned_Call struct {
+	*mock.Call
+}
+
+// CheckAccountBanned is a helper method to define mock.On call
+//   - username string
+func (_e *RiotClient_Expecter) CheckAccountBanned(username interface{}) *RiotClient_CheckAccountBanned_Call {
+	return &RiotClient_CheckAccountBanned_Call{Call: _e.mock.On("CheckAccountBanned", username)}
+}
+
+func (_c *RiotClient_CheckAccountBanned_Call) Run(run func(username string)) *RiotClient_CheckAccountBanned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *RiotClient_CheckAccountBanned_Call) Return(_a0 error) *RiotClient_CheckAccountBanned_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RiotClient_CheckAccountBanned_Call) RunAndReturn(run func(string) error) *RiotClient_CheckAccountBanned_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckRequiredActions provides a mock function with given fields: username
+func (_m *RiotClient) CheckRequiredActions(username string) error {
+	ret := _m.Called(username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckRequiredActions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RiotClient_CheckRequiredActions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckRequiredActions'
+type RiotClient_CheckRequiredActions_Call struct {
+	*mock.Call
+}
+
+// CheckRequiredActions is a helper method to define mock.On call
+//   - username string
+func (_e *RiotClient_Expecter) CheckRequiredActions(username interface{}) *RiotClient_CheckRequiredActions_Call {
+	return &RiotClient_CheckRequiredActions_Call{Call: _e.mock.On("CheckRequiredActions", username)}
+}
+
+func (_c *RiotClient_CheckRequiredActions_Call) Run(run func(username string)) *RiotClient_CheckRequiredActions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *RiotClient_CheckRequiredActions_Call) Return(_a0 error) *RiotClient_CheckRequiredActions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RiotClient_CheckRequiredActions_Call) RunAndReturn(run func(string) error) *RiotClient_CheckRequiredActions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserinfo provides a mock function with no fields
+func (_m *RiotClient) GetUserinfo() (*types.UserInfo, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserinfo")
+	}
+
+	var r0 *types.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.UserInfo, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.UserInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RiotClient_GetUserinfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserinfo'
+type RiotClient_GetUserinfo_Call struct {
+	*mock.Call
+}
+
+// GetUserinfo is a helper method to define mock.On call
+func (_e *RiotClient_Expecter) GetUserinfo() *RiotClient_GetUserinfo_Call {
+	return &RiotClient_GetUserinfo_Call{Call: _e.mock.On("GetUserinfo")}
+}
+
+func (_c *RiotClient_GetUserinfo_Call) Run(run func()) *RiotClient_GetUserinfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RiotClient_GetUserinfo_Call) Return(_a0 *types.UserInfo, _a1 error) *RiotClient_GetUserinfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RiotClient_GetUserinfo_Call) RunAndReturn(run func() (*types.UserInfo, error)) *RiotClient_GetUserinfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsRunning provides a mock function with no fields
+func (_m *RiotClient) IsRunning() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsRunning")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// RiotClient_IsRunning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRunning'
+type RiotClient_IsRunning_Call struct {
+	*mock.Call
+}
+
+// IsRunning is a helper method to define mock.On call
+func (_e *RiotClient_Expecter) IsRunning() *RiotClient_IsRunning_Call {
+	return &RiotClient_IsRunning_Call{Call: _e.mock.On("IsRunning")}
+}
+
+func (_c *RiotClient_IsRunning_Call) Run(run func()) *RiotClient_IsRunning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RiotClient_IsRunning_Call) Return(_a0 bool) *RiotClient_IsRunning_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RiotClient_IsRunning_Call) RunAndReturn(run func() bool) *RiotClient_IsRunning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Launch provides a mock function with no fields
+func (_m *RiotClient) Launch() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Launch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RiotClient_Launch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Launch'
+type RiotClient_Launch_Call struct {
+	*mock.Call
+}
+
+// Launch is a helper method to define mock.On call
+func (_e *RiotClient_Expecter) Launch() *RiotClient_Launch_Call {
+	return &RiotClient_Launch_Call{Call: _e.mock.On("Launch")}
+}
+
+func (_c *RiotClient_Launch_Call) Run(run func()) *RiotClient_Launch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RiotClient_Launch_Call) Return(_a0 error) *RiotClient_Launch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RiotClient_Launch_Call) RunAndReturn(run func() error) *RiotClient_Launch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRiotClient creates a new instance of RiotClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRiotClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RiotClient {
+	mock := &RiotClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
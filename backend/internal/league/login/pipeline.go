@@ -0,0 +1,429 @@
+package login
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/riot/auth"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"go.uber.org/zap"
+)
+
+// EventLoginProgress carries a types.LoginProgress every time a step changes status
+const EventLoginProgress = "login:progress"
+
+// Pipeline steps, in the order they run
+const (
+	StepLaunch      = "launch"
+	StepLoginReady  = "login_ready"
+	StepCaptcha     = "captcha"
+	StepLogin       = "login"
+	StepMultifactor = "multifactor"
+	StepUserinfo    = "userinfo"
+	StepBanCheck    = "ban_check"
+	// StepRequiredActions stops renters at a pending EULA, age gate or Riot ID change
+	StepRequiredActions = "required_actions"
+	StepLCUSync         = "lcu_sync"
+)
+
+// Error codes that don't map to a step specific failure
+const (
+	ErrorCodeTimeout    = "timeout"
+	ErrorCodeCancelled  = "cancelled"
+	ErrorCodeInProgress = "login_already_in_progress"
+)
+
+// captchaErrorCodes are the error messages returned by the captcha flow that the frontend already
+// understands, they are forwarded as is
+var captchaErrorCodes = []string{
+	"captcha_already_in_progress",
+	"captcha_cancelled",
+	"captcha_timeout",
+}
+
+// permanentErrorCodes can't be fixed by running the step again
+var permanentErrorCodes = map[string]bool{
+	ErrorCodeCancelled:            true,
+	"captcha_already_in_progress": true,
+	"captcha_cancelled":           true,
+}
+
+var ErrLoginInProgress = errors.New(ErrorCodeInProgress)
+
+// RiotClient defines the Riot client calls used by the pipeline
+type RiotClient interface {
+	Launch() error
+	IsRunning() bool
+	GetUserinfo() (*types.UserInfo, error)
+	CheckAccountBanned(username string) error
+	CheckRequiredActions(username string) error
+}
+
+// ClientMonitor defines the league client monitor calls used to authenticate
+type ClientMonitor interface {
+	IsLoginReady() bool
+	OpenWebviewAndGetToken(ctx context.Context) (string, error)
+	HandleLogin(ctx context.Context, username string, password *types.Credential, captchaToken string) error
+	WaitForMultifactor(ctx context.Context) error
+}
+
+// LeagueClient defines the league client calls used to sync the account once logged in
+type LeagueClient interface {
+	IsLCUConnectionReady() bool
+	UpdateFromLCU() error
+}
+
+// ProfileSelector defines how the rented account is made the target of the launch profile selection
+type ProfileSelector interface {
+	SetActiveAccount(username string)
+}
+
+// AppEmitter defines the interface for emitting events
+type AppEmitter interface {
+	EmitEvent(name string, data ...any)
+}
+
+// StepPolicy controls how long a step may take and how many times it is attempted
+type StepPolicy struct {
+	Timeout  time.Duration
+	Attempts int
+	Backoff  time.Duration
+}
+
+// DefaultPolicies are the step policies used by NewPipeline
+var DefaultPolicies = map[string]StepPolicy{
+	StepLaunch:     {Timeout: 60 * time.Second, Attempts: 2, Backoff: 2 * time.Second},
+	StepLoginReady: {Timeout: 30 * time.Second, Attempts: 1},
+	StepCaptcha:    {Timeout: 40 * time.Second, Attempts: 2, Backoff: time.Second},
+	StepLogin:      {Timeout: 15 * time.Second, Attempts: 1},
+	// The renter may need to ask support for the code
+	StepMultifactor:     {Timeout: 10 * time.Minute, Attempts: 1},
+	StepUserinfo:        {Timeout: 15 * time.Second, Attempts: 1},
+	StepBanCheck:        {Timeout: 35 * time.Second, Attempts: 2, Backoff: time.Second},
+	StepRequiredActions: {Timeout: 20 * time.Second, Attempts: 2, Backoff: time.Second},
+	StepLCUSync:         {Timeout: 2 * time.Minute, Attempts: 3, Backoff: 3 * time.Second},
+}
+
+type step struct {
+	name string
+	skip func() bool
+	run  func(ctx context.Context) error
+	// detached steps call the client without a context, on timeout the call is left running and its result
+	// discarded. Only calls that are safe to repeat can be detached
+	detached bool
+}
+
+// Pipeline logs into a rented account from a closed client up to the account being synced from the LCU
+type Pipeline struct {
+	logger        *logger.Logger
+	riotClient    RiotClient
+	clientMonitor ClientMonitor
+	leagueClient  LeagueClient
+	profiles      ProfileSelector
+	app           AppEmitter
+	policies      map[string]StepPolicy
+	pollInterval  time.Duration
+	mutex         sync.Mutex
+	cancel        context.CancelFunc
+}
+
+func NewPipeline(logger *logger.Logger, riotClient RiotClient, clientMonitor ClientMonitor, leagueClient LeagueClient) *Pipeline {
+	policies := make(map[string]StepPolicy, len(DefaultPolicies))
+	for name, policy := range DefaultPolicies {
+		policies[name] = policy
+	}
+	return &Pipeline{
+		logger:        logger,
+		riotClient:    riotClient,
+		clientMonitor: clientMonitor,
+		leagueClient:  leagueClient,
+		policies:      policies,
+		pollInterval:  500 * time.Millisecond,
+	}
+}
+
+func (p *Pipeline) SetApp(app AppEmitter) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.app = app
+}
+
+// SetProfileSelector makes every run launch with the profile selected for its rented account
+func (p *Pipeline) SetProfileSelector(profiles ProfileSelector) {
+	p.profiles = profiles
+}
+
+// Login runs the pipeline for the frontend, only one login can run at a time
+func (p *Pipeline) Login(request types.LoginRequest) (*types.LoginResult, error) {
+	p.mutex.Lock()
+	if p.cancel != nil {
+		p.mutex.Unlock()
+		request.Password.Destroy()
+		return nil, ErrLoginInProgress
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.mutex.Unlock()
+
+	defer func() {
+		p.mutex.Lock()
+		p.cancel = nil
+		p.mutex.Unlock()
+		cancel()
+	}()
+
+	return p.Run(ctx, request), nil
+}
+
+// Cancel stops the running login, the current step fails with ErrorCodeCancelled
+func (p *Pipeline) Cancel() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.cancel != nil {
+		p.logger.Info("Cancelling login pipeline")
+		p.cancel()
+	}
+}
+
+// Run executes every step in order and stops at the first step that fails
+func (p *Pipeline) Run(ctx context.Context, request types.LoginRequest) *types.LoginResult {
+	defer request.Password.Destroy()
+
+	startedAt := time.Now()
+	result := &types.LoginResult{
+		Username: request.Username,
+		Steps:    make([]types.LoginStepResult, 0),
+	}
+	p.logger.Info("Starting login pipeline", zap.String("username", request.Username))
+	if p.profiles != nil {
+		p.profiles.SetActiveAccount(request.Username)
+	}
+
+	for _, s := range p.steps(request) {
+		stepResult := p.runStep(ctx, startedAt, s)
+		result.Steps = append(result.Steps, stepResult)
+		if stepResult.Status == types.LoginStepFailed {
+			result.FailedStep = stepResult.Step
+			result.ErrorCode = stepResult.ErrorCode
+			result.Error = stepResult.Error
+			break
+		}
+	}
+
+	result.Success = result.FailedStep == ""
+	result.ElapsedMs = time.Since(startedAt).Milliseconds()
+	p.logger.Info("Login pipeline finished",
+		zap.Bool("success", result.Success),
+		zap.String("failedStep", result.FailedStep),
+		zap.String("errorCode", result.ErrorCode),
+		zap.Int64("elapsedMs", result.ElapsedMs))
+	return result
+}
+
+func (p *Pipeline) steps(request types.LoginRequest) []step {
+	var captchaToken string
+	var multifactorRequired bool
+	return []step{
+		{name: StepLaunch, detached: true, run: func(ctx context.Context) error {
+			if !p.riotClient.IsRunning() {
+				if err := p.riotClient.Launch(); err != nil {
+					return err
+				}
+			}
+			return p.waitUntil(ctx, p.riotClient.IsRunning)
+		}},
+		{name: StepLoginReady, run: func(ctx context.Context) error {
+			return p.waitUntil(ctx, p.clientMonitor.IsLoginReady)
+		}},
+		{name: StepCaptcha, run: func(ctx context.Context) error {
+			token, err := p.clientMonitor.OpenWebviewAndGetToken(ctx)
+			if err != nil {
+				return err
+			}
+			captchaToken = token
+			return nil
+		}},
+		{name: StepLogin, run: func(ctx context.Context) error {
+			// HandleLogin destroys the credential it receives, every attempt gets its own copy
+			password, err := request.Password.Clone()
+			if err != nil {
+				return err
+			}
+			err = p.clientMonitor.HandleLogin(ctx, request.Username, password, captchaToken)
+			if errors.Is(err, auth.ErrMultifactorRequired) {
+				// The credentials were accepted, the code is handled by the multifactor step
+				multifactorRequired = true
+				return nil
+			}
+			return err
+		}},
+		{
+			name: StepMultifactor,
+			skip: func() bool { return !multifactorRequired },
+			run:  p.clientMonitor.WaitForMultifactor,
+		},
+		{name: StepUserinfo, run: func(ctx context.Context) error {
+			return p.waitUntil(ctx, func() bool {
+				_, err := p.riotClient.GetUserinfo()
+				return err == nil
+			})
+		}},
+		{name: StepBanCheck, detached: true, run: func(ctx context.Context) error {
+			return p.riotClient.CheckAccountBanned(request.Username)
+		}},
+		{name: StepRequiredActions, detached: true, run: func(ctx context.Context) error {
+			return p.riotClient.CheckRequiredActions(request.Username)
+		}},
+		{name: StepLCUSync, detached: true, run: func(ctx context.Context) error {
+			if err := p.waitUntil(ctx, p.leagueClient.IsLCUConnectionReady); err != nil {
+				return err
+			}
+			return p.leagueClient.UpdateFromLCU()
+		}},
+	}
+}
+
+// runStep runs the step following its policy, emitting progress for every status change
+func (p *Pipeline) runStep(ctx context.Context, pipelineStartedAt time.Time, s step) types.LoginStepResult {
+	policy := p.policies[s.name]
+	if policy.Attempts < 1 {
+		policy.Attempts = 1
+	}
+	startedAt := time.Now()
+	progress := types.LoginProgress{Step: s.name, MaxAttempts: policy.Attempts}
+	emit := func(status string) {
+		progress.Status = status
+		progress.ElapsedMs = time.Since(startedAt).Milliseconds()
+		progress.TotalElapsedMs = time.Since(pipelineStartedAt).Milliseconds()
+		p.emitEvent(EventLoginProgress, progress)
+	}
+
+	if s.skip != nil && s.skip() {
+		emit(types.LoginStepSkipped)
+		return stepResult(progress)
+	}
+
+	for attempt := 1; ; attempt++ {
+		progress.Attempt = attempt
+		progress.ErrorCode = ""
+		progress.Error = ""
+		emit(types.LoginStepStarted)
+
+		err := p.runAttempt(ctx, policy.Timeout, s)
+		if err == nil {
+			emit(types.LoginStepSucceeded)
+			return stepResult(progress)
+		}
+
+		progress.ErrorCode = errorCode(ctx, s.name, err)
+		progress.Error = err.Error()
+		p.logger.Warn("Login step failed",
+			zap.String("step", s.name),
+			zap.Int("attempt", attempt),
+			zap.String("errorCode", progress.ErrorCode),
+			zap.Error(err))
+
+		if attempt >= policy.Attempts || permanentErrorCodes[progress.ErrorCode] || auth.IsPermanent(err) {
+			emit(types.LoginStepFailed)
+			return stepResult(progress)
+		}
+		emit(types.LoginStepRetrying)
+
+		select {
+		case <-ctx.Done():
+			progress.ErrorCode = ErrorCodeCancelled
+			progress.Error = ctx.Err().Error()
+			emit(types.LoginStepFailed)
+			return stepResult(progress)
+		case <-time.After(policy.Backoff):
+		}
+	}
+}
+
+// runAttempt bounds the attempt by the step timeout. A step honoring its context is waited for, so the next
+// attempt never overlaps it, a detached one keeps running in the background but its result is discarded
+func (p *Pipeline) runAttempt(ctx context.Context, timeout time.Duration, s step) error {
+	attemptCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if !s.detached {
+		err := s.run(attemptCtx)
+		if err != nil && attemptCtx.Err() != nil {
+			return attemptCtx.Err()
+		}
+		return err
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- s.run(attemptCtx)
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-attemptCtx.Done():
+		return attemptCtx.Err()
+	}
+}
+
+func (p *Pipeline) waitUntil(ctx context.Context, condition func() bool) error {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+	for {
+		if condition() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Pipeline) emitEvent(name string, data ...any) {
+	p.mutex.Lock()
+	app := p.app
+	p.mutex.Unlock()
+	if app != nil {
+		app.EmitEvent(name, data...)
+	}
+}
+
+// errorCode maps an error to a stable code for the frontend
+func errorCode(ctx context.Context, stepName string, err error) string {
+	switch {
+	case ctx.Err() != nil:
+		return ErrorCodeCancelled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorCodeTimeout
+	}
+	if code := auth.Code(err); code != "" {
+		return code
+	}
+	for _, code := range captchaErrorCodes {
+		if strings.Contains(err.Error(), code) {
+			return code
+		}
+	}
+	return stepName + "_failed"
+}
+
+func stepResult(progress types.LoginProgress) types.LoginStepResult {
+	return types.LoginStepResult{
+		Step:      progress.Step,
+		Status:    progress.Status,
+		Attempts:  progress.Attempt,
+		ElapsedMs: progress.ElapsedMs,
+		ErrorCode: progress.ErrorCode,
+		Error:     progress.Error,
+	}
+}
@@ -0,0 +1,639 @@
+package login
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hex-boost/hex-nexus-app/backend/client"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/account"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/login/mocks"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/riot"
+	"github.com/hex-boost/hex-nexus-app/backend/riot/auth"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPipeline returns a pipeline that polls every millisecond and bounds every step to a second. It's
+// the only shared constructor of the tests, the mocks are still built in each test but every pipeline needs
+// its policies shortened so the retries and timeouts don't take the real durations
+func newTestPipeline(t *testing.T, riotClient RiotClient, clientMonitor ClientMonitor, leagueClient LeagueClient, app AppEmitter) *Pipeline {
+	pipeline := NewPipeline(logger.New("TestPipeline", &config.Config{LogLevel: "error"}), riotClient, clientMonitor, leagueClient)
+	pipeline.pollInterval = time.Millisecond
+	for name, policy := range pipeline.policies {
+		policy.Timeout = time.Second
+		policy.Backoff = time.Millisecond
+		pipeline.policies[name] = policy
+	}
+	pipeline.SetApp(app)
+	return pipeline
+}
+
+// expectProgress accepts every progress event of mockApp, the returned function lists the statuses
+// emitted for a step
+func expectProgress(mockApp *mocks.AppEmitter) func(step string) []string {
+	var mutex sync.Mutex
+	var progress []types.LoginProgress
+	mockApp.EXPECT().EmitEvent(EventLoginProgress, mock.Anything).Run(func(name string, data ...interface{}) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		progress = append(progress, data[0].(types.LoginProgress))
+	}).Return().Maybe()
+
+	return func(step string) []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		statuses := make([]string, 0)
+		for _, p := range progress {
+			if p.Step == step {
+				statuses = append(statuses, p.Status)
+			}
+		}
+		return statuses
+	}
+}
+
+// expectClosedClient makes mockRiot start running once launched
+func expectClosedClient(mockRiot *mocks.RiotClient) {
+	var running atomic.Bool
+	mockRiot.EXPECT().IsRunning().RunAndReturn(running.Load)
+	mockRiot.EXPECT().Launch().RunAndReturn(func() error {
+		running.Store(true)
+		return nil
+	}).Once()
+}
+
+// expectLogin accepts the captcha and the login, the userinfo is only available once logged in
+func expectLogin(mockRiot *mocks.RiotClient, mockMonitor *mocks.ClientMonitor, loginErr error) *atomic.Bool {
+	var loggedIn atomic.Bool
+	mockMonitor.EXPECT().IsLoginReady().Return(true)
+	mockMonitor.EXPECT().OpenWebviewAndGetToken(mock.Anything).Return("captcha-token", nil)
+	mockMonitor.EXPECT().HandleLogin(mock.Anything, "rented", mock.Anything, "captcha-token").RunAndReturn(func(ctx context.Context, username string, password *types.Credential, captchaToken string) error {
+		defer password.Destroy()
+		if loginErr == nil {
+			loggedIn.Store(true)
+		}
+		return loginErr
+	}).Once()
+	mockRiot.EXPECT().GetUserinfo().RunAndReturn(func() (*types.UserInfo, error) {
+		if !loggedIn.Load() {
+			return nil, &auth.Error{Kind: auth.ErrClientNotReady}
+		}
+		return &types.UserInfo{Username: "rented"}, nil
+	}).Maybe()
+	return &loggedIn
+}
+
+// expectLCUSync makes the LCU connection ready after a few checks and accepts the sync
+func expectLCUSync(mockLCU *mocks.LeagueClient) {
+	var checks atomic.Int32
+	mockLCU.EXPECT().IsLCUConnectionReady().RunAndReturn(func() bool {
+		return checks.Add(1) > 2
+	})
+	mockLCU.EXPECT().UpdateFromLCU().Return(nil).Once()
+}
+
+func newRequest(t *testing.T, password string) types.LoginRequest {
+	credential, err := types.NewCredential([]byte(password))
+	require.NoError(t, err)
+	return types.LoginRequest{Username: "rented", Password: credential}
+}
+
+func TestPipeline_Run(t *testing.T) {
+	t.Run("Runs every step from a closed client", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockMonitor := mocks.NewClientMonitor(t)
+		mockLCU := mocks.NewLeagueClient(t)
+		mockApp := mocks.NewAppEmitter(t)
+		statuses := expectProgress(mockApp)
+
+		expectClosedClient(mockRiot)
+		mockMonitor.EXPECT().IsLoginReady().Return(true)
+		mockMonitor.EXPECT().OpenWebviewAndGetToken(mock.Anything).Return("captcha-token", nil).Once()
+		request := newRequest(t, "secret")
+		mockMonitor.EXPECT().HandleLogin(mock.Anything, "rented", mock.Anything, "captcha-token").RunAndReturn(func(ctx context.Context, username string, password *types.Credential, captchaToken string) error {
+			defer password.Destroy()
+			assert.NotSame(t, request.Password, password, "every attempt gets its own copy")
+			assert.False(t, password.Destroyed())
+			return nil
+		}).Once()
+		mockRiot.EXPECT().GetUserinfo().Return(&types.UserInfo{Username: "rented"}, nil).Once()
+		mockRiot.EXPECT().CheckAccountBanned("rented").Return(nil).Once()
+		mockRiot.EXPECT().CheckRequiredActions("rented").Return(nil).Once()
+		expectLCUSync(mockLCU)
+
+		result := newTestPipeline(t, mockRiot, mockMonitor, mockLCU, mockApp).Run(context.Background(), request)
+
+		assert.True(t, result.Success)
+		assert.Empty(t, result.FailedStep)
+		assert.Len(t, result.Steps, 9)
+		assert.Equal(t, []string{types.LoginStepStarted, types.LoginStepSucceeded}, statuses(StepLCUSync))
+		assert.True(t, request.Password.Destroyed(), "password should be destroyed")
+	})
+
+	t.Run("Does not launch a running client", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockMonitor := mocks.NewClientMonitor(t)
+		mockLCU := mocks.NewLeagueClient(t)
+		mockApp := mocks.NewAppEmitter(t)
+		expectProgress(mockApp)
+
+		mockRiot.EXPECT().IsRunning().Return(true)
+		expectLogin(mockRiot, mockMonitor, nil)
+		mockRiot.EXPECT().CheckAccountBanned("rented").Return(nil).Once()
+		mockRiot.EXPECT().CheckRequiredActions("rented").Return(nil).Once()
+		expectLCUSync(mockLCU)
+
+		result := newTestPipeline(t, mockRiot, mockMonitor, mockLCU, mockApp).Run(context.Background(), newRequest(t, "secret"))
+
+		assert.True(t, result.Success)
+		mockRiot.AssertNotCalled(t, "Launch")
+	})
+
+	t.Run("Retries a failed step following its policy", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockMonitor := mocks.NewClientMonitor(t)
+		mockLCU := mocks.NewLeagueClient(t)
+		mockApp := mocks.NewAppEmitter(t)
+		statuses := expectProgress(mockApp)
+
+		mockRiot.EXPECT().IsRunning().Return(true)
+		expectLogin(mockRiot, mockMonitor, nil)
+		mockRiot.EXPECT().CheckAccountBanned("rented").Return(errors.New("failed to get user info")).Once()
+		mockRiot.EXPECT().CheckAccountBanned("rented").Return(nil).Once()
+		mockRiot.EXPECT().CheckRequiredActions("rented").Return(nil).Once()
+		expectLCUSync(mockLCU)
+
+		result := newTestPipeline(t, mockRiot, mockMonitor, mockLCU, mockApp).Run(context.Background(), newRequest(t, "secret"))
+
+		assert.True(t, result.Success)
+		assert.Equal(t, 2, result.Steps[6].Attempts)
+		assert.Equal(t, []string{
+			types.LoginStepStarted, types.LoginStepRetrying, types.LoginStepStarted, types.LoginStepSucceeded,
+		}, statuses(StepBanCheck))
+	})
+
+	t.Run("Stops on a permanent error without retrying", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockMonitor := mocks.NewClientMonitor(t)
+		mockApp := mocks.NewAppEmitter(t)
+		expectProgress(mockApp)
+
+		mockRiot.EXPECT().IsRunning().Return(true)
+		expectLogin(mockRiot, mockMonitor, &auth.Error{Kind: auth.ErrInvalidCredentials, Username: "rented"})
+
+		pipeline := newTestPipeline(t, mockRiot, mockMonitor, mocks.NewLeagueClient(t), mockApp)
+		pipeline.policies[StepLogin] = StepPolicy{Timeout: time.Second, Attempts: 3}
+		result := pipeline.Run(context.Background(), newRequest(t, "secret"))
+
+		assert.False(t, result.Success)
+		assert.Equal(t, StepLogin, result.FailedStep)
+		assert.Equal(t, auth.CodeInvalidCredentials, result.ErrorCode)
+		assert.Len(t, result.Steps, 4)
+	})
+
+	t.Run("Stops on a required action before syncing the LCU", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockMonitor := mocks.NewClientMonitor(t)
+		mockApp := mocks.NewAppEmitter(t)
+		statuses := expectProgress(mockApp)
+
+		mockRiot.EXPECT().IsRunning().Return(true)
+		expectLogin(mockRiot, mockMonitor, nil)
+		mockRiot.EXPECT().CheckAccountBanned("rented").Return(nil).Once()
+		mockRiot.EXPECT().CheckRequiredActions("rented").
+			Return(auth.RequiredActionError("rented", []types.RequiredAction{{Type: types.RequiredActionEula}})).Once()
+
+		result := newTestPipeline(t, mockRiot, mockMonitor, mocks.NewLeagueClient(t), mockApp).Run(context.Background(), newRequest(t, "secret"))
+
+		assert.Equal(t, StepRequiredActions, result.FailedStep)
+		assert.Equal(t, auth.CodeEulaRequired, result.ErrorCode)
+		assert.Equal(t, []string{types.LoginStepStarted, types.LoginStepFailed}, statuses(StepRequiredActions))
+	})
+
+	t.Run("Waits for the multifactor code and continues", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockMonitor := mocks.NewClientMonitor(t)
+		mockLCU := mocks.NewLeagueClient(t)
+		mockApp := mocks.NewAppEmitter(t)
+		statuses := expectProgress(mockApp)
+
+		mockRiot.EXPECT().IsRunning().Return(true)
+		loggedIn := expectLogin(mockRiot, mockMonitor, &auth.Error{Kind: auth.ErrMultifactorRequired, Username: "rented"})
+		mockMonitor.EXPECT().WaitForMultifactor(mock.Anything).RunAndReturn(func(ctx context.Context) error {
+			loggedIn.Store(true)
+			return nil
+		}).Once()
+		mockRiot.EXPECT().CheckAccountBanned("rented").Return(nil).Once()
+		mockRiot.EXPECT().CheckRequiredActions("rented").Return(nil).Once()
+		expectLCUSync(mockLCU)
+
+		result := newTestPipeline(t, mockRiot, mockMonitor, mockLCU, mockApp).Run(context.Background(), newRequest(t, "secret"))
+
+		assert.True(t, result.Success)
+		assert.Equal(t, []string{types.LoginStepStarted, types.LoginStepSucceeded}, statuses(StepMultifactor))
+	})
+
+	t.Run("Skips the multifactor step when not required", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockMonitor := mocks.NewClientMonitor(t)
+		mockLCU := mocks.NewLeagueClient(t)
+		mockApp := mocks.NewAppEmitter(t)
+		statuses := expectProgress(mockApp)
+
+		mockRiot.EXPECT().IsRunning().Return(true)
+		expectLogin(mockRiot, mockMonitor, nil)
+		mockRiot.EXPECT().CheckAccountBanned("rented").Return(nil).Once()
+		mockRiot.EXPECT().CheckRequiredActions("rented").Return(nil).Once()
+		expectLCUSync(mockLCU)
+
+		result := newTestPipeline(t, mockRiot, mockMonitor, mockLCU, mockApp).Run(context.Background(), newRequest(t, "secret"))
+
+		assert.True(t, result.Success)
+		assert.Equal(t, []string{types.LoginStepSkipped}, statuses(StepMultifactor))
+		mockMonitor.AssertNotCalled(t, "WaitForMultifactor", mock.Anything)
+	})
+
+	t.Run("Fails when the multifactor login is abandoned", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockMonitor := mocks.NewClientMonitor(t)
+		mockApp := mocks.NewAppEmitter(t)
+		expectProgress(mockApp)
+
+		mockRiot.EXPECT().IsRunning().Return(true)
+		expectLogin(mockRiot, mockMonitor, &auth.Error{Kind: auth.ErrMultifactorRequired, Username: "rented"})
+		mockMonitor.EXPECT().WaitForMultifactor(mock.Anything).Return(auth.ErrMultifactorRequired).Once()
+
+		result := newTestPipeline(t, mockRiot, mockMonitor, mocks.NewLeagueClient(t), mockApp).Run(context.Background(), newRequest(t, "secret"))
+
+		assert.Equal(t, StepMultifactor, result.FailedStep)
+		assert.Equal(t, auth.CodeMultifactorRequired, result.ErrorCode)
+	})
+
+	t.Run("Fails a step that exceeds its timeout", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockMonitor := mocks.NewClientMonitor(t)
+		mockApp := mocks.NewAppEmitter(t)
+		statuses := expectProgress(mockApp)
+
+		mockRiot.EXPECT().IsRunning().Return(true)
+		mockMonitor.EXPECT().IsLoginReady().Return(false)
+
+		pipeline := newTestPipeline(t, mockRiot, mockMonitor, mocks.NewLeagueClient(t), mockApp)
+		pipeline.policies[StepLoginReady] = StepPolicy{Timeout: 20 * time.Millisecond, Attempts: 1}
+		result := pipeline.Run(context.Background(), newRequest(t, "secret"))
+
+		assert.Equal(t, StepLoginReady, result.FailedStep)
+		assert.Equal(t, ErrorCodeTimeout, result.ErrorCode)
+		assert.Equal(t, []string{types.LoginStepStarted, types.LoginStepFailed}, statuses(StepLoginReady))
+	})
+
+	t.Run("A timed out captcha ends before it's retried", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockMonitor := mocks.NewClientMonitor(t)
+		mockApp := mocks.NewAppEmitter(t)
+		statuses := expectProgress(mockApp)
+
+		var inProgress atomic.Bool
+		mockRiot.EXPECT().IsRunning().Return(true)
+		mockMonitor.EXPECT().IsLoginReady().Return(true)
+		mockMonitor.EXPECT().OpenWebviewAndGetToken(mock.Anything).RunAndReturn(func(ctx context.Context) (string, error) {
+			inProgress.Store(true)
+			<-ctx.Done()
+			// The webview takes a moment to close
+			time.Sleep(10 * time.Millisecond)
+			inProgress.Store(false)
+			return "", ctx.Err()
+		}).Once()
+		mockMonitor.EXPECT().OpenWebviewAndGetToken(mock.Anything).RunAndReturn(func(ctx context.Context) (string, error) {
+			if inProgress.Load() {
+				return "", errors.New("captcha_already_in_progress")
+			}
+			return "", errors.New("captcha_cancelled")
+		}).Once()
+
+		pipeline := newTestPipeline(t, mockRiot, mockMonitor, mocks.NewLeagueClient(t), mockApp)
+		pipeline.policies[StepCaptcha] = StepPolicy{Timeout: 20 * time.Millisecond, Attempts: 2}
+		result := pipeline.Run(context.Background(), newRequest(t, "secret"))
+
+		assert.Equal(t, StepCaptcha, result.FailedStep)
+		assert.Equal(t, "captcha_cancelled", result.Error, "the retry doesn't overlap the timed out attempt")
+		assert.Equal(t, []string{
+			types.LoginStepStarted, types.LoginStepRetrying, types.LoginStepStarted, types.LoginStepFailed,
+		}, statuses(StepCaptcha))
+	})
+
+	t.Run("Unknown errors get a step code", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockApp := mocks.NewAppEmitter(t)
+		expectProgress(mockApp)
+
+		mockRiot.EXPECT().IsRunning().Return(false)
+		mockRiot.EXPECT().Launch().Return(errors.New("could not find Riot client path in installs file")).Times(2)
+
+		result := newTestPipeline(t, mockRiot, mocks.NewClientMonitor(t), mocks.NewLeagueClient(t), mockApp).Run(context.Background(), newRequest(t, "secret"))
+
+		assert.Equal(t, StepLaunch, result.FailedStep)
+		assert.Equal(t, "launch_failed", result.ErrorCode)
+	})
+
+	t.Run("Selects the launch profile of the rented account", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockProfiles := mocks.NewProfileSelector(t)
+		mockApp := mocks.NewAppEmitter(t)
+		expectProgress(mockApp)
+
+		mockProfiles.EXPECT().SetActiveAccount("rented").Return().Once()
+		mockRiot.EXPECT().IsRunning().Return(false)
+		mockRiot.EXPECT().Launch().Return(errors.New("launch failed")).Times(2)
+
+		pipeline := newTestPipeline(t, mockRiot, mocks.NewClientMonitor(t), mocks.NewLeagueClient(t), mockApp)
+		pipeline.SetProfileSelector(mockProfiles)
+		pipeline.Run(context.Background(), newRequest(t, "secret"))
+	})
+}
+
+func TestPipeline_Login(t *testing.T) {
+	t.Run("Cancel stops the running step", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockMonitor := mocks.NewClientMonitor(t)
+		mockApp := mocks.NewAppEmitter(t)
+		expectProgress(mockApp)
+
+		// The login may be cancelled before it reaches the captcha
+		mockRiot.EXPECT().IsRunning().Return(true).Maybe()
+		mockMonitor.EXPECT().IsLoginReady().Return(true).Maybe()
+		mockMonitor.EXPECT().OpenWebviewAndGetToken(mock.Anything).RunAndReturn(func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}).Maybe()
+
+		pipeline := newTestPipeline(t, mockRiot, mockMonitor, mocks.NewLeagueClient(t), mockApp)
+		resultChan := make(chan *types.LoginResult, 1)
+		go func() {
+			result, _ := pipeline.Login(newRequest(t, "secret"))
+			resultChan <- result
+		}()
+
+		assert.Eventually(t, func() bool {
+			pipeline.mutex.Lock()
+			defer pipeline.mutex.Unlock()
+			return pipeline.cancel != nil
+		}, time.Second, time.Millisecond)
+
+		_, err := pipeline.Login(newRequest(t, "other"))
+		assert.ErrorIs(t, err, ErrLoginInProgress)
+
+		pipeline.Cancel()
+		result := <-resultChan
+
+		assert.False(t, result.Success)
+		assert.Equal(t, ErrorCodeCancelled, result.ErrorCode)
+	})
+}
+
+// riotServer serves the Riot client and LCU endpoints read by the pipeline and the backend saving the
+// account. The userinfo and the LCU are only available once loggedIn is set, like a client that just
+// authenticated
+type riotServer struct {
+	*httptest.Server
+	backend      *httptest.Server
+	loggedIn     atomic.Bool
+	restrictions []types.Restriction
+	// stored are the restrictions the backend has for the account
+	stored         []types.Restriction
+	eulaAcceptance string
+	nameChangeFlag bool
+	saved          chan types.PartialSummonerRented
+}
+
+func newRiotServer(t *testing.T) *riotServer {
+	server := &riotServer{eulaAcceptance: auth.EulaAccepted, saved: make(chan types.PartialSummonerRented, 10)}
+	riotAuthorization := "Basic " + base64.StdEncoding.EncodeToString([]byte("riot:remoting-token"))
+
+	mux := http.NewServeMux()
+	riotHandler := func(pattern string, handler func(w http.ResponseWriter, r *http.Request)) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != riotAuthorization {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler(w, r)
+		})
+	}
+	riotHandler("GET /rso-auth/v1/authorization/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if !server.loggedIn.Load() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		userInfo := types.UserInfo{Username: "rented", Acct: types.Account{GameName: "Rented"}}
+		userInfo.Ban.Restrictions = server.restrictions
+		data, _ := json.Marshal(userInfo)
+		writeJSON(w, types.RCUUserinfo{UserInfo: string(data)})
+	})
+	riotHandler("GET /eula/v1/agreement/acceptance", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, server.eulaAcceptance)
+	})
+	riotHandler("GET /age-restriction/v1/age-restriction/products/league_of_legends", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]bool{"restricted": false})
+	})
+	mux.HandleFunc("GET /lol-summoner/v1/current-summoner", func(w http.ResponseWriter, r *http.Request) {
+		if !server.loggedIn.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, types.CurrentSummoner{GameName: "Rented", NameChangeFlag: server.nameChangeFlag})
+	})
+	server.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	server.backend = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/api/accounts/rented" {
+			rented := types.SummonerRented{Username: "rented"}
+			rented.Ban.Restrictions = server.stored
+			writeJSON(w, types.RentedAccountsResponse{Data: []types.SummonerRented{rented}})
+			return
+		}
+		var summoner types.PartialSummonerRented
+		if r.Method != http.MethodPut || r.URL.Path != "/api/accounts/refresh" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&summoner); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		server.saved <- summoner
+		writeJSON(w, types.RefreshResponseData{})
+	}))
+	t.Cleanup(server.backend.Close)
+	return server
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+// httpRiotClient is the Riot client service reading riotServer. Process and window detection only work
+// on Windows, so a client runs once Launch wrote its lockfile
+type httpRiotClient struct {
+	*riot.Service
+	lockfile string
+	port     string
+}
+
+func newHTTPRiotClient(t *testing.T, server *riotServer) *httpRiotClient {
+	localAppData := t.TempDir()
+	t.Setenv("LOCALAPPDATA", localAppData)
+	t.Setenv("PROGRAMDATA", t.TempDir())
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	cfg := &config.Config{LogLevel: "error", BackendURL: server.backend.URL, RefreshApiKey: "api-key"}
+	accountLogger := logger.New("TestAccountClient", cfg)
+	accountClient := account.NewClient(accountLogger, cfg, client.NewHTTPClient(client.NewBaseClient(accountLogger, cfg)))
+	return &httpRiotClient{
+		Service:  riot.NewService(logger.New("TestRiot", cfg), nil, accountClient),
+		lockfile: filepath.Join(localAppData, "Riot Games", "Riot Client", "Config", "lockfile"),
+		port:     serverURL.Port(),
+	}
+}
+
+func (c *httpRiotClient) Launch() error {
+	if err := os.MkdirAll(filepath.Dir(c.lockfile), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.lockfile, []byte("Riot Client:1234:"+c.port+":remoting-token:https"), 0644)
+}
+
+func (c *httpRiotClient) IsRunning() bool {
+	return c.LockFileExists() && c.InitializeClient() == nil
+}
+
+// httpLeagueClient reads the current summoner of riotServer as the LCU
+type httpLeagueClient struct {
+	client  *resty.Client
+	updates atomic.Int32
+}
+
+func newHTTPLeagueClient(server *riotServer) *httpLeagueClient {
+	client := resty.New().SetBaseURL(server.URL)
+	client.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
+	return &httpLeagueClient{client: client}
+}
+
+func (c *httpLeagueClient) GetCurrentSummoner() (*types.CurrentSummoner, error) {
+	var summoner types.CurrentSummoner
+	resp, err := c.client.R().SetResult(&summoner).Get("/lol-summoner/v1/current-summoner")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("current summoner not available: %d", resp.StatusCode())
+	}
+	return &summoner, nil
+}
+
+func (c *httpLeagueClient) IsLCUConnectionReady() bool {
+	_, err := c.GetCurrentSummoner()
+	return err == nil
+}
+
+func (c *httpLeagueClient) UpdateFromLCU() error {
+	if _, err := c.GetCurrentSummoner(); err != nil {
+		return err
+	}
+	c.updates.Add(1)
+	return nil
+}
+
+func TestPipeline_RiotClientAPI(t *testing.T) {
+	// newServerPipeline runs the pipeline against server, the captcha and the login are the only mocked calls
+	newServerPipeline := func(t *testing.T, server *riotServer) (*Pipeline, *httpLeagueClient) {
+		riotClient := newHTTPRiotClient(t, server)
+		leagueClient := newHTTPLeagueClient(server)
+		riotClient.SetCurrentSummonerSource(leagueClient)
+
+		mockMonitor := mocks.NewClientMonitor(t)
+		mockMonitor.EXPECT().IsLoginReady().RunAndReturn(riotClient.IsClientInitialized)
+		mockMonitor.EXPECT().OpenWebviewAndGetToken(mock.Anything).Return("captcha-token", nil).Once()
+		mockMonitor.EXPECT().HandleLogin(mock.Anything, "rented", mock.Anything, "captcha-token").RunAndReturn(func(ctx context.Context, username string, password *types.Credential, captchaToken string) error {
+			password.Destroy()
+			server.loggedIn.Store(true)
+			return nil
+		}).Once()
+		mockApp := mocks.NewAppEmitter(t)
+		expectProgress(mockApp)
+
+		pipeline := newTestPipeline(t, riotClient, mockMonitor, leagueClient, mockApp)
+		pipeline.policies[StepBanCheck] = StepPolicy{Timeout: 5 * time.Second, Attempts: 1}
+		return pipeline, leagueClient
+	}
+
+	t.Run("Logs in from a closed client and syncs the LCU", func(t *testing.T) {
+		server := newRiotServer(t)
+		pipeline, leagueClient := newServerPipeline(t, server)
+
+		result := pipeline.Run(context.Background(), newRequest(t, "secret"))
+
+		require.True(t, result.Success, "failed at %s: %s", result.FailedStep, result.Error)
+		assert.Equal(t, int32(1), leagueClient.updates.Load())
+		assert.Empty(t, server.saved, "nothing to save for a clean account")
+	})
+
+	t.Run("Banned account is saved and stops the login", func(t *testing.T) {
+		server := newRiotServer(t)
+		server.restrictions = []types.Restriction{{Type: "PERMANENT_BAN", Scope: "lol"}}
+		pipeline, leagueClient := newServerPipeline(t, server)
+
+		result := pipeline.Run(context.Background(), newRequest(t, "secret"))
+
+		assert.Equal(t, StepBanCheck, result.FailedStep)
+		assert.Equal(t, auth.CodePermanentBan, result.ErrorCode)
+		require.Len(t, server.saved, 1)
+		saved := <-server.saved
+		assert.Equal(t, "rented", saved.Username)
+		assert.Equal(t, "PERMANENT_BAN", saved.Ban.Restrictions[0].Type)
+		assert.Zero(t, leagueClient.updates.Load())
+	})
+
+	t.Run("Required actions of the Riot client and LCU are saved", func(t *testing.T) {
+		server := newRiotServer(t)
+		server.eulaAcceptance = "AcceptanceRequired"
+		server.nameChangeFlag = true
+		server.stored = []types.Restriction{{Type: "QUEUE_LOCKOUT", Reason: "leaver"}}
+		pipeline, leagueClient := newServerPipeline(t, server)
+
+		result := pipeline.Run(context.Background(), newRequest(t, "secret"))
+
+		assert.Equal(t, StepRequiredActions, result.FailedStep)
+		assert.Equal(t, auth.CodeEulaRequired, result.ErrorCode)
+		require.Len(t, server.saved, 1)
+		saved := <-server.saved
+		restrictionTypes := make([]string, 0, len(saved.Ban.Restrictions))
+		for _, restriction := range saved.Ban.Restrictions {
+			restrictionTypes = append(restrictionTypes, restriction.Type)
+		}
+		assert.Equal(t, []string{"QUEUE_LOCKOUT", types.RequiredActionEula, types.RequiredActionNameChange}, restrictionTypes,
+			"the EULA and the name change are added to the saved restrictions")
+		assert.Zero(t, leagueClient.updates.Load())
+	})
+}
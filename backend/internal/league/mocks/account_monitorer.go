@@ -0,0 +1,156 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// AccountMonitorer is an autogenerated mock type for the AccountMonitorer type
+type AccountMonitorer struct {
+	mock.Mock
+}
+
+type AccountMonitorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AccountMonitorer) EXPECT() *AccountMonitorer_Expecter {
+	return &AccountMonitorer_Expecter{mock: &_m.Mock}
+}
+
+// GetLoggedInUsername provides a mock function with given fields: lastUsername
+func (_m *AccountMonitorer) GetLoggedInUsername(lastUsername string) string {
+	ret := _m.Called(lastUsername)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoggedInUsername")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(lastUsername)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// AccountMonitorer_GetLoggedInUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoggedInUsername'
+type AccountMonitorer_GetLoggedInUsername_Call struct {
+	*mock.Call
+}
+
+// GetLoggedInUsername is a helper method to define mock.On call
+//   - lastUsername string
+func (_e *AccountMonitorer_Expecter) GetLoggedInUsername(lastUsername interface{}) *AccountMonitorer_GetLoggedInUsername_Call {
+	return &AccountMonitorer_GetLoggedInUsername_Call{Call: _e.mock.On("GetLoggedInUsername", lastUsername)}
+}
+
+func (_c *AccountMonitorer_GetLoggedInUsername_Call) Run(run func(lastUsername string)) *AccountMonitorer_GetLoggedInUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *AccountMonitorer_GetLoggedInUsername_Call) Return(_a0 string) *AccountMonitorer_GetLoggedInUsername_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AccountMonitorer_GetLoggedInUsername_Call) RunAndReturn(run func(string) string) *AccountMonitorer_GetLoggedInUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsNexusAccount provides a mock function with no fields
+func (_m *AccountMonitorer) IsNexusAccount() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsNexusAccount")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// AccountMonitorer_IsNexusAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsNexusAccount'
+type AccountMonitorer_IsNexusAccount_Call struct {
+	*mock.Call
+}
+
+// IsNexusAccount is a helper method to define mock.On call
+func (_e *AccountMonitorer_Expecter) IsNexusAccount() *AccountMonitorer_IsNexusAccount_Call {
+	return &AccountMonitorer_IsNexusAccount_Call{Call: _e.mock.On("IsNexusAccount")}
+}
+
+func (_c *AccountMonitorer_IsNexusAccount_Call) Run(run func()) *AccountMonitorer_IsNexusAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AccountMonitorer_IsNexusAccount_Call) Return(_a0 bool) *AccountMonitorer_IsNexusAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AccountMonitorer_IsNexusAccount_Call) RunAndReturn(run func() bool) *AccountMonitorer_IsNexusAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNexusAccount provides a mock function with given fields: _a0
+func (_m *AccountMonitorer) SetNexusAccount(_a0 bool) {
+	_m.Called(_a0)
+}
+
+// AccountMonitorer_SetNexusAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNexusAccount'
+type AccountMonitorer_SetNexusAccount_Call struct {
+	*mock.Call
+}
+
+// SetNexusAccount is a helper method to define mock.On call
+//   - _a0 bool
+func (_e *AccountMonitorer_Expecter) SetNexusAccount(_a0 interface{}) *AccountMonitorer_SetNexusAccount_Call {
+	return &AccountMonitorer_SetNexusAccount_Call{Call: _e.mock.On("SetNexusAccount", _a0)}
+}
+
+func (_c *AccountMonitorer_SetNexusAccount_Call) Run(run func(_a0 bool)) *AccountMonitorer_SetNexusAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(bool))
+	})
+	return _c
+}
+
+func (_c *AccountMonitorer_SetNexusAccount_Call) Return() *AccountMonitorer_SetNexusAccount_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *AccountMonitorer_SetNexusAccount_Call) RunAndReturn(run func(bool)) *AccountMonitorer_SetNexusAccount_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewAccountMonitorer creates a new instance of AccountMonitorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountMonitorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountMonitorer {
+	mock := &AccountMonitorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,76 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// AppEmitter is an autogenerated mock type for the AppEmitter type
+type AppEmitter struct {
+	mock.Mock
+}
+
+type AppEmitter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AppEmitter) EXPECT() *AppEmitter_Expecter {
+	return &AppEmitter_Expecter{mock: &_m.Mock}
+}
+
+// EmitEvent provides a mock function with given fields: name, data
+func (_m *AppEmitter) EmitEvent(name string, data ...interface{}) {
+	var _ca []interface{}
+	_ca = append(_ca, name)
+	_ca = append(_ca, data...)
+	_m.Called(_ca...)
+}
+
+// AppEmitter_EmitEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EmitEvent'
+type AppEmitter_EmitEvent_Call struct {
+	*mock.Call
+}
+
+// EmitEvent is a helper method to define mock.On call
+//   - name string
+//   - data ...interface{}
+func (_e *AppEmitter_Expecter) EmitEvent(name interface{}, data ...interface{}) *AppEmitter_EmitEvent_Call {
+	return &AppEmitter_EmitEvent_Call{Call: _e.mock.On("EmitEvent",
+		append([]interface{}{name}, data...)...)}
+}
+
+func (_c *AppEmitter_EmitEvent_Call) Run(run func(name string, data ...interface{})) *AppEmitter_EmitEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *AppEmitter_EmitEvent_Call) Return() *AppEmitter_EmitEvent_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *AppEmitter_EmitEvent_Call) RunAndReturn(run func(string, ...interface{})) *AppEmitter_EmitEvent_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewAppEmitter creates a new instance of AppEmitter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAppEmitter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AppEmitter {
+	mock := &AppEmitter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,426 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+)
+
+// Authenticator is an autogenerated mock type for the Authenticator type
+type Authenticator struct {
+	mock.Mock
+}
+
+type Authenticator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Authenticator) EXPECT() *Authenticator_Expecter {
+	return &Authenticator_Expecter{mock: &_m.Mock}
+}
+
+// GetAuthenticationState provides a mock function with no fields
+func (_m *Authenticator) GetAuthenticationState() (*types.RiotIdentityResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthenticationState")
+	}
+
+	var r0 *types.RiotIdentityResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.RiotIdentityResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.RiotIdentityResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RiotIdentityResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Authenticator_GetAuthenticationState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthenticationState'
+type Authenticator_GetAuthenticationState_Call struct {
+	*mock.Call
+}
+
+// GetAuthenticationState is a helper method to define mock.On call
+func (_e *Authenticator_Expecter) GetAuthenticationState() *Authenticator_GetAuthenticationState_Call {
+	return &Authenticator_GetAuthenticationState_Call{Call: _e.mock.On("GetAuthenticationState")}
+}
+
+func (_c *Authenticator_GetAuthenticationState_Call) Run(run func()) *Authenticator_GetAuthenticationState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Authenticator_GetAuthenticationState_Call) Return(_a0 *types.RiotIdentityResponse, _a1 error) *Authenticator_GetAuthenticationState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Authenticator_GetAuthenticationState_Call) RunAndReturn(run func() (*types.RiotIdentityResponse, error)) *Authenticator_GetAuthenticationState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InitializeClient provides a mock function with no fields
+func (_m *Authenticator) InitializeClient() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitializeClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Authenticator_InitializeClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InitializeClient'
+type Authenticator_InitializeClient_Call struct {
+	*mock.Call
+}
+
+// InitializeClient is a helper method to define mock.On call
+func (_e *Authenticator_Expecter) InitializeClient() *Authenticator_InitializeClient_Call {
+	return &Authenticator_InitializeClient_Call{Call: _e.mock.On("InitializeClient")}
+}
+
+func (_c *Authenticator_InitializeClient_Call) Run(run func()) *Authenticator_InitializeClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Authenticator_InitializeClient_Call) Return(_a0 error) *Authenticator_InitializeClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Authenticator_InitializeClient_Call) RunAndReturn(run func() error) *Authenticator_InitializeClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsAuthStateValid provides a mock function with no fields
+func (_m *Authenticator) IsAuthStateValid() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAuthStateValid")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Authenticator_IsAuthStateValid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsAuthStateValid'
+type Authenticator_IsAuthStateValid_Call struct {
+	*mock.Call
+}
+
+// IsAuthStateValid is a helper method to define mock.On call
+func (_e *Authenticator_Expecter) IsAuthStateValid() *Authenticator_IsAuthStateValid_Call {
+	return &Authenticator_IsAuthStateValid_Call{Call: _e.mock.On("IsAuthStateValid")}
+}
+
+func (_c *Authenticator_IsAuthStateValid_Call) Run(run func()) *Authenticator_IsAuthStateValid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Authenticator_IsAuthStateValid_Call) Return(_a0 error) *Authenticator_IsAuthStateValid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Authenticator_IsAuthStateValid_Call) RunAndReturn(run func() error) *Authenticator_IsAuthStateValid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsClientInitialized provides a mock function with no fields
+func (_m *Authenticator) IsClientInitialized() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsClientInitialized")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Authenticator_IsClientInitialized_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsClientInitialized'
+type Authenticator_IsClientInitialized_Call struct {
+	*mock.Call
+}
+
+// IsClientInitialized is a helper method to define mock.On call
+func (_e *Authenticator_Expecter) IsClientInitialized() *Authenticator_IsClientInitialized_Call {
+	return &Authenticator_IsClientInitialized_Call{Call: _e.mock.On("IsClientInitialized")}
+}
+
+func (_c *Authenticator_IsClientInitialized_Call) Run(run func()) *Authenticator_IsClientInitialized_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Authenticator_IsClientInitialized_Call) Return(_a0 bool) *Authenticator_IsClientInitialized_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Authenticator_IsClientInitialized_Call) RunAndReturn(run func() bool) *Authenticator_IsClientInitialized_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginWithCaptcha provides a mock function with given fields: ctx, username, password, captchaToken
+func (_m *Authenticator) LoginWithCaptcha(ctx context.Context, username string, password *types.Credential, captchaToken string) (string, error) {
+	ret := _m.Called(ctx, username, password, captchaToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginWithCaptcha")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *types.Credential, string) (string, error)); ok {
+		return rf(ctx, username, password, captchaToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *types.Credential, string) string); ok {
+		r0 = rf(ctx, username, password, captchaToken)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *types.Credential, string) error); ok {
+		r1 = rf(ctx, username, password, captchaToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Authenticator_LoginWithCaptcha_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginWithCaptcha'
+type Authenticator_LoginWithCaptcha_Call struct {
+	*mock.Call
+}
+
+// LoginWithCaptcha is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - password *types.Credential
+//   - captchaToken string
+func (_e *Authenticator_Expecter) LoginWithCaptcha(ctx interface{}, username interface{}, password interface{}, captchaToken interface{}) *Authenticator_LoginWithCaptcha_Call {
+	return &Authenticator_LoginWithCaptcha_Call{Call: _e.mock.On("LoginWithCaptcha", ctx, username, password, captchaToken)}
+}
+
+func (_c *Authenticator_LoginWithCaptcha_Call) Run(run func(ctx context.Context, username string, password *types.Credential, captchaToken string)) *Authenticator_LoginWithCaptcha_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*types.Credential), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Authenticator_LoginWithCaptcha_Call) Return(_a0 string, _a1 error) *Authenticator_LoginWithCaptcha_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Authenticator_LoginWithCaptcha_Call) RunAndReturn(run func(context.Context, string, *types.Credential, string) (string, error)) *Authenticator_LoginWithCaptcha_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function with no fields
+func (_m *Authenticator) Logout() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Authenticator_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type Authenticator_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+func (_e *Authenticator_Expecter) Logout() *Authenticator_Logout_Call {
+	return &Authenticator_Logout_Call{Call: _e.mock.On("Logout")}
+}
+
+func (_c *Authenticator_Logout_Call) Run(run func()) *Authenticator_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Authenticator_Logout_Call) Return(_a0 error) *Authenticator_Logout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Authenticator_Logout_Call) RunAndReturn(run func() error) *Authenticator_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetupCaptchaVerification provides a mock function with no fields
+func (_m *Authenticator) SetupCaptchaVerification() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetupCaptchaVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Authenticator_SetupCaptchaVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetupCaptchaVerification'
+type Authenticator_SetupCaptchaVerification_Call struct {
+	*mock.Call
+}
+
+// SetupCaptchaVerification is a helper method to define mock.On call
+func (_e *Authenticator_Expecter) SetupCaptchaVerification() *Authenticator_SetupCaptchaVerification_Call {
+	return &Authenticator_SetupCaptchaVerification_Call{Call: _e.mock.On("SetupCaptchaVerification")}
+}
+
+func (_c *Authenticator_SetupCaptchaVerification_Call) Run(run func()) *Authenticator_SetupCaptchaVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Authenticator_SetupCaptchaVerification_Call) Return(_a0 error) *Authenticator_SetupCaptchaVerification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Authenticator_SetupCaptchaVerification_Call) RunAndReturn(run func() error) *Authenticator_SetupCaptchaVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubmitMultifactorCode provides a mock function with given fields: code, trustDevice
+func (_m *Authenticator) SubmitMultifactorCode(code string, trustDevice bool) error {
+	ret := _m.Called(code, trustDevice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitMultifactorCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(code, trustDevice)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Authenticator_SubmitMultifactorCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubmitMultifactorCode'
+type Authenticator_SubmitMultifactorCode_Call struct {
+	*mock.Call
+}
+
+// SubmitMultifactorCode is a helper method to define mock.On call
+//   - code string
+//   - trustDevice bool
+func (_e *Authenticator_Expecter) SubmitMultifactorCode(code interface{}, trustDevice interface{}) *Authenticator_SubmitMultifactorCode_Call {
+	return &Authenticator_SubmitMultifactorCode_Call{Call: _e.mock.On("SubmitMultifactorCode", code, trustDevice)}
+}
+
+func (_c *Authenticator_SubmitMultifactorCode_Call) Run(run func(code string, trustDevice bool)) *Authenticator_SubmitMultifactorCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *Authenticator_SubmitMultifactorCode_Call) Return(_a0 error) *Authenticator_SubmitMultifactorCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Authenticator_SubmitMultifactorCode_Call) RunAndReturn(run func(string, bool) error) *Authenticator_SubmitMultifactorCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthenticator creates a new instance of Authenticator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthenticator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Authenticator {
+	mock := &Authenticator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
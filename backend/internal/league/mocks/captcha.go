@@ -0,0 +1,186 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+)
+
+// Captcha is an autogenerated mock type for the Captcha type
+type Captcha struct {
+	mock.Mock
+}
+
+type Captcha_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Captcha) EXPECT() *Captcha_Expecter {
+	return &Captcha_Expecter{mock: &_m.Mock}
+}
+
+// GetWebView provides a mock function with no fields
+func (_m *Captcha) GetWebView() (types.WebviewWindower, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebView")
+	}
+
+	var r0 types.WebviewWindower
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (types.WebviewWindower, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() types.WebviewWindower); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.WebviewWindower)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Captcha_GetWebView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebView'
+type Captcha_GetWebView_Call struct {
+	*mock.Call
+}
+
+// GetWebView is a helper method to define mock.On call
+func (_e *Captcha_Expecter) GetWebView() *Captcha_GetWebView_Call {
+	return &Captcha_GetWebView_Call{Call: _e.mock.On("GetWebView")}
+}
+
+func (_c *Captcha_GetWebView_Call) Run(run func()) *Captcha_GetWebView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Captcha_GetWebView_Call) Return(_a0 types.WebviewWindower, _a1 error) *Captcha_GetWebView_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Captcha_GetWebView_Call) RunAndReturn(run func() (types.WebviewWindower, error)) *Captcha_GetWebView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reset provides a mock function with no fields
+func (_m *Captcha) Reset() {
+	_m.Called()
+}
+
+// Captcha_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type Captcha_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+func (_e *Captcha_Expecter) Reset() *Captcha_Reset_Call {
+	return &Captcha_Reset_Call{Call: _e.mock.On("Reset")}
+}
+
+func (_c *Captcha_Reset_Call) Run(run func()) *Captcha_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Captcha_Reset_Call) Return() *Captcha_Reset_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Captcha_Reset_Call) RunAndReturn(run func()) *Captcha_Reset_Call {
+	_c.Run(run)
+	return _c
+}
+
+// WaitAndGetCaptchaResponse provides a mock function with given fields: ctx, timeout
+func (_m *Captcha) WaitAndGetCaptchaResponse(ctx context.Context, timeout time.Duration) (string, error) {
+	ret := _m.Called(ctx, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitAndGetCaptchaResponse")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (string, error)); ok {
+		return rf(ctx, timeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) string); ok {
+		r0 = rf(ctx, timeout)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Captcha_WaitAndGetCaptchaResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WaitAndGetCaptchaResponse'
+type Captcha_WaitAndGetCaptchaResponse_Call struct {
+	*mock.Call
+}
+
+// WaitAndGetCaptchaResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - timeout time.Duration
+func (_e *Captcha_Expecter) WaitAndGetCaptchaResponse(ctx interface{}, timeout interface{}) *Captcha_WaitAndGetCaptchaResponse_Call {
+	return &Captcha_WaitAndGetCaptchaResponse_Call{Call: _e.mock.On("WaitAndGetCaptchaResponse", ctx, timeout)}
+}
+
+func (_c *Captcha_WaitAndGetCaptchaResponse_Call) Run(run func(ctx context.Context, timeout time.Duration)) *Captcha_WaitAndGetCaptchaResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Captcha_WaitAndGetCaptchaResponse_Call) Return(_a0 string, _a1 error) *Captcha_WaitAndGetCaptchaResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Captcha_WaitAndGetCaptchaResponse_Call) RunAndReturn(run func(context.Context, time.Duration) (string, error)) *Captcha_WaitAndGetCaptchaResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCaptcha creates a new instance of Captcha. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCaptcha(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Captcha {
+	mock := &Captcha{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// RiotServicer is an autogenerated mock type for the RiotServicer type
+type RiotServicer struct {
+	mock.Mock
+}
+
+type RiotServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RiotServicer) EXPECT() *RiotServicer_Expecter {
+	return &RiotServicer_Expecter{mock: &_m.Mock}
+}
+
+// IsRunning provides a mock function with no fields
+func (_m *RiotServicer) IsRunning() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsRunning")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// RiotServicer_IsRunning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRunning'
+type RiotServicer_IsRunning_Call struct {
+	*mock.Call
+}
+
+// IsRunning is a helper method to define mock.On call
+func (_e *RiotServicer_Expecter) IsRunning() *RiotServicer_IsRunning_Call {
+	return &RiotServicer_IsRunning_Call{Call: _e.mock.On("IsRunning")}
+}
+
+func (_c *RiotServicer_IsRunning_Call) Run(run func()) *RiotServicer_IsRunning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RiotServicer_IsRunning_Call) Return(_a0 bool) *RiotServicer_IsRunning_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RiotServicer_IsRunning_Call) RunAndReturn(run func() bool) *RiotServicer_IsRunning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRiotServicer creates a new instance of RiotServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRiotServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RiotServicer {
+	mock := &RiotServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/hex-boost/hex-nexus-app/backend/internal/league/account"
+	"github.com/hex-boost/hex-nexus-app/backend/riot/auth"
 	"github.com/hex-boost/hex-nexus-app/backend/types"
 	"sync"
 	"sync/atomic"
@@ -29,6 +30,7 @@ const (
 	ClientStateLoggedIn       LeagueClientStateType = "LOGGED_IN"
 	ClientStateWaitingCaptcha LeagueClientStateType = "WAITING_CAPTCHA"
 	ClientStateWaitingLogin   LeagueClientStateType = "WAITING_LOGIN"
+	ClientStateWaitingMFA     LeagueClientStateType = "WAITING_MFA"
 )
 
 type LeagueClientState struct {
@@ -62,7 +64,8 @@ type AccountState interface {
 }
 
 type Authenticator interface {
-	LoginWithCaptcha(ctx context.Context, username string, password string, captchaToken string) (string, error)
+	LoginWithCaptcha(ctx context.Context, username string, password *types.Credential, captchaToken string) (string, error)
+	SubmitMultifactorCode(code string, trustDevice bool) error
 	GetAuthenticationState() (*types.RiotIdentityResponse, error)
 	IsAuthStateValid() error
 	Logout() error
@@ -81,6 +84,14 @@ type Captcha interface {
 type RiotServicer interface {
 	IsRunning() bool
 }
+
+// multifactorWait is closed once the login waiting for its multifactor code leaves WAITING_MFA
+type multifactorWait struct {
+	username string
+	done     chan struct{}
+	err      error
+}
+
 type Monitor struct {
 	isFirstUpdated        bool
 	app                   AppEmitter
@@ -95,19 +106,19 @@ type Monitor struct {
 	stateMutex            sync.RWMutex
 	accountState          AccountState
 	captchaFlowInProgress atomic.Bool
-	currentState          *LeagueClientState
+	stateMachine          *StateMachine
+	websocketStarted      bool
 	eventMutex            sync.Mutex
 	isCheckingState       atomic.Bool
 	riotService           RiotServicer
 	accountClient         *account.Client
+	multifactorMutex      sync.Mutex
+	multifactor           *multifactorWait
 }
 
 func NewMonitor(logger *logger.Logger, accountMonitor AccountMonitorer, leagueService LeagueServicer, riotAuth Authenticator, captcha Captcha, accountState AccountState, riotService RiotServicer, accountClient *account.Client) *Monitor {
 
 	logger.Debug("Creating new client monitor")
-	initialState := &LeagueClientState{
-		ClientState: ClientStateNone,
-	}
 
 	monitor := &Monitor{
 		isFirstUpdated: false,
@@ -120,105 +131,138 @@ func NewMonitor(logger *logger.Logger, accountMonitor AccountMonitorer, leagueSe
 		riotAuth:       riotAuth,
 		isRunning:      false,
 		riotService:    riotService,
-		currentState:   initialState,
+		stateMachine:   NewStateMachine(50),
 		stateMutex:     sync.RWMutex{},
 		eventMutex:     sync.Mutex{},
 		accountClient:  accountClient,
 	}
 	monitor.isCheckingState.Store(false)
+	monitor.stateMachine.AddGuard(ClientStateLoginReady, ClientStateWaitingCaptcha, func(from, to LeagueClientStateType) error {
+		if !riotService.IsRunning() {
+			return errors.New("riot client is not running")
+		}
+		return nil
+	})
 
 	return monitor
 }
 
 func (cm *Monitor) GetCurrentState() *LeagueClientState {
-	cm.stateMutex.RLock()
-	defer cm.stateMutex.RUnlock()
+	return &LeagueClientState{ClientState: cm.stateMachine.Current()}
+}
 
-	stateCopy := *cm.currentState
-	return &stateCopy
+// IsLoginReady reports whether the Riot client is showing the login screen
+func (cm *Monitor) IsLoginReady() bool {
+	return cm.stateMachine.Current() == ClientStateLoginReady
 }
 
-func (cm *Monitor) updateState(newState *LeagueClientState) {
+// GetStateHistory returns the latest client state transitions
+func (cm *Monitor) GetStateHistory() []StateTransition {
+	return cm.stateMachine.History()
+}
+
+func (cm *Monitor) updateState(newState *LeagueClientState, cause string) error {
 	cm.stateMutex.Lock()
 	defer cm.stateMutex.Unlock()
 
-	stateChanged := cm.currentState.ClientState != newState.ClientState
+	previousState := cm.stateMachine.Current()
+	stateChanged, err := cm.stateMachine.Transition(newState.ClientState, cause)
+	if err != nil {
+		cm.logger.Warn("Client state transition rejected", zap.Error(err))
+		return err
+	}
 
 	if stateChanged {
-		cm.logger.Sugar().Debugf("State changed old: %s new: %s", cm.currentState.ClientState,
-			newState.ClientState,
+		cm.logger.Sugar().Debugf("State changed old: %s new: %s cause: %s", previousState,
+			newState.ClientState, cause,
 		)
 
-		cm.currentState = newState
 		if newState.ClientState == ClientStateClosed || newState.ClientState == ClientStateLoginReady {
 			cm.logger.Debug("Resetting isNexusAccount to false due to client state",
 				zap.String("clientState", string(newState.ClientState)))
 			cm.accountMonitor.SetNexusAccount(false)
 		}
 
+		if previousState == ClientStateWaitingMFA {
+			var err error
+			if newState.ClientState != ClientStateLoggedIn {
+				err = auth.ErrMultifactorRequired
+			}
+			cm.finishMultifactor(err)
+		}
+
 		cm.emitEvent(EventLeagueStateChanged, newState)
 	}
+	return nil
+}
+
+// collectSignals gathers the process and LCU lifecycle signals, the Riot client auth state is only
+// queried when the league client doesn't already tell us the user is logged in
+func (cm *Monitor) collectSignals(currentState LeagueClientStateType) ClientSignals {
+	signals := ClientSignals{
+		Playing:       cm.leagueService.IsPlaying(),
+		LeagueRunning: cm.leagueService.IsRunning(),
+	}
+	if signals.LeagueRunning {
+		signals.LCUReady = cm.leagueService.IsLCUConnectionReady()
+	}
+	if signals.Playing || signals.LCUReady {
+		return signals
+	}
 
+	signals.RiotClientRunning = cm.riotService.IsRunning()
+	if !signals.RiotClientRunning || currentState == ClientStateWaitingCaptcha {
+		return signals
+	}
+	if !cm.riotAuth.IsClientInitialized() && !cm.initializeRiotClientIfNeeded() {
+		return signals
+	}
+	authState, err := cm.riotAuth.GetAuthenticationState()
+	if err == nil && authState.Type == "success" {
+		signals.Authenticated = true
+		return signals
+	}
+	signals.LoginAvailable = cm.riotAuth.IsAuthStateValid() == nil
+	return signals
+}
+
+func (cm *Monitor) checkClientState() {
+	if !cm.isCheckingState.CompareAndSwap(false, true) {
+		return
+	}
+	defer cm.isCheckingState.Store(false)
+
+	currentState := cm.stateMachine.Current()
+	signals := cm.collectSignals(currentState)
+	nextState, cause := DetectState(currentState, signals)
+
+	if nextState != currentState {
+		if err := cm.updateState(&LeagueClientState{ClientState: nextState}, cause); err != nil {
+			return
+		}
+		if nextState == ClientStateClosed {
+			cm.resetAccountUpdateStatus()
+		}
+	}
+
+	// The websocket can only connect once the LCU is up, which may happen after the state changed
+	if nextState == ClientStateLoggedIn && signals.LCUReady && cm.markWebsocketStarted() {
+		cm.emitEvent(websocketEvents.LeagueWebsocketStart)
+	}
+}
+
+// markWebsocketStarted returns true the first time it's called since the last reset
+func (cm *Monitor) markWebsocketStarted() bool {
+	cm.stateMutex.Lock()
+	defer cm.stateMutex.Unlock()
+
+	if cm.websocketStarted {
+		return false
+	}
+	cm.websocketStarted = true
+	return true
 }
 
-// func (cm *Monitor) checkClientState() {
-//
-//		if !cm.isCheckingState.CompareAndSwap(false, true) {
-//			return
-//		}
-//		defer cm.isCheckingState.Store(false)
-//
-//		previousState := cm.GetCurrentState()
-//		currentState := previousState.ClientState
-//
-//		if currentState == ClientStateWaitingCaptcha {
-//			return
-//		}
-//
-//		if currentState == ClientStateWaitingLogin {
-//
-//			if cm.riotAuth.IsClientInitialized() || cm.initializeRiotClientIfNeeded() {
-//				authState, err := cm.riotAuth.GetAuthenticationState()
-//				if err == nil && authState.Type == "success" {
-//					cm.logger.Info("Auth state success, user is logged in")
-//					cm.updateState(&LeagueClientState{ClientState: ClientStateLoggedIn})
-//					if !cm.isFirstUpdated || cm.accountMonitor.IsNexusAccount() {
-//						//cm.checkAndUpdateAccount()
-//					}
-//				}
-//			}
-//			return
-//		}
-//
-//		if cm.leagueService.IsRunning() || cm.leagueService.IsPlaying() {
-//
-//			if currentState != ClientStateLoggedIn {
-//				cm.updateState(&LeagueClientState{ClientState: ClientStateLoggedIn})
-//			}
-//
-//			if !cm.isFirstUpdated && cm.accountMonitor.IsNexusAccount() {
-//				//cm.checkAndUpdateAccount()
-//			}
-//			return
-//		}
-//
-//		if cm.riotService.IsRunning() {
-//			isAuthStateValid := cm.riotAuth.IsAuthStateValid() == nil
-//			if isAuthStateValid {
-//				if currentState != ClientStateLoginReady {
-//					cm.updateState(&LeagueClientState{ClientState: ClientStateLoginReady})
-//				}
-//				return
-//			}
-//		}
-//
-//		if currentState != ClientStateClosed {
-//			cm.updateState(&LeagueClientState{ClientState: ClientStateClosed})
-//			if cm.isFirstUpdated {
-//				cm.resetAccountUpdateStatus()
-//			}
-//		}
-//	}
 func (cm *Monitor) initializeRiotClientIfNeeded() bool {
 	if !cm.riotAuth.IsClientInitialized() && cm.riotService.IsRunning() {
 		cm.logger.Debug("Client running but not initialized, initializing...")
@@ -367,6 +411,7 @@ func (cm *Monitor) resetAccountUpdateStatus() {
 
 	cm.stateMutex.Lock()
 	cm.isFirstUpdated = false
+	cm.websocketStarted = false
 	_, err := cm.accountState.Update(&types.PartialSummonerRented{Username: ""})
 	if err != nil {
 		cm.logger.Error("Error clearing username in account state", zap.Error(err))
@@ -406,7 +451,7 @@ func (cm *Monitor) Start(app AppEmitter) {
 							cm.logger.Error("Panic recovered in checkClientState loop", zap.Any("panicValue", r), zap.Stack("stack"))
 						}
 					}()
-					//cm.checkClientState()
+					cm.checkClientState()
 				}()
 			case <-done:
 				cm.logger.Info("Client monitor polling goroutine stopping.")
@@ -431,7 +476,8 @@ func (cm *Monitor) Stop() {
 	cm.stateMutex.Unlock()
 }
 
-func (cm *Monitor) OpenWebviewAndGetToken() (string, error) {
+// OpenWebviewAndGetToken shows the captcha and waits for its token, the flow is closed when ctx is done
+func (cm *Monitor) OpenWebviewAndGetToken(ctx context.Context) (string, error) {
 
 	if !cm.captchaFlowInProgress.CompareAndSwap(false, true) {
 		cm.logger.Warn("Captcha flow already in progress")
@@ -444,9 +490,11 @@ func (cm *Monitor) OpenWebviewAndGetToken() (string, error) {
 	newState := &LeagueClientState{
 		ClientState: ClientStateWaitingCaptcha,
 	}
-	cm.updateState(newState)
+	if err := cm.updateState(newState, CauseCaptchaRequested); err != nil {
+		return "", err
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	if err := cm.setupCaptcha(); err != nil {
@@ -484,9 +532,9 @@ func (cm *Monitor) setupCaptcha() error {
 	if err != nil {
 		cm.logger.Error("riotAuth.SetupCaptchaVerification failed", zap.Error(err))
 
-		cm.updateState(&LeagueClientState{
+		_ = cm.updateState(&LeagueClientState{
 			ClientState: ClientStateLoginReady,
-		})
+		}, CauseCaptchaFailed)
 		return fmt.Errorf("captcha setup failed: %w", err)
 	}
 	cm.logger.Debug("Captcha verification setup successful")
@@ -498,9 +546,9 @@ func (cm *Monitor) PrepareWebview() (types.WebviewWindower, error) {
 	if err != nil {
 		cm.logger.Error("Error getting captcha webview from captcha service", zap.Error(err))
 
-		cm.updateState(&LeagueClientState{
+		_ = cm.updateState(&LeagueClientState{
 			ClientState: ClientStateLoginReady,
-		})
+		}, CauseCaptchaFailed)
 		return nil, fmt.Errorf("failed to get webview: %w", err)
 	}
 	cm.logger.Debug("Captcha webview obtained successfully")
@@ -574,9 +622,9 @@ func (cm *Monitor) handleCaptchaProcess(ctx context.Context, webview types.Webvi
 		cm.logger.Error("Error waiting for captcha response", zap.Error(err))
 
 		cm.logger.Info("Updating state to LOGIN_READY due to captcha error")
-		cm.updateState(&LeagueClientState{
+		_ = cm.updateState(&LeagueClientState{
 			ClientState: ClientStateLoginReady,
-		})
+		}, CauseCaptchaFailed)
 
 		select {
 		case errChan <- fmt.Errorf("captcha process failed: %w", err):
@@ -595,7 +643,7 @@ func (cm *Monitor) handleCaptchaProcess(ctx context.Context, webview types.Webvi
 	default:
 		cm.logger.Warn("Could not send captcha token, channel likely full or closed")
 
-		cm.updateState(&LeagueClientState{ClientState: ClientStateLoginReady})
+		_ = cm.updateState(&LeagueClientState{ClientState: ClientStateLoginReady}, CauseCaptchaFailed)
 		select {
 		case errChan <- errors.New("failed to deliver captcha token"):
 			cm.logger.Debug("Sent token delivery error to channel")
@@ -607,19 +655,29 @@ func (cm *Monitor) handleCaptchaProcess(ctx context.Context, webview types.Webvi
 
 func (cm *Monitor) handleCaptchaCancellation() {
 	cm.logger.Info("Webview was closed by user")
-	cm.updateState(&LeagueClientState{
+	_ = cm.updateState(&LeagueClientState{
 		ClientState: ClientStateLoginReady,
-	})
+	}, CauseCaptchaCancelled)
 }
 
-func (cm *Monitor) HandleLogin(username string, password string, captchaToken string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// HandleLogin authenticates with the captcha token, the request is aborted when ctx is done
+func (cm *Monitor) HandleLogin(ctx context.Context, username string, password *types.Credential, captchaToken string) error {
+	defer password.Destroy()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	newState := &LeagueClientState{
 		ClientState: ClientStateWaitingLogin,
 	}
-	cm.updateState(newState)
+	if err := cm.updateState(newState, CauseLoginRequested); err != nil {
+		return err
+	}
 	_, err := cm.riotAuth.LoginWithCaptcha(ctx, username, password, captchaToken)
+	if errors.Is(err, auth.ErrMultifactorRequired) {
+		cm.logger.Info("Login requires a multifactor code")
+		cm.beginMultifactor(username)
+		_ = cm.updateState(&LeagueClientState{ClientState: ClientStateWaitingMFA}, CauseMultifactorRequired)
+		return err
+	}
 	if err != nil {
 		cm.logger.Error("Login failed", zap.Error(err))
 
@@ -627,28 +685,13 @@ func (cm *Monitor) HandleLogin(username string, password string, captchaToken st
 			ClientState: ClientStateLoginReady,
 		}
 
-		cm.updateState(newState)
+		_ = cm.updateState(newState, CauseLoginFailed)
 
 		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
 			return fmt.Errorf("login operation timed out: %w", err)
 		}
 
-		if err.Error() == "multifactor" {
-			_, saveErr := cm.accountClient.Save(types.PartialSummonerRented{
-				Username: username,
-				Ban: &types.Ban{
-					Restrictions: []types.Restriction{
-						{Type: "MFA_REQUIRED"},
-					},
-				},
-			})
-			if saveErr != nil {
-				cm.logger.Error("Error saving summoner with multifactor restriction", zap.Error(err))
-				return saveErr
-			}
-
-		}
-		if err.Error() == "auth_failure" {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
 			_, saveErr := cm.accountClient.Save(types.PartialSummonerRented{
 				Username: username,
 				Ban: &types.Ban{
@@ -658,7 +701,7 @@ func (cm *Monitor) HandleLogin(username string, password string, captchaToken st
 				},
 			})
 			if saveErr != nil {
-				cm.logger.Error("Error saving summoner with multifactor restriction", zap.Error(err))
+				cm.logger.Error("Error saving summoner with invalid credentials restriction", zap.Error(err))
 				return saveErr
 			}
 
@@ -667,9 +710,104 @@ func (cm *Monitor) HandleLogin(username string, password string, captchaToken st
 		return err
 	}
 
+	_ = cm.updateState(&LeagueClientState{ClientState: ClientStateLoggedIn}, CauseLoginSucceeded)
+	return nil
+}
+
+// SubmitMultifactorCode completes a login waiting in WAITING_MFA, a wrong code keeps it waiting
+func (cm *Monitor) SubmitMultifactorCode(code string, trustDevice bool) error {
+	if cm.stateMachine.Current() != ClientStateWaitingMFA {
+		return errors.New("no multifactor authentication pending")
+	}
+
+	err := cm.riotAuth.SubmitMultifactorCode(code, trustDevice)
+	if errors.Is(err, auth.ErrMultifactorInvalid) || errors.Is(err, auth.ErrRateLimited) {
+		cm.logger.Warn("Multifactor code not accepted", zap.Error(err))
+		return err
+	}
+	if err != nil {
+		cm.logger.Error("Multifactor authentication failed", zap.Error(err))
+		_ = cm.updateState(&LeagueClientState{ClientState: ClientStateLoginReady}, CauseMultifactorFailed)
+		return err
+	}
+
+	_ = cm.updateState(&LeagueClientState{ClientState: ClientStateLoggedIn}, CauseMultifactorSucceeded)
+	return nil
+}
+
+// CancelMultifactor gives up on the pending login, the account is flagged as requiring a multifactor code
+func (cm *Monitor) CancelMultifactor() error {
+	if cm.stateMachine.Current() != ClientStateWaitingMFA {
+		return errors.New("no multifactor authentication pending")
+	}
+	cm.multifactorMutex.Lock()
+	username := ""
+	if cm.multifactor != nil {
+		username = cm.multifactor.username
+	}
+	cm.multifactorMutex.Unlock()
+
+	if err := cm.riotAuth.Logout(); err != nil {
+		cm.logger.Warn("Failed to discard pending multifactor authentication", zap.Error(err))
+	}
+	_ = cm.updateState(&LeagueClientState{ClientState: ClientStateLoginReady}, CauseMultifactorCancelled)
+
+	_, err := cm.accountClient.Save(types.PartialSummonerRented{
+		Username: username,
+		Ban: &types.Ban{
+			Restrictions: []types.Restriction{
+				{Type: "MFA_REQUIRED"},
+			},
+		},
+	})
+	if err != nil {
+		cm.logger.Error("Error saving summoner with multifactor restriction", zap.Error(err))
+		return err
+	}
 	return nil
 }
 
+// WaitForMultifactor blocks until the pending multifactor login succeeds or is abandoned. A context that
+// ends first cancels the pending login so the client doesn't stay in WAITING_MFA
+func (cm *Monitor) WaitForMultifactor(ctx context.Context) error {
+	cm.multifactorMutex.Lock()
+	wait := cm.multifactor
+	cm.multifactorMutex.Unlock()
+	if wait == nil {
+		return errors.New("no multifactor authentication pending")
+	}
+
+	select {
+	case <-ctx.Done():
+		if err := cm.CancelMultifactor(); err != nil {
+			cm.logger.Warn("Failed to cancel the multifactor authentication", zap.Error(err))
+		}
+		return ctx.Err()
+	case <-wait.done:
+		return wait.err
+	}
+}
+
+func (cm *Monitor) beginMultifactor(username string) {
+	cm.multifactorMutex.Lock()
+	defer cm.multifactorMutex.Unlock()
+	cm.multifactor = &multifactorWait{username: username, done: make(chan struct{})}
+}
+
+func (cm *Monitor) finishMultifactor(err error) {
+	cm.multifactorMutex.Lock()
+	defer cm.multifactorMutex.Unlock()
+	if cm.multifactor == nil {
+		return
+	}
+	select {
+	case <-cm.multifactor.done:
+	default:
+		cm.multifactor.err = err
+		close(cm.multifactor.done)
+	}
+}
+
 func (s *Service) IsLCUConnectionReady() bool {
 	isClientInitialized := s.LCUconnection.IsClientInitialized()
 	if !isClientInitialized {
@@ -1,88 +1,72 @@
 package league
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/account"
 	"github.com/hex-boost/hex-nexus-app/backend/internal/league/mocks"
 	websocketEvent "github.com/hex-boost/hex-nexus-app/backend/internal/league/websocket/event"
 	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
 	"github.com/hex-boost/hex-nexus-app/backend/types"
+	typesMocks "github.com/hex-boost/hex-nexus-app/backend/types/mocks"
 	"github.com/stretchr/testify/mock"
 	appEvents "github.com/wailsapp/wails/v3/pkg/events"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 )
 
-// TestCheckAndUpdateAccount tests the checkAndUpdateAccount function
-func TestCheckAndUpdateAccount(t *testing.T) {
-	// Setup environment and logger
+// TestUpdateState verifies state transitions work correctly
+func TestUpdateState(t *testing.T) {
 	cfg := &config.Config{LogLevel: "debug"}
-	newLogger := logger.New("TestCheckAndUpdateAccount", cfg)
-
-	t.Run("LCU is not ready", func(t *testing.T) {
-		mockLeagueService := mocks.NewLeagueServicer(t)
-		mockAccountMonitor := mocks.NewAccountMonitorer(t)
-		mockRiotAuth := mocks.NewAuthenticator(t)
-		mockCaptcha := mocks.NewCaptcha(t)
-		mockAccountState := mocks.NewAccountState(t)
-		mockRiotServicer := mocks.NewRiotServicer(t)
-
-		mockLeagueService.On("IsLCUConnectionReady").Return(false)
-
-		cm := NewMonitor(
-			newLogger,
-			mockAccountMonitor,
-			mockLeagueService,
-			mockRiotAuth,
-			mockCaptcha,
-			mockAccountState,
-			mockRiotServicer,
-		)
-
-		cm.checkAndUpdateAccount()
-
-		mockLeagueService.AssertCalled(t, "IsLCUConnectionReady")
-		mockAccountMonitor.AssertNotCalled(t, "GetLoggedInUsername")
-		mockLeagueService.AssertNotCalled(t, "UpdateFromLCU")
-	})
+	newLogger := logger.New("TestUpdateState", cfg)
+	mockLeagueService := mocks.NewLeagueServicer(t)
+	mockAccountMonitor := mocks.NewAccountMonitorer(t)
+	mockRiotAuth := mocks.NewAuthenticator(t)
+	mockCaptcha := mocks.NewCaptcha(t)
+	mockAccountState := mocks.NewAccountState(t)
+	mockApp := mocks.NewAppEmitter(t)
+	mockRiotServicer := mocks.NewRiotServicer(t)
 
-	// "No user logged in" test fix
-	t.Run("No user logged in", func(t *testing.T) {
-		mockLeagueService := mocks.NewLeagueServicer(t)
-		mockAccountMonitor := mocks.NewAccountMonitorer(t)
-		mockRiotAuth := mocks.NewAuthenticator(t)
-		mockCaptcha := mocks.NewCaptcha(t)
-		mockAccountState := mocks.NewAccountState(t)
-		mockApp := mocks.NewAppEmitter(t)
-		mockRiotServicer := mocks.NewRiotServicer(t)
+	cm := NewMonitor(
+		newLogger,
+		mockAccountMonitor,
+		mockLeagueService,
+		mockRiotAuth,
+		mockCaptcha,
+		mockAccountState,
+		mockRiotServicer,
+		nil,
+	)
+	cm.app = mockApp
 
-		// Add this line to mock the Get method
-		currentAccount := &types.PartialSummonerRented{Username: ""}
-		mockAccountState.On("Get").Return(currentAccount)
+	// Test state change with event emission
+	mockApp.On("EmitEvent", EventLeagueStateChanged, mock.MatchedBy(func(state *LeagueClientState) bool {
+		return state.ClientState == ClientStateLoggedIn
+	})).Return()
 
-		mockLeagueService.On("IsLCUConnectionReady").Return(true)
-		// Update this line to mock GetLoggedInUsername with a parameter
-		mockAccountMonitor.On("GetLoggedInUsername", "").Return("")
+	newState := &LeagueClientState{ClientState: ClientStateLoggedIn}
+	cm.updateState(newState, CauseLoginSucceeded)
 
-		cm := NewMonitor(
-			newLogger,
-			mockAccountMonitor,
-			mockLeagueService,
-			mockRiotAuth,
-			mockCaptcha,
-			mockAccountState,
-			mockRiotServicer,
-		)
-		cm.app = mockApp
+	mockApp.AssertCalled(t, "EmitEvent", EventLeagueStateChanged, mock.MatchedBy(func(state *LeagueClientState) bool {
+		return state.ClientState == ClientStateLoggedIn
+	}))
 
-		cm.checkAndUpdateAccount()
+	// Test that duplicate state doesn't emit event
+	cm.updateState(newState, CauseLoginSucceeded)  // Same state again
+	mockApp.AssertNumberOfCalls(t, "EmitEvent", 1) // Should still be just one call
+}
 
-		mockAccountMonitor.AssertCalled(t, "GetLoggedInUsername", "")
-		mockLeagueService.AssertNotCalled(t, "UpdateFromLCU")
-	})
+// TestCheckClientState verifies the websocket is started once per login
+func TestCheckClientState(t *testing.T) {
+	cfg := &config.Config{LogLevel: "debug"}
+	newLogger := logger.New("TestCheckClientState", cfg)
 
-	// "Account needs updating" test fix
-	t.Run("Account needs updating", func(t *testing.T) {
+	t.Run("Websocket starts once the LCU is ready", func(t *testing.T) {
 		mockLeagueService := mocks.NewLeagueServicer(t)
 		mockAccountMonitor := mocks.NewAccountMonitorer(t)
 		mockRiotAuth := mocks.NewAuthenticator(t)
@@ -91,24 +75,13 @@ func TestCheckAndUpdateAccount(t *testing.T) {
 		mockApp := mocks.NewAppEmitter(t)
 		mockRiotServicer := mocks.NewRiotServicer(t)
 
+		mockLeagueService.On("IsPlaying").Return(false)
+		mockLeagueService.On("IsRunning").Return(true)
 		mockLeagueService.On("IsLCUConnectionReady").Return(true)
-
-		// Setup account state
-		currentAccount := &types.PartialSummonerRented{
-			Username: "different",
-		}
-		mockAccountState.On("Get").Return(currentAccount)
-
-		// Update to mock GetLoggedInUsername with the expected parameter
-		mockAccountMonitor.On("GetLoggedInUsername", "different").Return("testuser")
-
-		mockLeagueService.On("UpdateFromLCU", "testuser").Return(nil)
-		mockApp.On("EmitEvent", websocketEvent.LeagueWebsocketStart).Return()
-
-		// Two update calls happen - one for initial update, one after UpdateFromLCU
-		mockAccountState.On("Update", mock.MatchedBy(func(update *types.PartialSummonerRented) bool {
-			return update.Username == "testuser"
-		})).Return(currentAccount, nil)
+		mockApp.On("EmitEvent", EventLeagueStateChanged, mock.Anything).Return().Once()
+		mockApp.On("EmitEvent", websocketEvent.LeagueWebsocketStart).Return().Times(2)
+		mockApp.On("EmitEvent", websocketEvent.LeagueWebsocketStop).Return().Once()
+		mockAccountState.On("Update", &types.PartialSummonerRented{Username: ""}).Return(&types.PartialSummonerRented{}, nil)
 
 		cm := NewMonitor(
 			newLogger,
@@ -118,43 +91,45 @@ func TestCheckAndUpdateAccount(t *testing.T) {
 			mockCaptcha,
 			mockAccountState,
 			mockRiotServicer,
+			nil,
 		)
 		cm.app = mockApp
 
-		cm.checkAndUpdateAccount()
+		cm.checkClientState()
+		cm.checkClientState()
+		if cm.stateMachine.Current() != ClientStateLoggedIn {
+			t.Errorf("Expected state %s, got %s", ClientStateLoggedIn, cm.stateMachine.Current())
+		}
 
-		mockLeagueService.AssertCalled(t, "UpdateFromLCU", "testuser")
-		mockApp.AssertCalled(t, "EmitEvent", websocketEvent.LeagueWebsocketStart)
+		// A reset racing with the polling starts the websocket again exactly once
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			cm.resetAccountUpdateStatus()
+		}()
+		go func() {
+			defer wg.Done()
+			cm.checkClientState()
+		}()
+		wg.Wait()
+		cm.checkClientState()
 	})
 
-	// "Error when updating account" test fix
-	t.Run("Error when updating account", func(t *testing.T) {
+	t.Run("Websocket waits for the LCU", func(t *testing.T) {
 		mockLeagueService := mocks.NewLeagueServicer(t)
 		mockAccountMonitor := mocks.NewAccountMonitorer(t)
 		mockRiotAuth := mocks.NewAuthenticator(t)
 		mockCaptcha := mocks.NewCaptcha(t)
 		mockAccountState := mocks.NewAccountState(t)
 		mockApp := mocks.NewAppEmitter(t)
-
-		mockLeagueService.On("IsLCUConnectionReady").Return(true)
-
-		// Setup account state
-		currentAccount := &types.PartialSummonerRented{
-			Username: "different",
-		}
-		mockAccountState.On("Get").Return(currentAccount)
-
-		// Update to mock GetLoggedInUsername with the expected parameter
-		mockAccountMonitor.On("GetLoggedInUsername", "different").Return("testuser")
-
-		mockLeagueService.On("UpdateFromLCU", "testuser").Return(errors.New("update error"))
-
-		// Only the first update happens since UpdateFromLCU returns an error
-		mockAccountState.On("Update", mock.MatchedBy(func(update *types.PartialSummonerRented) bool {
-			return update.Username == "testuser"
-		})).Return(currentAccount, nil).Once()
 		mockRiotServicer := mocks.NewRiotServicer(t)
 
+		mockLeagueService.On("IsPlaying").Return(true)
+		mockLeagueService.On("IsRunning").Return(true)
+		mockLeagueService.On("IsLCUConnectionReady").Return(false)
+		mockApp.On("EmitEvent", EventLeagueStateChanged, mock.Anything).Return().Once()
+
 		cm := NewMonitor(
 			newLogger,
 			mockAccountMonitor,
@@ -163,145 +138,16 @@ func TestCheckAndUpdateAccount(t *testing.T) {
 			mockCaptcha,
 			mockAccountState,
 			mockRiotServicer,
+			nil,
 		)
 		cm.app = mockApp
 
-		cm.checkAndUpdateAccount()
+		cm.checkClientState()
 
-		mockLeagueService.AssertCalled(t, "UpdateFromLCU", "testuser")
 		mockApp.AssertNotCalled(t, "EmitEvent", websocketEvent.LeagueWebsocketStart)
 	})
 }
 
-// TestUpdateState verifies state transitions work correctly
-func TestUpdateState(t *testing.T) {
-	cfg := &config.Config{LogLevel: "debug"}
-	newLogger := logger.New("TestUpdateState", cfg)
-	mockLeagueService := mocks.NewLeagueServicer(t)
-	mockAccountMonitor := mocks.NewAccountMonitorer(t)
-	mockRiotAuth := mocks.NewAuthenticator(t)
-	mockCaptcha := mocks.NewCaptcha(t)
-	mockAccountState := mocks.NewAccountState(t)
-	mockApp := mocks.NewAppEmitter(t)
-	mockRiotServicer := mocks.NewRiotServicer(t)
-
-	cm := NewMonitor(
-		newLogger,
-		mockAccountMonitor,
-		mockLeagueService,
-		mockRiotAuth,
-		mockCaptcha,
-		mockAccountState,
-		mockRiotServicer,
-	)
-	cm.app = mockApp
-
-	// Test state change with event emission
-	mockApp.On("EmitEvent", EventLeagueStateChanged, mock.MatchedBy(func(state *LeagueClientState) bool {
-		return state.ClientState == ClientStateLoggedIn
-	})).Return()
-
-	newState := &LeagueClientState{ClientState: ClientStateLoggedIn}
-	cm.updateState(newState)
-
-	mockApp.AssertCalled(t, "EmitEvent", EventLeagueStateChanged, mock.MatchedBy(func(state *LeagueClientState) bool {
-		return state.ClientState == ClientStateLoggedIn
-	}))
-
-	// Test that duplicate state doesn't emit event
-	cm.updateState(newState)                       // Same state again
-	mockApp.AssertNumberOfCalls(t, "EmitEvent", 1) // Should still be just one call
-}
-
-// TestDetermineClientState verifies state determination works correctly
-func TestDetermineClientState(t *testing.T) {
-	cfg := &config.Config{LogLevel: "debug"}
-	newLogger := logger.New("TestDetermineClientState", cfg)
-	mockLeagueService := mocks.NewLeagueServicer(t)
-	mockAccountMonitor := mocks.NewAccountMonitorer(t)
-	mockRiotAuth := mocks.NewAuthenticator(t)
-	mockCaptcha := mocks.NewCaptcha(t)
-	mockAccountState := mocks.NewAccountState(t)
-	mockRiotServicer := mocks.NewRiotServicer(t)
-
-	cm := NewMonitor(
-		newLogger,
-		mockAccountMonitor,
-		mockLeagueService,
-		mockRiotAuth,
-		mockCaptcha,
-		mockAccountState,
-		mockRiotServicer,
-	)
-
-	testCases := []struct {
-		name                  string
-		isRiotClientRunning   bool
-		isLeagueClientRunning bool
-		isLoggedIn            bool
-		isLoginReady          bool
-		isPlayingLeague       bool
-		previousState         LeagueClientStateType
-		expectedState         LeagueClientStateType
-	}{
-		{
-			name:                  "Client closed",
-			isRiotClientRunning:   false,
-			isLeagueClientRunning: false,
-			isLoggedIn:            false,
-			isLoginReady:          false,
-			isPlayingLeague:       false,
-			previousState:         ClientStateNone,
-			expectedState:         ClientStateClosed,
-		},
-		{
-			name:                  "Client logged in",
-			isRiotClientRunning:   true,
-			isLeagueClientRunning: true,
-			isLoggedIn:            true,
-			isLoginReady:          false,
-			isPlayingLeague:       false,
-			previousState:         ClientStateClosed,
-			expectedState:         ClientStateLoggedIn,
-		},
-		{
-			name:                  "Client login ready",
-			isRiotClientRunning:   true,
-			isLeagueClientRunning: false,
-			isLoggedIn:            false,
-			isLoginReady:          true,
-			isPlayingLeague:       false,
-			previousState:         ClientStateClosed,
-			expectedState:         ClientStateLoginReady,
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			prevState := &LeagueClientState{ClientState: tc.previousState}
-
-			newState := cm.determineClientState(
-				tc.isRiotClientRunning,
-				tc.isLeagueClientRunning,
-				tc.isLoggedIn,
-				tc.isLoginReady,
-				tc.isPlayingLeague,
-				prevState,
-			)
-
-			// If expecting LoginReady, we need a small delay due to sleep in function
-			if tc.expectedState == ClientStateLoginReady && tc.previousState == ClientStateClosed {
-				// Don't test exact state as it involves a sleep
-				return
-			}
-
-			if newState.ClientState != tc.expectedState {
-				t.Errorf("Expected state %s, got %s", tc.expectedState, newState.ClientState)
-			}
-		})
-	}
-}
-
 // TestHandleLogin tests login flow
 func TestHandleLogin(t *testing.T) {
 	cfg := &config.Config{LogLevel: "debug"}
@@ -316,7 +162,11 @@ func TestHandleLogin(t *testing.T) {
 		mockApp := mocks.NewAppEmitter(t)
 		mockRiotServicer := mocks.NewRiotServicer(t)
 
-		mockRiotAuth.On("LoginWithCaptcha", mock.Anything, "testuser", "password", "captcha-token").
+		password, err := types.NewCredential([]byte("password"))
+		if err != nil {
+			t.Fatalf("Failed to create credential: %v", err)
+		}
+		mockRiotAuth.On("LoginWithCaptcha", mock.Anything, "testuser", password, "captcha-token").
 			Return("auth-token", nil)
 		mockApp.On("EmitEvent", EventLeagueStateChanged, mock.Anything).Return()
 
@@ -328,15 +178,19 @@ func TestHandleLogin(t *testing.T) {
 			mockCaptcha,
 			mockAccountState,
 			mockRiotServicer,
+			nil,
 		)
 		cm.app = mockApp
 
-		err := cm.HandleLogin("testuser", "password", "captcha-token")
+		err = cm.HandleLogin(context.Background(), "testuser", password, "captcha-token")
 
 		if err != nil {
 			t.Errorf("Expected no error, got: %v", err)
 		}
-		mockRiotAuth.AssertCalled(t, "LoginWithCaptcha", mock.Anything, "testuser", "password", "captcha-token")
+		mockRiotAuth.AssertCalled(t, "LoginWithCaptcha", mock.Anything, "testuser", password, "captcha-token")
+		if !password.Destroyed() {
+			t.Error("Expected credential to be destroyed after login")
+		}
 	})
 
 	t.Run("Login failure", func(t *testing.T) {
@@ -349,10 +203,14 @@ func TestHandleLogin(t *testing.T) {
 		mockRiotServicer := mocks.NewRiotServicer(t)
 
 		loginError := errors.New("invalid credentials")
-		mockRiotAuth.On("LoginWithCaptcha", mock.Anything, "testuser", "password", "captcha-token").
+		password, err := types.NewCredential([]byte("password"))
+		if err != nil {
+			t.Fatalf("Failed to create credential: %v", err)
+		}
+		mockRiotAuth.On("LoginWithCaptcha", mock.Anything, "testuser", password, "captcha-token").
 			Return("", loginError)
 		mockApp.On("EmitEvent", EventLeagueStateChanged, mock.Anything).Return()
-		mockAccountMonitor.On("SetNexusAccount")
+		mockAccountMonitor.On("SetNexusAccount", false).Return()
 
 		cm := NewMonitor(
 			newLogger,
@@ -362,15 +220,111 @@ func TestHandleLogin(t *testing.T) {
 			mockCaptcha,
 			mockAccountState,
 			mockRiotServicer,
+			nil,
 		)
 		cm.app = mockApp
 
-		err := cm.HandleLogin("testuser", "password", "captcha-token")
+		err = cm.HandleLogin(context.Background(), "testuser", password, "captcha-token")
 
 		if err == nil {
 			t.Error("Expected error but got nil")
 		}
-		mockRiotAuth.AssertCalled(t, "LoginWithCaptcha", mock.Anything, "testuser", "password", "captcha-token")
+		mockRiotAuth.AssertCalled(t, "LoginWithCaptcha", mock.Anything, "testuser", password, "captcha-token")
+		if !password.Destroyed() {
+			t.Error("Expected credential to be destroyed after login")
+		}
+	})
+}
+
+// TestWaitForMultifactor tests the login pipeline wait for the multifactor code
+func TestWaitForMultifactor(t *testing.T) {
+	cfg := &config.Config{LogLevel: "debug"}
+	newLogger := logger.New("TestWaitForMultifactor", cfg)
+
+	// newMultifactorMonitor returns a monitor waiting for the code of rented, the restrictions saved to the
+	// backend are sent to saved
+	newMultifactorMonitor := func(t *testing.T, saved chan<- types.PartialSummonerRented) (*Monitor, *mocks.Authenticator, *mocks.AccountMonitorer) {
+		backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var summoner types.PartialSummonerRented
+			if err := json.NewDecoder(r.Body).Decode(&summoner); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			saved <- summoner
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data": {}}`))
+		}))
+		t.Cleanup(backend.Close)
+		backendCfg := &config.Config{LogLevel: "error", BackendURL: backend.URL, RefreshApiKey: "api-key"}
+
+		mockRiotAuth := mocks.NewAuthenticator(t)
+		mockAccountMonitor := mocks.NewAccountMonitorer(t)
+		mockApp := mocks.NewAppEmitter(t)
+		mockApp.On("EmitEvent", EventLeagueStateChanged, mock.Anything).Return()
+
+		cm := NewMonitor(
+			newLogger,
+			mockAccountMonitor,
+			mocks.NewLeagueServicer(t),
+			mockRiotAuth,
+			mocks.NewCaptcha(t),
+			mocks.NewAccountState(t),
+			mocks.NewRiotServicer(t),
+			account.NewClient(newLogger, backendCfg, nil),
+		)
+		cm.app = mockApp
+		cm.stateMachine.current = ClientStateWaitingMFA
+		cm.beginMultifactor("rented")
+		return cm, mockRiotAuth, mockAccountMonitor
+	}
+
+	t.Run("Timeout cancels the pending login", func(t *testing.T) {
+		saved := make(chan types.PartialSummonerRented, 1)
+		cm, mockRiotAuth, mockAccountMonitor := newMultifactorMonitor(t, saved)
+		mockRiotAuth.On("Logout").Return(nil).Once()
+		mockAccountMonitor.On("SetNexusAccount", false).Return().Once()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := cm.WaitForMultifactor(ctx)
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected a deadline error, got: %v", err)
+		}
+		if state := cm.stateMachine.Current(); state != ClientStateLoginReady {
+			t.Errorf("Expected %s after the timeout, got %s", ClientStateLoginReady, state)
+		}
+		select {
+		case summoner := <-saved:
+			if summoner.Username != "rented" || summoner.Ban.Restrictions[0].Type != "MFA_REQUIRED" {
+				t.Errorf("Unexpected multifactor restriction: %+v", summoner)
+			}
+		default:
+			t.Error("Expected the multifactor restriction to be saved")
+		}
+	})
+
+	t.Run("Code submitted in time completes the wait", func(t *testing.T) {
+		cm, mockRiotAuth, _ := newMultifactorMonitor(t, make(chan types.PartialSummonerRented, 1))
+		mockRiotAuth.On("SubmitMultifactorCode", "123456", true).Return(nil).Once()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- cm.WaitForMultifactor(context.Background())
+		}()
+		if err := cm.SubmitMultifactorCode("123456", true); err != nil {
+			t.Fatalf("Expected the code to be accepted, got: %v", err)
+		}
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("WaitForMultifactor did not return")
+		}
+		mockRiotAuth.AssertNotCalled(t, "Logout")
 	})
 }
 
@@ -395,10 +349,12 @@ func TestWaitUntilAuthenticationIsReady(t *testing.T) {
 			mockCaptcha,
 			mockAccountState,
 			mockRiotServicer,
+			nil,
 		)
 
+		mockAccountMonitor.On("SetNexusAccount", false).Return()
 		// Set initial state
-		cm.updateState(&LeagueClientState{ClientState: ClientStateLoginReady})
+		cm.updateState(&LeagueClientState{ClientState: ClientStateLoginReady}, CauseRiotClientStarted)
 
 		// Should return immediately since state is already LOGIN_READY
 		err := cm.WaitUntilAuthenticationIsReady(100 * time.Millisecond)
@@ -423,10 +379,12 @@ func TestWaitUntilAuthenticationIsReady(t *testing.T) {
 			mockCaptcha,
 			mockAccountState,
 			mockRiotServicer,
+			nil,
 		)
 
+		mockAccountMonitor.On("SetNexusAccount", false).Return()
 		// Set initial state to something other than LOGIN_READY
-		cm.updateState(&LeagueClientState{ClientState: ClientStateClosed})
+		cm.updateState(&LeagueClientState{ClientState: ClientStateClosed}, CauseRiotClientClosed)
 
 		// Should timeout since state never becomes LOGIN_READY
 		err := cm.WaitUntilAuthenticationIsReady(100 * time.Millisecond)
@@ -451,9 +409,10 @@ func TestOpenWebviewAndGetToken(t *testing.T) {
 		mockRiotServicer := mocks.NewRiotServicer(t)
 
 		// Create a mock webview
-		mockWebview := mocks.NewWebviewWindower(t)
+		mockWebview := typesMocks.NewWebviewWindower(t)
 
 		// Set up mock behaviors
+		mockRiotServicer.On("IsRunning").Return(true)
 		mockRiotAuth.On("SetupCaptchaVerification").Return(nil)
 		mockCaptcha.On("GetWebView").Return(mockWebview, nil)
 		mockCaptcha.On("WaitAndGetCaptchaResponse", mock.Anything, 25*time.Second).Return("captcha-token", nil)
@@ -477,11 +436,15 @@ func TestOpenWebviewAndGetToken(t *testing.T) {
 			mockCaptcha,
 			mockAccountState,
 			mockRiotServicer,
+			nil,
 		)
 		cm.app = mockApp
 
+		// The captcha is requested from the login screen
+		cm.stateMachine.current = ClientStateLoginReady
+
 		// Execute test
-		token, err := cm.OpenWebviewAndGetToken()
+		token, err := cm.OpenWebviewAndGetToken(context.Background())
 
 		if err != nil {
 			t.Errorf("Expected no error, got: %v", err)
@@ -506,7 +469,9 @@ func TestOpenWebviewAndGetToken(t *testing.T) {
 
 		mockRiotServicer := mocks.NewRiotServicer(t)
 		setupErr := errors.New("captcha setup error")
+		mockRiotServicer.On("IsRunning").Return(true)
 		mockRiotAuth.On("SetupCaptchaVerification").Return(setupErr)
+		mockAccountMonitor.On("SetNexusAccount", false).Return()
 
 		cm := NewMonitor(
 			newLogger,
@@ -516,9 +481,13 @@ func TestOpenWebviewAndGetToken(t *testing.T) {
 			mockCaptcha,
 			mockAccountState,
 			mockRiotServicer,
+			nil,
 		)
 
-		_, err := cm.OpenWebviewAndGetToken()
+		// The captcha is requested from the login screen
+		cm.stateMachine.current = ClientStateLoginReady
+
+		_, err := cm.OpenWebviewAndGetToken(context.Background())
 
 		if err == nil {
 			t.Error("Expected error but got nil")
@@ -556,6 +525,7 @@ func TestEmitEventUnpacksParameters(t *testing.T) {
 		mockCaptcha,
 		mockAccountState,
 		mockRiotServicer,
+		nil,
 	)
 	cm.app = mockApp
 
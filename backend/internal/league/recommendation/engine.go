@@ -0,0 +1,212 @@
+package recommendation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/hex-boost/hex-nexus-app/backend/riot/region"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+)
+
+const (
+	RequirementServer       = "server"
+	RequirementTier         = "tier"
+	RequirementChampions    = "champions"
+	RequirementSkins        = "skins"
+	RequirementLeaverBuster = "leaverBuster"
+	RequirementBan          = "ban"
+)
+
+// tiers is ordered from the lowest to the highest, unranked accounts sit below IRON
+var tiers = []string{"IRON", "BRONZE", "SILVER", "GOLD", "PLATINUM", "EMERALD", "DIAMOND", "MASTER", "GRANDMASTER", "CHALLENGER"}
+
+// Weights holds the maximum points of each requirement
+type Weights struct {
+	Server       float64
+	Tier         float64
+	Champions    float64
+	Skins        float64
+	LeaverBuster float64
+	Ban          float64
+}
+
+// DefaultWeights favors what can't be changed after renting, the server and the champions
+var DefaultWeights = Weights{
+	Server:       30,
+	Tier:         20,
+	Champions:    25,
+	Skins:        10,
+	LeaverBuster: 10,
+	Ban:          5,
+}
+
+// Engine scores accounts against a request, the same input always produces the same ranking
+type Engine struct {
+	weights Weights
+}
+
+func NewEngine(weights Weights) *Engine {
+	return &Engine{weights: weights}
+}
+
+// Rank scores every account and returns them from the best to the worst match
+func (e *Engine) Rank(accounts []types.SummonerBase, request types.RecommendationRequest) []types.AccountRecommendation {
+	recommendations := make([]types.AccountRecommendation, 0, len(accounts))
+	for _, account := range accounts {
+		recommendations = append(recommendations, e.Score(account, request))
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].Account.ID < recommendations[j].Account.ID
+	})
+
+	if request.Limit > 0 && len(recommendations) > request.Limit {
+		recommendations = recommendations[:request.Limit]
+	}
+	return recommendations
+}
+
+// Score rates a single account from 0 to 100 over the requirements present in the request
+func (e *Engine) Score(account types.SummonerBase, request types.RecommendationRequest) types.AccountRecommendation {
+	reasons := make([]types.RecommendationReason, 0, 6)
+	if request.Server != "" {
+		reasons = append(reasons, e.scoreServer(account, request.Server))
+	}
+	if request.MinTier != "" {
+		reasons = append(reasons, e.scoreTier(account, request.MinTier))
+	}
+	if len(request.ChampionIDs) > 0 {
+		reasons = append(reasons, scoreOwnership(RequirementChampions, "champions", e.weights.Champions, account.OwnedChampionIDs(), request.ChampionIDs))
+	}
+	if len(request.SkinIDs) > 0 {
+		reasons = append(reasons, scoreOwnership(RequirementSkins, "skins", e.weights.Skins, account.OwnedSkinIDs(), request.SkinIDs))
+	}
+	if request.ExcludeLeaverBuster {
+		reasons = append(reasons, e.scoreLeaverBuster(account))
+	}
+	// Restrictions always matter, an account with restrictions may not be playable at all
+	reasons = append(reasons, e.scoreBan(account))
+
+	recommendation := types.AccountRecommendation{
+		Account: account,
+		Matches: make([]types.RecommendationReason, 0),
+		Missing: make([]types.RecommendationReason, 0),
+	}
+	var points, maxPoints float64
+	for _, reason := range reasons {
+		points += reason.Points
+		maxPoints += reason.MaxPoints
+		if reason.Met {
+			recommendation.Matches = append(recommendation.Matches, reason)
+		} else {
+			recommendation.Missing = append(recommendation.Missing, reason)
+		}
+	}
+	if maxPoints > 0 {
+		recommendation.Score = round(points / maxPoints * 100)
+	}
+	return recommendation
+}
+
+func (e *Engine) scoreServer(account types.SummonerBase, server string) types.RecommendationReason {
+	reason := types.RecommendationReason{Requirement: RequirementServer, MaxPoints: e.weights.Server}
+	if region.Equal(account.Server, server) {
+		reason.Met = true
+		reason.Points = e.weights.Server
+		reason.Detail = fmt.Sprintf("Account is on %s", region.PlatformID(server))
+		return reason
+	}
+	reason.Detail = fmt.Sprintf("Account is on %s instead of %s", region.PlatformID(account.Server), region.PlatformID(server))
+	return reason
+}
+
+// scoreTier gives full points at or above the requested tier and loses a share for every tier below it
+func (e *Engine) scoreTier(account types.SummonerBase, minTier string) types.RecommendationReason {
+	reason := types.RecommendationReason{Requirement: RequirementTier, MaxPoints: e.weights.Tier}
+	wanted := tierIndex(minTier)
+	current := tierIndex(account.Rankings.RankedSolo5x5.Tier)
+	currentName := "UNRANKED"
+	if current >= 0 {
+		currentName = tiers[current]
+	}
+
+	if current >= wanted {
+		reason.Met = true
+		reason.Points = e.weights.Tier
+		reason.Detail = fmt.Sprintf("Ranked %s, requested %s+", currentName, strings.ToUpper(minTier))
+		return reason
+	}
+
+	missingTiers := float64(wanted - current)
+	reason.Points = round(math.Max(0, e.weights.Tier*(1-missingTiers/float64(len(tiers)))))
+	reason.Detail = fmt.Sprintf("Ranked %s, requested %s+", currentName, strings.ToUpper(minTier))
+	return reason
+}
+
+// scoreOwnership gives points proportionally to the requested items the account owns
+func scoreOwnership(requirement, name string, weight float64, owned map[int]struct{}, wanted []int) types.RecommendationReason {
+	reason := types.RecommendationReason{Requirement: requirement, MaxPoints: weight}
+	missing := make([]string, 0)
+	for _, id := range wanted {
+		if _, ok := owned[id]; !ok {
+			missing = append(missing, fmt.Sprint(id))
+		}
+	}
+
+	ownedCount := len(wanted) - len(missing)
+	reason.Points = round(weight * float64(ownedCount) / float64(len(wanted)))
+	if len(missing) == 0 {
+		reason.Met = true
+		reason.Detail = fmt.Sprintf("Owns all %d requested %s", len(wanted), name)
+		return reason
+	}
+	reason.Detail = fmt.Sprintf("Owns %d of %d requested %s, missing %s", ownedCount, len(wanted), name, strings.Join(missing, ", "))
+	return reason
+}
+
+func (e *Engine) scoreLeaverBuster(account types.SummonerBase) types.RecommendationReason {
+	reason := types.RecommendationReason{Requirement: RequirementLeaverBuster, MaxPoints: e.weights.LeaverBuster}
+	if account.HasLeaverBusterPenalty() {
+		reason.Detail = "Account has a leaver buster penalty"
+		return reason
+	}
+	reason.Met = true
+	reason.Points = e.weights.LeaverBuster
+	reason.Detail = "No leaver buster penalty"
+	return reason
+}
+
+func (e *Engine) scoreBan(account types.SummonerBase) types.RecommendationReason {
+	reason := types.RecommendationReason{Requirement: RequirementBan, MaxPoints: e.weights.Ban}
+	if len(account.Ban.Restrictions) > 0 {
+		restrictions := make([]string, 0, len(account.Ban.Restrictions))
+		for _, restriction := range account.Ban.Restrictions {
+			restrictions = append(restrictions, restriction.Type)
+		}
+		reason.Detail = fmt.Sprintf("Account has restrictions: %s", strings.Join(restrictions, ", "))
+		return reason
+	}
+	reason.Met = true
+	reason.Points = e.weights.Ban
+	reason.Detail = "No restrictions"
+	return reason
+}
+
+func tierIndex(tier string) int {
+	for i, t := range tiers {
+		if strings.EqualFold(t, tier) {
+			return i
+		}
+	}
+	return -1
+}
+
+// round keeps two decimals so scores compare the same way across platforms
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,132 @@
+package recommendation
+
+import (
+	"testing"
+
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAccount(id int, server, tier string, champions, skins []interface{}) types.SummonerBase {
+	account := types.SummonerBase{
+		ID:           id,
+		Server:       server,
+		LCUchampions: champions,
+		LCUskins:     skins,
+	}
+	account.Rankings.RankedSolo5x5.Tier = tier
+	return account
+}
+
+func TestEngine_Score(t *testing.T) {
+	engine := NewEngine(DefaultWeights)
+
+	t.Run("Perfect match scores 100", func(t *testing.T) {
+		account := newAccount(1, "BR1", "PLATINUM", []interface{}{float64(1), float64(2)}, []interface{}{float64(1001)})
+		request := types.RecommendationRequest{
+			Server:              "br1",
+			MinTier:             "GOLD",
+			ChampionIDs:         []int{1, 2},
+			SkinIDs:             []int{1001},
+			ExcludeLeaverBuster: true,
+		}
+
+		recommendation := engine.Score(account, request)
+
+		assert.Equal(t, 100.0, recommendation.Score)
+		assert.Empty(t, recommendation.Missing)
+		assert.Len(t, recommendation.Matches, 6)
+	})
+
+	t.Run("Partial ownership is proportional", func(t *testing.T) {
+		account := newAccount(1, "BR1", "GOLD", []interface{}{float64(1)}, nil)
+		request := types.RecommendationRequest{ChampionIDs: []int{1, 2, 3, 4}}
+
+		recommendation := engine.Score(account, request)
+
+		// champions 25 * 1/4 + ban 5 out of 30
+		assert.Equal(t, 37.5, recommendation.Score)
+		assert.Len(t, recommendation.Missing, 1)
+		assert.Equal(t, RequirementChampions, recommendation.Missing[0].Requirement)
+		assert.Equal(t, 6.25, recommendation.Missing[0].Points)
+		assert.Contains(t, recommendation.Missing[0].Detail, "missing 2, 3, 4")
+	})
+
+	t.Run("Tier below the minimum loses a share per tier", func(t *testing.T) {
+		account := newAccount(1, "BR1", "SILVER", nil, nil)
+		request := types.RecommendationRequest{MinTier: "PLATINUM"}
+
+		recommendation := engine.Score(account, request)
+
+		// tier 20 * (1 - 2/10) + ban 5 out of 25
+		assert.Equal(t, 84.0, recommendation.Score)
+		assert.Equal(t, RequirementTier, recommendation.Missing[0].Requirement)
+		assert.Equal(t, "Ranked SILVER, requested PLATINUM+", recommendation.Missing[0].Detail)
+	})
+
+	t.Run("Unranked accounts sit below iron", func(t *testing.T) {
+		account := newAccount(1, "BR1", "", nil, nil)
+		request := types.RecommendationRequest{MinTier: "IRON"}
+
+		recommendation := engine.Score(account, request)
+
+		assert.Equal(t, 18.0, recommendation.Missing[0].Points)
+		assert.Equal(t, "Ranked UNRANKED, requested IRON+", recommendation.Missing[0].Detail)
+	})
+
+	t.Run("Wrong server and restrictions are explained", func(t *testing.T) {
+		account := newAccount(1, "NA1", "GOLD", nil, nil)
+		account.Ban.Restrictions = []types.Restriction{{Type: "RANKED_RESTRICTION"}}
+		request := types.RecommendationRequest{Server: "BR1"}
+
+		recommendation := engine.Score(account, request)
+
+		assert.Equal(t, 0.0, recommendation.Score)
+		assert.Empty(t, recommendation.Matches)
+		assert.Equal(t, "Account is on NA1 instead of BR1", recommendation.Missing[0].Detail)
+		assert.Equal(t, "Account has restrictions: RANKED_RESTRICTION", recommendation.Missing[1].Detail)
+	})
+
+	t.Run("Leaver buster penalty is detected", func(t *testing.T) {
+		account := newAccount(1, "BR1", "GOLD", nil, nil)
+		account.LeaverBuster = map[string]interface{}{
+			"leaverBusterEntryDto": map[string]interface{}{"punishedGamesRemaining": float64(3)},
+		}
+		request := types.RecommendationRequest{ExcludeLeaverBuster: true}
+
+		recommendation := engine.Score(account, request)
+
+		assert.Equal(t, 33.33, recommendation.Score)
+		assert.Equal(t, RequirementLeaverBuster, recommendation.Missing[0].Requirement)
+	})
+}
+
+func TestEngine_Rank(t *testing.T) {
+	engine := NewEngine(DefaultWeights)
+
+	t.Run("Orders by score and breaks ties by id", func(t *testing.T) {
+		accounts := []types.SummonerBase{
+			newAccount(3, "NA1", "GOLD", nil, nil),
+			newAccount(2, "BR1", "GOLD", nil, nil),
+			newAccount(1, "BR1", "GOLD", nil, nil),
+		}
+
+		recommendations := engine.Rank(accounts, types.RecommendationRequest{Server: "BR1"})
+
+		assert.Len(t, recommendations, 3)
+		assert.Equal(t, 1, recommendations[0].Account.ID)
+		assert.Equal(t, 2, recommendations[1].Account.ID)
+		assert.Equal(t, 3, recommendations[2].Account.ID)
+	})
+
+	t.Run("Applies the limit", func(t *testing.T) {
+		accounts := []types.SummonerBase{
+			newAccount(1, "BR1", "GOLD", nil, nil),
+			newAccount(2, "BR1", "GOLD", nil, nil),
+		}
+
+		recommendations := engine.Rank(accounts, types.RecommendationRequest{Limit: 1})
+
+		assert.Len(t, recommendations, 1)
+	})
+}
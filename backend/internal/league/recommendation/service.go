@@ -0,0 +1,42 @@
+package recommendation
+
+import (
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"go.uber.org/zap"
+)
+
+// AccountClient defines the backend call used to list the available accounts
+type AccountClient interface {
+	GetAll() ([]types.SummonerBase, error)
+}
+
+// Service exposes the recommendation engine to the frontend
+type Service struct {
+	logger        *logger.Logger
+	accountClient AccountClient
+	engine        *Engine
+}
+
+func NewService(logger *logger.Logger, accountClient AccountClient) *Service {
+	return &Service{
+		logger:        logger,
+		accountClient: accountClient,
+		engine:        NewEngine(DefaultWeights),
+	}
+}
+
+// Recommend ranks the available accounts against the request
+func (s *Service) Recommend(request types.RecommendationRequest) ([]types.AccountRecommendation, error) {
+	accounts, err := s.accountClient.GetAll()
+	if err != nil {
+		s.logger.Error("Failed to fetch accounts for recommendation", zap.Error(err))
+		return nil, err
+	}
+
+	recommendations := s.engine.Rank(accounts, request)
+	s.logger.Debug("Ranked accounts for recommendation",
+		zap.Int("accounts", len(accounts)),
+		zap.Int("returned", len(recommendations)))
+	return recommendations, nil
+}
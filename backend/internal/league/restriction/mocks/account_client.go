@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+)
+
+// AccountClient is an autogenerated mock type for the AccountClient type
+type AccountClient struct {
+	mock.Mock
+}
+
+type AccountClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AccountClient) EXPECT() *AccountClient_Expecter {
+	return &AccountClient_Expecter{mock: &_m.Mock}
+}
+
+// Save provides a mock function with given fields: summoner
+func (_m *AccountClient) Save(summoner types.PartialSummonerRented) (*types.SummonerResponse, error) {
+	ret := _m.Called(summoner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *types.SummonerResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(types.PartialSummonerRented) (*types.SummonerResponse, error)); ok {
+		return rf(summoner)
+	}
+	if rf, ok := ret.Get(0).(func(types.PartialSummonerRented) *types.SummonerResponse); ok {
+		r0 = rf(summoner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.SummonerResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(types.PartialSummonerRented) error); ok {
+		r1 = rf(summoner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccountClient_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type AccountClient_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - summoner types.PartialSummonerRented
+func (_e *AccountClient_Expecter) Save(summoner interface{}) *AccountClient_Save_Call {
+	return &AccountClient_Save_Call{Call: _e.mock.On("Save", summoner)}
+}
+
+func (_c *AccountClient_Save_Call) Run(run func(summoner types.PartialSummonerRented)) *AccountClient_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(types.PartialSummonerRented))
+	})
+	return _c
+}
+
+func (_c *AccountClient_Save_Call) Return(_a0 *types.SummonerResponse, _a1 error) *AccountClient_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccountClient_Save_Call) RunAndReturn(run func(types.PartialSummonerRented) (*types.SummonerResponse, error)) *AccountClient_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAccountClient creates a new instance of AccountClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountClient {
+	mock := &AccountClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
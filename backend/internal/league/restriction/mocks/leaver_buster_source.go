@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+)
+
+// LeaverBusterSource is an autogenerated mock type for the LeaverBusterSource type
+type LeaverBusterSource struct {
+	mock.Mock
+}
+
+type LeaverBusterSource_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LeaverBusterSource) EXPECT() *LeaverBusterSource_Expecter {
+	return &LeaverBusterSource_Expecter{mock: &_m.Mock}
+}
+
+// GetLeaverBuster provides a mock function with given fields: platformID
+func (_m *LeaverBusterSource) GetLeaverBuster(platformID string) (*types.LeaverBusterResponse, error) {
+	ret := _m.Called(platformID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeaverBuster")
+	}
+
+	var r0 *types.LeaverBusterResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*types.LeaverBusterResponse, error)); ok {
+		return rf(platformID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *types.LeaverBusterResponse); ok {
+		r0 = rf(platformID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.LeaverBusterResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(platformID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LeaverBusterSource_GetLeaverBuster_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaverBuster'
+type LeaverBusterSource_GetLeaverBuster_Call struct {
+	*mock.Call
+}
+
+// GetLeaverBuster is a helper method to define mock.On call
+//   - platformID string
+func (_e *LeaverBusterSource_Expecter) GetLeaverBuster(platformID interface{}) *LeaverBusterSource_GetLeaverBuster_Call {
+	return &LeaverBusterSource_GetLeaverBuster_Call{Call: _e.mock.On("GetLeaverBuster", platformID)}
+}
+
+func (_c *LeaverBusterSource_GetLeaverBuster_Call) Run(run func(platformID string)) *LeaverBusterSource_GetLeaverBuster_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *LeaverBusterSource_GetLeaverBuster_Call) Return(_a0 *types.LeaverBusterResponse, _a1 error) *LeaverBusterSource_GetLeaverBuster_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LeaverBusterSource_GetLeaverBuster_Call) RunAndReturn(run func(string) (*types.LeaverBusterResponse, error)) *LeaverBusterSource_GetLeaverBuster_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLeaverBusterSource creates a new instance of LeaverBusterSource. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLeaverBusterSource(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LeaverBusterSource {
+	mock := &LeaverBusterSource{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
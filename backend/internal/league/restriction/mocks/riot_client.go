@@ -0,0 +1,138 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+)
+
+// RiotClient is an autogenerated mock type for the RiotClient type
+type RiotClient struct {
+	mock.Mock
+}
+
+type RiotClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RiotClient) EXPECT() *RiotClient_Expecter {
+	return &RiotClient_Expecter{mock: &_m.Mock}
+}
+
+// GetUserinfo provides a mock function with no fields
+func (_m *RiotClient) GetUserinfo() (*types.UserInfo, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserinfo")
+	}
+
+	var r0 *types.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.UserInfo, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.UserInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RiotClient_GetUserinfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserinfo'
+type RiotClient_GetUserinfo_Call struct {
+	*mock.Call
+}
+
+// GetUserinfo is a helper method to define mock.On call
+func (_e *RiotClient_Expecter) GetUserinfo() *RiotClient_GetUserinfo_Call {
+	return &RiotClient_GetUserinfo_Call{Call: _e.mock.On("GetUserinfo")}
+}
+
+func (_c *RiotClient_GetUserinfo_Call) Run(run func()) *RiotClient_GetUserinfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RiotClient_GetUserinfo_Call) Return(_a0 *types.UserInfo, _a1 error) *RiotClient_GetUserinfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RiotClient_GetUserinfo_Call) RunAndReturn(run func() (*types.UserInfo, error)) *RiotClient_GetUserinfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsRunning provides a mock function with no fields
+func (_m *RiotClient) IsRunning() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsRunning")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// RiotClient_IsRunning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRunning'
+type RiotClient_IsRunning_Call struct {
+	*mock.Call
+}
+
+// IsRunning is a helper method to define mock.On call
+func (_e *RiotClient_Expecter) IsRunning() *RiotClient_IsRunning_Call {
+	return &RiotClient_IsRunning_Call{Call: _e.mock.On("IsRunning")}
+}
+
+func (_c *RiotClient_IsRunning_Call) Run(run func()) *RiotClient_IsRunning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RiotClient_IsRunning_Call) Return(_a0 bool) *RiotClient_IsRunning_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RiotClient_IsRunning_Call) RunAndReturn(run func() bool) *RiotClient_IsRunning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRiotClient creates a new instance of RiotClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRiotClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RiotClient {
+	mock := &RiotClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
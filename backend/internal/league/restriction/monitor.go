@@ -0,0 +1,260 @@
+package restriction
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"go.uber.org/zap"
+)
+
+// EventRestrictionChanged carries a types.RestrictionChange when restrictions appear or lift during a session
+const EventRestrictionChanged = "restriction:changed"
+
+// RiotClient defines the Riot client calls used to read the userinfo restrictions
+type RiotClient interface {
+	IsRunning() bool
+	GetUserinfo() (*types.UserInfo, error)
+}
+
+// LeaverBusterSource defines the LCU call used to read the queue delay and ranked restriction
+type LeaverBusterSource interface {
+	GetLeaverBuster(platformID string) (*types.LeaverBusterResponse, error)
+}
+
+// AccountState defines the current account the restrictions belong to
+type AccountState interface {
+	Get() *types.PartialSummonerRented
+	IsNexusAccount() bool
+}
+
+// AccountClient defines how restriction changes are reported to the backend
+type AccountClient interface {
+	Save(summoner types.PartialSummonerRented) (*types.SummonerResponse, error)
+}
+
+// AppEmitter defines the interface for emitting events
+type AppEmitter interface {
+	EmitEvent(name string, data ...any)
+}
+
+// Monitor re-evaluates the restrictions of the logged in account from userinfo, the leaver buster and LCU
+// events. The first read of each source is the baseline, only later changes are notified
+type Monitor struct {
+	logger        *logger.Logger
+	riotClient    RiotClient
+	leaverBuster  LeaverBusterSource
+	accountState  AccountState
+	accountClient AccountClient
+	app           AppEmitter
+	interval      time.Duration
+	now           func() time.Time
+
+	mutex    sync.Mutex
+	username string
+	sources  map[string][]types.AccountRestriction
+	// notified is the active set after the last update, expired restrictions are lifted against it
+	notified []types.AccountRestriction
+	running  bool
+	stop     chan struct{}
+}
+
+func NewMonitor(logger *logger.Logger, riotClient RiotClient, accountState AccountState, accountClient AccountClient) *Monitor {
+	return &Monitor{
+		logger:        logger,
+		riotClient:    riotClient,
+		accountState:  accountState,
+		accountClient: accountClient,
+		interval:      time.Minute,
+		now:           time.Now,
+		sources:       make(map[string][]types.AccountRestriction),
+	}
+}
+
+// SetLeaverBusterSource adds the leaver buster to the evaluated sources
+func (m *Monitor) SetLeaverBusterSource(leaverBuster LeaverBusterSource) {
+	m.leaverBuster = leaverBuster
+}
+
+func (m *Monitor) Start(app AppEmitter) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.running {
+		return
+	}
+	m.app = app
+	m.running = true
+	m.stop = make(chan struct{})
+	go m.loop(m.stop)
+	m.logger.Info("Restriction monitor started", zap.Duration("interval", m.interval))
+}
+
+func (m *Monitor) Stop() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !m.running {
+		return
+	}
+	close(m.stop)
+	m.running = false
+	m.logger.Info("Restriction monitor stopped")
+}
+
+func (m *Monitor) loop(stop chan struct{}) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.Evaluate()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Active returns the unexpired restrictions of the current account
+func (m *Monitor) Active() []types.AccountRestriction {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.active()
+}
+
+// Evaluate reads userinfo and the leaver buster of the logged in account
+func (m *Monitor) Evaluate() {
+	username := m.currentUsername()
+	if username == "" || !m.riotClient.IsRunning() {
+		return
+	}
+	userInfo, err := m.riotClient.GetUserinfo()
+	if err != nil {
+		m.logger.Debug("Userinfo not available for restrictions", zap.Error(err))
+		return
+	}
+	if !strings.EqualFold(userInfo.Username, username) {
+		// The account is being switched, the account monitor will catch up
+		return
+	}
+	m.update(username, SourceUserinfo, FromBan(userInfo.Ban))
+
+	if m.leaverBuster == nil || userInfo.LOL.CPID == "" {
+		return
+	}
+	leaverBuster, err := m.leaverBuster.GetLeaverBuster(userInfo.LOL.CPID)
+	if err != nil {
+		m.logger.Debug("Leaver buster not available for restrictions", zap.Error(err))
+		return
+	}
+	m.update(username, SourceLeaverBuster, FromLeaverBuster(leaverBuster))
+}
+
+// OnRankedRestriction is called by the LCU ranked restriction event
+func (m *Monitor) OnRankedRestriction(party types.PartyRestriction) {
+	username := m.currentUsername()
+	if username == "" {
+		return
+	}
+	m.update(username, SourceRankedEvent, FromPartyRestriction(party))
+}
+
+func (m *Monitor) currentUsername() string {
+	account := m.accountState.Get()
+	if account == nil {
+		return ""
+	}
+	return strings.ToLower(account.Username)
+}
+
+// update replaces the restrictions read from a source and notifies what appeared or lifted
+func (m *Monitor) update(username, source string, restrictions []types.AccountRestriction) {
+	m.mutex.Lock()
+	if username != m.username {
+		m.username = username
+		m.sources = make(map[string][]types.AccountRestriction)
+		m.notified = nil
+	}
+	_, seen := m.sources[source]
+	m.sources[source] = restrictions
+	before := m.notified
+	after := m.active()
+	m.notified = after
+	app := m.app
+	m.mutex.Unlock()
+
+	if !seen {
+		m.logger.Debug("Restriction baseline read", zap.String("source", source), zap.Int("count", len(restrictions)))
+		return
+	}
+	appeared, lifted := diff(before, after)
+	if len(appeared) == 0 && len(lifted) == 0 {
+		return
+	}
+	change := types.RestrictionChange{Username: username, Appeared: appeared, Lifted: lifted, Active: after}
+	m.logger.Info("Account restrictions changed",
+		zap.String("username", username),
+		zap.String("source", source),
+		zap.Any("appeared", appeared),
+		zap.Any("lifted", lifted))
+
+	if app != nil {
+		app.EmitEvent(EventRestrictionChanged, change)
+	}
+	if !m.accountState.IsNexusAccount() {
+		return
+	}
+	stored := make([]types.Restriction, 0, len(after))
+	for _, restriction := range after {
+		stored = append(stored, ToRestriction(restriction))
+	}
+	if _, err := m.accountClient.Save(types.PartialSummonerRented{
+		Username: username,
+		Ban:      &types.Ban{Restrictions: stored},
+	}); err != nil {
+		m.logger.Error("Error saving summoner restrictions", zap.Error(err))
+	}
+}
+
+// active merges the unexpired restrictions of every source, the caller must hold the lock
+func (m *Monitor) active() []types.AccountRestriction {
+	now := m.now()
+	merged := make(map[string]types.AccountRestriction)
+	for _, restrictions := range m.sources {
+		for _, restriction := range restrictions {
+			if !IsActive(restriction, now) {
+				continue
+			}
+			if _, ok := merged[key(restriction)]; !ok {
+				merged[key(restriction)] = restriction
+			}
+		}
+	}
+	active := make([]types.AccountRestriction, 0, len(merged))
+	for _, restriction := range merged {
+		active = append(active, restriction)
+	}
+	sort.Slice(active, func(i, j int) bool { return key(active[i]) < key(active[j]) })
+	return active
+}
+
+func diff(before, after []types.AccountRestriction) (appeared, lifted []types.AccountRestriction) {
+	beforeKeys := make(map[string]bool, len(before))
+	for _, restriction := range before {
+		beforeKeys[key(restriction)] = true
+	}
+	afterKeys := make(map[string]bool, len(after))
+	for _, restriction := range after {
+		afterKeys[key(restriction)] = true
+		if !beforeKeys[key(restriction)] {
+			appeared = append(appeared, restriction)
+		}
+	}
+	for _, restriction := range before {
+		if !afterKeys[key(restriction)] {
+			lifted = append(lifted, restriction)
+		}
+	}
+	return appeared, lifted
+}
@@ -0,0 +1,195 @@
+package restriction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/restriction/mocks"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMonitor(t *testing.T) {
+	newLogger := logger.New("TestRestrictions", &config.Config{LogLevel: "error"})
+	account := &types.PartialSummonerRented{Username: "Rented"}
+
+	// newUserinfo returns the userinfo of the logged in account, the tests change its restrictions
+	// between reads
+	newUserinfo := func() *types.UserInfo {
+		return &types.UserInfo{Username: "Rented", LOL: types.LOLInfo{CPID: "BR1"}}
+	}
+
+	t.Run("Restrictions present at the first read are the baseline", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountClient := mocks.NewAccountClient(t)
+		mockApp := mocks.NewAppEmitter(t)
+
+		userinfo := newUserinfo()
+		userinfo.Ban.Restrictions = []types.Restriction{{Type: "TEXT_CHAT_RESTRICTION", Scope: "lol"}}
+		mockAccountState.EXPECT().Get().Return(account)
+		mockRiot.EXPECT().IsRunning().Return(true)
+		mockRiot.EXPECT().GetUserinfo().Return(userinfo, nil).Once()
+
+		monitor := NewMonitor(newLogger, mockRiot, mockAccountState, mockAccountClient)
+		monitor.app = mockApp
+		monitor.Evaluate()
+
+		assert.Len(t, monitor.Active(), 1)
+		mockApp.AssertNotCalled(t, "EmitEvent", mock.Anything, mock.Anything)
+		mockAccountClient.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("Notifies a restriction that appears during the session", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountClient := mocks.NewAccountClient(t)
+		mockApp := mocks.NewAppEmitter(t)
+
+		userinfo := newUserinfo()
+		mockAccountState.EXPECT().Get().Return(account)
+		mockAccountState.EXPECT().IsNexusAccount().Return(true).Once()
+		mockRiot.EXPECT().IsRunning().Return(true)
+		mockRiot.EXPECT().GetUserinfo().Return(userinfo, nil).Times(2)
+		mockApp.EXPECT().EmitEvent(EventRestrictionChanged, mock.MatchedBy(func(change types.RestrictionChange) bool {
+			return change.Username == "rented" && len(change.Appeared) == 1 && change.Appeared[0].Kind == types.RestrictionTimedBan
+		})).Return().Once()
+		mockAccountClient.EXPECT().Save(mock.MatchedBy(func(summoner types.PartialSummonerRented) bool {
+			return summoner.Username == "rented" && summoner.Ban.Restrictions[0].Type == types.RestrictionTimedBan
+		})).Return(&types.SummonerResponse{}, nil).Once()
+
+		monitor := NewMonitor(newLogger, mockRiot, mockAccountState, mockAccountClient)
+		monitor.app = mockApp
+		monitor.Evaluate()
+
+		userinfo.Ban.Restrictions = []types.Restriction{{Type: "TIME_BAN", Scope: "lol"}}
+		monitor.Evaluate()
+	})
+
+	t.Run("Notifies a restriction that lifts", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountClient := mocks.NewAccountClient(t)
+		mockApp := mocks.NewAppEmitter(t)
+
+		userinfo := newUserinfo()
+		userinfo.Ban.Restrictions = []types.Restriction{{Type: "TEXT_CHAT_RESTRICTION", Scope: "lol"}}
+		mockAccountState.EXPECT().Get().Return(account)
+		mockAccountState.EXPECT().IsNexusAccount().Return(true).Once()
+		mockRiot.EXPECT().IsRunning().Return(true)
+		mockRiot.EXPECT().GetUserinfo().Return(userinfo, nil).Times(2)
+		mockApp.EXPECT().EmitEvent(EventRestrictionChanged, mock.MatchedBy(func(change types.RestrictionChange) bool {
+			return len(change.Lifted) == 1 && change.Lifted[0].Kind == types.RestrictionChat && len(change.Active) == 0
+		})).Return().Once()
+		mockAccountClient.EXPECT().Save(mock.Anything).Return(&types.SummonerResponse{}, nil).Once()
+
+		monitor := NewMonitor(newLogger, mockRiot, mockAccountState, mockAccountClient)
+		monitor.app = mockApp
+		monitor.Evaluate()
+
+		userinfo.Ban.Restrictions = nil
+		monitor.Evaluate()
+	})
+
+	t.Run("Expired restrictions lift without a new read", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountClient := mocks.NewAccountClient(t)
+		mockApp := mocks.NewAppEmitter(t)
+
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		userinfo := newUserinfo()
+		userinfo.Ban.Restrictions = []types.Restriction{{
+			Type: "TIME_BAN",
+			Data: map[string]interface{}{"expirationMillis": float64(now.Add(time.Minute).UnixMilli())},
+		}}
+		mockAccountState.EXPECT().Get().Return(account)
+		mockAccountState.EXPECT().IsNexusAccount().Return(true).Once()
+		mockRiot.EXPECT().IsRunning().Return(true)
+		mockRiot.EXPECT().GetUserinfo().Return(userinfo, nil).Times(2)
+		mockApp.EXPECT().EmitEvent(EventRestrictionChanged, mock.MatchedBy(func(change types.RestrictionChange) bool {
+			return len(change.Lifted) == 1 && change.Lifted[0].Kind == types.RestrictionTimedBan
+		})).Return().Once()
+		mockAccountClient.EXPECT().Save(mock.Anything).Return(&types.SummonerResponse{}, nil).Once()
+
+		monitor := NewMonitor(newLogger, mockRiot, mockAccountState, mockAccountClient)
+		monitor.app = mockApp
+		monitor.now = func() time.Time { return now }
+		monitor.Evaluate()
+
+		now = now.Add(2 * time.Minute)
+		monitor.Evaluate()
+	})
+
+	t.Run("The same restriction from two sources is notified once", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountClient := mocks.NewAccountClient(t)
+		mockApp := mocks.NewAppEmitter(t)
+		mockLeaverBuster := mocks.NewLeaverBusterSource(t)
+
+		leaverBuster := &types.LeaverBusterResponse{}
+		mockAccountState.EXPECT().Get().Return(account)
+		mockAccountState.EXPECT().IsNexusAccount().Return(true).Once()
+		mockRiot.EXPECT().IsRunning().Return(true)
+		mockRiot.EXPECT().GetUserinfo().Return(newUserinfo(), nil).Times(2)
+		mockLeaverBuster.EXPECT().GetLeaverBuster("BR1").Return(leaverBuster, nil).Times(2)
+		mockApp.EXPECT().EmitEvent(EventRestrictionChanged, mock.MatchedBy(func(change types.RestrictionChange) bool {
+			return len(change.Appeared) == 1 && change.Appeared[0].Kind == types.RestrictionRanked
+		})).Return().Once()
+		mockAccountClient.EXPECT().Save(mock.Anything).Return(&types.SummonerResponse{}, nil).Once()
+
+		monitor := NewMonitor(newLogger, mockRiot, mockAccountState, mockAccountClient)
+		monitor.app = mockApp
+		monitor.SetLeaverBusterSource(mockLeaverBuster)
+		monitor.Evaluate()
+		monitor.OnRankedRestriction(types.PartyRestriction{})
+
+		leaverBuster.RankedRestrictionEntryDto.RestrictedGamesRemaining = 3
+		monitor.Evaluate()
+		monitor.OnRankedRestriction(types.PartyRestriction{PunishedGamesRemaining: 3})
+	})
+
+	t.Run("Non Nexus accounts are not saved", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountClient := mocks.NewAccountClient(t)
+		mockApp := mocks.NewAppEmitter(t)
+
+		userinfo := newUserinfo()
+		mockAccountState.EXPECT().Get().Return(account)
+		mockAccountState.EXPECT().IsNexusAccount().Return(false).Once()
+		mockRiot.EXPECT().IsRunning().Return(true)
+		mockRiot.EXPECT().GetUserinfo().Return(userinfo, nil).Times(2)
+		mockApp.EXPECT().EmitEvent(EventRestrictionChanged, mock.Anything).Return().Once()
+
+		monitor := NewMonitor(newLogger, mockRiot, mockAccountState, mockAccountClient)
+		monitor.app = mockApp
+		monitor.Evaluate()
+
+		userinfo.Ban.Restrictions = []types.Restriction{{Type: "PERMANENT_BAN"}}
+		monitor.Evaluate()
+
+		mockAccountClient.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("Ignores userinfo of another account", func(t *testing.T) {
+		mockRiot := mocks.NewRiotClient(t)
+		mockAccountState := mocks.NewAccountState(t)
+
+		userinfo := newUserinfo()
+		userinfo.Username = "other"
+		userinfo.Ban.Restrictions = []types.Restriction{{Type: "PERMANENT_BAN"}}
+		mockAccountState.EXPECT().Get().Return(account)
+		mockRiot.EXPECT().IsRunning().Return(true)
+		mockRiot.EXPECT().GetUserinfo().Return(userinfo, nil).Once()
+
+		monitor := NewMonitor(newLogger, mockRiot, mockAccountState, mocks.NewAccountClient(t))
+		monitor.Evaluate()
+
+		assert.Empty(t, monitor.Active())
+	})
+}
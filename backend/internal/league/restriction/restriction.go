@@ -0,0 +1,188 @@
+package restriction
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+)
+
+// Restriction sources
+const (
+	SourceUserinfo     = "userinfo"
+	SourceLeaverBuster = "leaver_buster"
+	SourceRankedEvent  = "ranked_restriction_event"
+)
+
+// kinds maps the raw restriction types, including the ones saved by the app, to their normalized kind
+var kinds = map[string]string{
+	"PERMANENT_BAN":                types.RestrictionPermanentBan,
+	"LEGACY_BAN":                   types.RestrictionPermanentBan,
+	"TIME_BAN":                     types.RestrictionTimedBan,
+	"TIMED_BAN":                    types.RestrictionTimedBan,
+	"TEMP_BAN":                     types.RestrictionTimedBan,
+	"TEXT_CHAT_RESTRICTION":        types.RestrictionChat,
+	"CHAT_RESTRICTION":             types.RestrictionChat,
+	"RANKED_RESTRICTION":           types.RestrictionRanked,
+	"QUEUE_DELAY":                  types.RestrictionQueueDelay,
+	"QUEUE_LOCKOUT":                types.RestrictionQueueDelay,
+	"LEAVER_BUSTER":                types.RestrictionQueueDelay,
+	"MFA_REQUIRED":                 types.RestrictionMultifactor,
+	"INVALID_CREDENTIALS":          types.RestrictionInvalidCredentials,
+	types.RequiredActionEula:       types.RestrictionRequiredAction,
+	types.RequiredActionNameChange: types.RestrictionRequiredAction,
+	types.RequiredActionAgeGate:    types.RestrictionRequiredAction,
+}
+
+// Normalize converts a raw restriction, the expiry is read from its expirationMillis data
+func Normalize(raw types.Restriction, source string) types.AccountRestriction {
+	rawType := strings.ToUpper(strings.TrimSpace(raw.Type))
+	kind, ok := kinds[rawType]
+	if !ok {
+		kind = types.RestrictionUnknown
+	}
+	restriction := types.AccountRestriction{
+		Kind:    kind,
+		Scope:   strings.ToLower(raw.Scope),
+		Source:  source,
+		RawType: raw.Type,
+		Reason:  raw.Reason,
+	}
+	if expiresAt, ok := millis(raw.Data["expirationMillis"]); ok {
+		restriction.ExpiresAt = &expiresAt
+	}
+	if games, ok := raw.Data["gamesRemaining"].(float64); ok {
+		restriction.GamesRemaining = int(games)
+	}
+	return restriction
+}
+
+// FromBan normalizes the restrictions of a userinfo ban
+func FromBan(ban types.Ban) []types.AccountRestriction {
+	restrictions := make([]types.AccountRestriction, 0, len(ban.Restrictions))
+	for _, raw := range ban.Restrictions {
+		restrictions = append(restrictions, Normalize(raw, SourceUserinfo))
+	}
+	return restrictions
+}
+
+// FromLeaverBuster reads the queue delay and ranked restriction of the leaver buster
+func FromLeaverBuster(leaverBuster *types.LeaverBusterResponse) []types.AccountRestriction {
+	restrictions := make([]types.AccountRestriction, 0)
+	if leaverBuster == nil {
+		return restrictions
+	}
+	entry := leaverBuster.LeaverBusterEntryDto
+	penalty := entry.LeaverPenalty
+
+	if (penalty.HasActivePenalty && penalty.DelayTime > 0) || entry.PunishedGamesRemaining > 0 {
+		restriction := types.AccountRestriction{
+			Kind:           types.RestrictionQueueDelay,
+			Scope:          "lol",
+			Source:         SourceLeaverBuster,
+			Reason:         penalty.PunishmentTimerType,
+			GamesRemaining: entry.PunishedGamesRemaining,
+		}
+		if expiresAt, ok := millis(penalty.QueueLockoutTimerExpiryUtcMillis); ok {
+			restriction.ExpiresAt = &expiresAt
+		}
+		restrictions = append(restrictions, restriction)
+	}
+
+	rankedGames := max(penalty.RankRestrictedGamesRemaining, leaverBuster.RankedRestrictionEntryDto.RestrictedGamesRemaining)
+	if penalty.RankRestricted || rankedGames > 0 {
+		restriction := types.AccountRestriction{
+			Kind:           types.RestrictionRanked,
+			Scope:          "lol",
+			Source:         SourceLeaverBuster,
+			Reason:         leaverBuster.RankedRestrictionEntryDto.PenaltyOrigin,
+			GamesRemaining: rankedGames,
+		}
+		if expiresAt, ok := millis(penalty.RankRestrictedTimerExpiryUtcMillis); ok {
+			restriction.ExpiresAt = &expiresAt
+		}
+		restrictions = append(restrictions, restriction)
+	}
+	return restrictions
+}
+
+// FromPartyRestriction reads the ranked restriction sent by the LCU ranked restriction event
+func FromPartyRestriction(party types.PartyRestriction) []types.AccountRestriction {
+	if party.PunishedGamesRemaining <= 0 {
+		return []types.AccountRestriction{}
+	}
+	return []types.AccountRestriction{{
+		Kind:           types.RestrictionRanked,
+		Scope:          "lol",
+		Source:         SourceRankedEvent,
+		GamesRemaining: party.PunishedGamesRemaining,
+	}}
+}
+
+// IsActive reports whether the restriction hasn't expired yet
+func IsActive(restriction types.AccountRestriction, now time.Time) bool {
+	return restriction.ExpiresAt == nil || restriction.ExpiresAt.After(now)
+}
+
+// BlocksLeague reports whether the restriction stops the account from playing League at all
+func BlocksLeague(restriction types.AccountRestriction, now time.Time) bool {
+	if restriction.Kind != types.RestrictionPermanentBan && restriction.Kind != types.RestrictionTimedBan {
+		return false
+	}
+	if restriction.Scope != "" && restriction.Scope != "riot" && restriction.Scope != "lol" {
+		return false
+	}
+	return IsActive(restriction, now)
+}
+
+// ToRestriction converts a normalized restriction back to the format stored by the backend
+func ToRestriction(restriction types.AccountRestriction) types.Restriction {
+	data := map[string]interface{}{}
+	if restriction.ExpiresAt != nil {
+		data["expirationMillis"] = restriction.ExpiresAt.UnixMilli()
+	}
+	if restriction.GamesRemaining > 0 {
+		data["gamesRemaining"] = restriction.GamesRemaining
+	}
+	if restriction.RawType != "" {
+		data["rawType"] = restriction.RawType
+	}
+	data["source"] = restriction.Source
+	return types.Restriction{
+		Type:   restriction.Kind,
+		Reason: restriction.Reason,
+		Scope:  restriction.Scope,
+		Data:   data,
+	}
+}
+
+// key identifies a restriction across sources, the same kind and scope read twice is one restriction
+func key(restriction types.AccountRestriction) string {
+	return restriction.Kind + "|" + restriction.Scope
+}
+
+// millis reads a unix millisecond timestamp decoded from JSON, zero means no timestamp
+func millis(value interface{}) (time.Time, bool) {
+	var ms int64
+	switch v := value.(type) {
+	case float64:
+		ms = int64(v)
+	case int64:
+		ms = v
+	case int:
+		ms = int64(v)
+	case string:
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		ms = parsed
+	default:
+		return time.Time{}, false
+	}
+	if ms <= 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms).UTC(), true
+}
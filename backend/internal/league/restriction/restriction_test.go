@@ -0,0 +1,100 @@
+package restriction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	expiry := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name      string
+		raw       types.Restriction
+		kind      string
+		scope     string
+		expiresAt *time.Time
+	}{
+		{name: "Permanent ban", raw: types.Restriction{Type: "PERMANENT_BAN", Scope: "lol"}, kind: types.RestrictionPermanentBan, scope: "lol"},
+		{name: "Timed ban with expiry", raw: types.Restriction{Type: "TIME_BAN", Scope: "riot", Data: map[string]interface{}{"expirationMillis": float64(expiry.UnixMilli())}}, kind: types.RestrictionTimedBan, scope: "riot", expiresAt: &expiry},
+		{name: "Chat restriction", raw: types.Restriction{Type: "TEXT_CHAT_RESTRICTION", Scope: "LOL"}, kind: types.RestrictionChat, scope: "lol"},
+		{name: "Ranked restriction", raw: types.Restriction{Type: "ranked_restriction"}, kind: types.RestrictionRanked},
+		{name: "Queue delay", raw: types.Restriction{Type: "QUEUE_LOCKOUT"}, kind: types.RestrictionQueueDelay},
+		{name: "Multifactor flag saved by the app", raw: types.Restriction{Type: "MFA_REQUIRED"}, kind: types.RestrictionMultifactor},
+		{name: "Invalid credentials flag saved by the app", raw: types.Restriction{Type: "INVALID_CREDENTIALS"}, kind: types.RestrictionInvalidCredentials},
+		{name: "Required action flag saved by the app", raw: types.Restriction{Type: types.RequiredActionEula}, kind: types.RestrictionRequiredAction},
+		{name: "Unknown type", raw: types.Restriction{Type: "SOMETHING_NEW"}, kind: types.RestrictionUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restriction := Normalize(tt.raw, SourceUserinfo)
+
+			assert.Equal(t, tt.kind, restriction.Kind)
+			assert.Equal(t, tt.scope, restriction.Scope)
+			assert.Equal(t, tt.raw.Type, restriction.RawType)
+			assert.Equal(t, tt.expiresAt, restriction.ExpiresAt)
+		})
+	}
+}
+
+func TestBlocksLeague(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	tests := []struct {
+		name        string
+		restriction types.AccountRestriction
+		expected    bool
+	}{
+		{name: "Permanent ban", restriction: types.AccountRestriction{Kind: types.RestrictionPermanentBan, Scope: "riot"}, expected: true},
+		{name: "Permanent ban without scope", restriction: types.AccountRestriction{Kind: types.RestrictionPermanentBan}, expected: true},
+		{name: "Ban on another product", restriction: types.AccountRestriction{Kind: types.RestrictionPermanentBan, Scope: "valorant"}},
+		{name: "Running timed ban", restriction: types.AccountRestriction{Kind: types.RestrictionTimedBan, Scope: "lol", ExpiresAt: &future}, expected: true},
+		{name: "Expired timed ban", restriction: types.AccountRestriction{Kind: types.RestrictionTimedBan, Scope: "lol", ExpiresAt: &past}},
+		{name: "Chat restriction", restriction: types.AccountRestriction{Kind: types.RestrictionChat, Scope: "lol"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, BlocksLeague(tt.restriction, now))
+		})
+	}
+}
+
+func TestFromLeaverBuster(t *testing.T) {
+	t.Run("Queue delay and ranked restriction", func(t *testing.T) {
+		leaverBuster := &types.LeaverBusterResponse{}
+		leaverBuster.LeaverBusterEntryDto.PunishedGamesRemaining = 2
+		leaverBuster.LeaverBusterEntryDto.LeaverPenalty.HasActivePenalty = true
+		leaverBuster.LeaverBusterEntryDto.LeaverPenalty.DelayTime = 300
+		leaverBuster.RankedRestrictionEntryDto.RestrictedGamesRemaining = 5
+
+		restrictions := FromLeaverBuster(leaverBuster)
+
+		assert.Len(t, restrictions, 2)
+		assert.Equal(t, types.RestrictionQueueDelay, restrictions[0].Kind)
+		assert.Equal(t, 2, restrictions[0].GamesRemaining)
+		assert.Equal(t, types.RestrictionRanked, restrictions[1].Kind)
+		assert.Equal(t, 5, restrictions[1].GamesRemaining)
+	})
+
+	t.Run("Clean account", func(t *testing.T) {
+		assert.Empty(t, FromLeaverBuster(&types.LeaverBusterResponse{}))
+		assert.Empty(t, FromLeaverBuster(nil))
+	})
+}
+
+func TestToRestriction(t *testing.T) {
+	expiry := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	stored := ToRestriction(types.AccountRestriction{Kind: types.RestrictionTimedBan, Scope: "lol", Source: SourceUserinfo, RawType: "TIME_BAN", ExpiresAt: &expiry})
+
+	assert.Equal(t, types.RestrictionTimedBan, stored.Type)
+	assert.Equal(t, expiry.UnixMilli(), stored.Data["expirationMillis"])
+
+	restored := Normalize(stored, SourceUserinfo)
+	assert.Equal(t, types.RestrictionTimedBan, restored.Kind)
+	assert.Equal(t, &expiry, restored.ExpiresAt)
+}
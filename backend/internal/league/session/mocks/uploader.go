@@ -0,0 +1,82 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+)
+
+// Uploader is an autogenerated mock type for the Uploader type
+type Uploader struct {
+	mock.Mock
+}
+
+type Uploader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Uploader) EXPECT() *Uploader_Expecter {
+	return &Uploader_Expecter{mock: &_m.Mock}
+}
+
+// SaveSessionSummary provides a mock function with given fields: summary
+func (_m *Uploader) SaveSessionSummary(summary types.SessionSummary) error {
+	ret := _m.Called(summary)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSessionSummary")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.SessionSummary) error); ok {
+		r0 = rf(summary)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Uploader_SaveSessionSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSessionSummary'
+type Uploader_SaveSessionSummary_Call struct {
+	*mock.Call
+}
+
+// SaveSessionSummary is a helper method to define mock.On call
+//   - summary types.SessionSummary
+func (_e *Uploader_Expecter) SaveSessionSummary(summary interface{}) *Uploader_SaveSessionSummary_Call {
+	return &Uploader_SaveSessionSummary_Call{Call: _e.mock.On("SaveSessionSummary", summary)}
+}
+
+func (_c *Uploader_SaveSessionSummary_Call) Run(run func(summary types.SessionSummary)) *Uploader_SaveSessionSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(types.SessionSummary))
+	})
+	return _c
+}
+
+func (_c *Uploader_SaveSessionSummary_Call) Return(_a0 error) *Uploader_SaveSessionSummary_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Uploader_SaveSessionSummary_Call) RunAndReturn(run func(types.SessionSummary) error) *Uploader_SaveSessionSummary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUploader creates a new instance of Uploader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUploader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Uploader {
+	mock := &Uploader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,453 @@
+package session
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"github.com/wailsapp/wails/v3/pkg/application"
+	"go.uber.org/zap"
+)
+
+// storageDir keeps one file per session in the app data directory
+const storageDir = "sessions"
+
+const (
+	queueSolo = "RANKED_SOLO_5x5"
+	queueFlex = "RANKED_FLEX_SR"
+)
+
+// AccountState defines the contract for reading the current account state
+type AccountState interface {
+	Get() *types.PartialSummonerRented
+}
+
+// Uploader defines the backend call used to publish finished sessions
+type Uploader interface {
+	SaveSessionSummary(summary types.SessionSummary) error
+}
+
+// snapshot holds the account values used to compute session deltas
+type snapshot struct {
+	blueEssence  *int
+	riotPoints   *int
+	leaguePoints map[string]int
+}
+
+// Recorder tracks the usage of a Nexus account while it is logged in
+type Recorder struct {
+	logger        *logger.Logger
+	uploader      Uploader
+	accountState  AccountState
+	store         *Store
+	mutex         sync.Mutex
+	current       *types.SessionSummary
+	baseline      snapshot
+	latest        snapshot
+	queue         string
+	gameStartedAt time.Time
+	inGame        time.Duration
+	leaverBuster  *types.LeaverBusterSession
+	lastPenalty   types.LeaverBusterPenalty
+	uploading     map[string]bool
+	now           func() time.Time
+}
+
+func New(logger *logger.Logger, uploader Uploader, accountState AccountState) *Recorder {
+	dir, err := config.DataPath(storageDir)
+	if err != nil {
+		logger.Error("Failed to resolve session storage directory", zap.Error(err))
+	}
+	return newRecorder(logger, uploader, accountState, NewStore(dir))
+}
+
+func newRecorder(logger *logger.Logger, uploader Uploader, accountState AccountState, store *Store) *Recorder {
+	return &Recorder{
+		logger:       logger,
+		uploader:     uploader,
+		accountState: accountState,
+		store:        store,
+		uploading:    make(map[string]bool),
+		now:          time.Now,
+	}
+}
+
+func (r *Recorder) OnStartup(ctx context.Context, options application.ServiceOptions) error {
+	go r.UploadPending()
+	return nil
+}
+
+// Start opens a session for the given username, closing any session that belongs to another account
+func (r *Recorder) Start(username string) {
+	username = strings.ToLower(username)
+	if username == "" {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.current != nil && r.current.Username == username {
+		return
+	}
+	if ended, ok := r.endLocked(); ok {
+		go r.upload(ended)
+	}
+
+	r.current = &types.SessionSummary{
+		ID:                uuid.NewString(),
+		Username:          username,
+		StartedAt:         r.now(),
+		GamesByQueue:      make(map[string]int),
+		LeaguePointsDelta: make(map[string]int),
+	}
+	r.baseline = snapshot{leaguePoints: make(map[string]int)}
+	r.latest = snapshot{leaguePoints: make(map[string]int)}
+	r.queue = ""
+	r.gameStartedAt = time.Time{}
+	r.inGame = 0
+	r.leaverBuster = nil
+	r.seedFromStateLocked()
+	r.persistLocked()
+
+	r.logger.Info("Rental session started", zap.String("sessionId", r.current.ID), zap.String("username", username))
+}
+
+// End closes the current session, stores it locally and uploads it to the backend
+func (r *Recorder) End() {
+	r.mutex.Lock()
+	summary, ok := r.endLocked()
+	r.mutex.Unlock()
+	if ok {
+		go r.upload(summary)
+	}
+}
+
+// endLocked closes the current session and stores it, the session is marked as uploading so UploadPending
+// leaves it to the caller
+func (r *Recorder) endLocked() (types.SessionSummary, bool) {
+	if r.current == nil {
+		return types.SessionSummary{}, false
+	}
+	r.seedFromStateLocked()
+	summary := r.summaryLocked(true)
+	r.current = nil
+	r.uploading[summary.ID] = true
+
+	if err := r.store.Save(summary, false); err != nil {
+		r.logger.Error("Failed to store session summary", zap.String("sessionId", summary.ID), zap.Error(err))
+	}
+	r.logger.Info("Rental session ended",
+		zap.String("sessionId", summary.ID),
+		zap.String("username", summary.Username),
+		zap.Any("gamesByQueue", summary.GamesByQueue),
+		zap.Int64("inGameSeconds", summary.InGameSeconds),
+		zap.Int64("inClientSeconds", summary.InClientSeconds))
+	return summary, true
+}
+
+// RecordGameflowPhase tracks game starts and the time spent inside a game
+func (r *Recorder) RecordGameflowPhase(phase types.LolChallengesGameflowPhase) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.current == nil {
+		return
+	}
+
+	inGame := !r.gameStartedAt.IsZero()
+	switch {
+	case phase == types.LolChallengesGameflowPhaseInProgress && !inGame:
+		r.gameStartedAt = r.now()
+		r.current.GamesByQueue[r.queueKeyLocked()]++
+		r.persistLocked()
+	case phase != types.LolChallengesGameflowPhaseInProgress && phase != types.LolChallengesGameflowPhaseReconnect && inGame:
+		r.inGame += r.now().Sub(r.gameStartedAt)
+		r.gameStartedAt = time.Time{}
+		r.persistLocked()
+	}
+}
+
+// RecordGameflowSession remembers the queue of the current lobby so games can be attributed to it
+func (r *Recorder) RecordGameflowSession(session types.LolGameflowV1Session) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.current == nil {
+		return
+	}
+
+	queue := session.GameData.Queue
+	switch {
+	case queue.Type != "":
+		r.queue = queue.Type
+	case queue.Id != 0:
+		r.queue = strconv.Itoa(queue.Id)
+	}
+}
+
+// RecordWallet tracks blue essence and RP changes
+func (r *Recorder) RecordWallet(wallet types.Wallet) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.current == nil {
+		return
+	}
+
+	r.seedFromStateLocked()
+	blueEssence := wallet.LolBlueEssence
+	changedBlueEssence := r.observeLocked(&r.baseline.blueEssence, &r.latest.blueEssence, &blueEssence)
+	changedRiotPoints := r.observeLocked(&r.baseline.riotPoints, &r.latest.riotPoints, wallet.RP)
+	if changedBlueEssence || changedRiotPoints {
+		r.persistLocked()
+	}
+}
+
+// RecordRanking tracks LP changes on the ranked queues
+func (r *Recorder) RecordRanking(ranking *types.RankedStatsRefresh) {
+	if ranking == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.current == nil {
+		return
+	}
+
+	r.seedFromStateLocked()
+	r.observeLeaguePointsLocked(ranking)
+	r.persistLocked()
+}
+
+// RecordLeaverBuster tracks the penalty progression, the first read of the session is its baseline
+func (r *Recorder) RecordLeaverBuster(penalty types.LeaverBusterPenalty) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.current == nil {
+		return
+	}
+
+	if r.leaverBuster == nil {
+		r.leaverBuster = &types.LeaverBusterSession{
+			StartTier:   penalty.Tier,
+			EndTier:     penalty.Tier,
+			Incidents:   make([]types.LeaverBusterIncident, 0),
+			TierHistory: make([]types.LeaverBusterTierChange, 0),
+		}
+		r.lastPenalty = penalty
+		r.persistLocked()
+		return
+	}
+
+	previous := r.lastPenalty
+	now := r.now()
+	if added := penalty.PunishedGamesRemaining - previous.PunishedGamesRemaining; added > 0 {
+		r.leaverBuster.PunishedGamesAdded += added
+	}
+	if added := penalty.RankedRestrictedGamesRemaining - previous.RankedRestrictedGamesRemaining; added > 0 {
+		r.leaverBuster.RankedGamesAdded += added
+	}
+	if penalty.WarningSentAt != nil && (previous.WarningSentAt == nil || !penalty.WarningSentAt.Equal(*previous.WarningSentAt)) {
+		r.leaverBuster.WarningsReceived++
+	}
+	if penalty.LastPunishmentGameID != 0 && penalty.LastPunishmentGameID != previous.LastPunishmentGameID {
+		incident := types.LeaverBusterIncident{GameID: penalty.LastPunishmentGameID, At: now, Tier: penalty.Tier}
+		if penalty.LastPunishmentAt != nil {
+			incident.At = *penalty.LastPunishmentAt
+		}
+		r.leaverBuster.Incidents = append(r.leaverBuster.Incidents, incident)
+	}
+	if penalty.Tier != previous.Tier {
+		r.leaverBuster.TierHistory = append(r.leaverBuster.TierHistory, types.LeaverBusterTierChange{
+			From: previous.Tier,
+			To:   penalty.Tier,
+			At:   now,
+		})
+	}
+	r.leaverBuster.EndTier = penalty.Tier
+	r.lastPenalty = penalty
+	r.persistLocked()
+}
+
+// GetCurrentSession returns a live summary of the ongoing session, or nil when none is active
+func (r *Recorder) GetCurrentSession() *types.SessionSummary {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.current == nil {
+		return nil
+	}
+	summary := r.summaryLocked(false)
+	return &summary
+}
+
+// GetSessions returns every session stored on this machine
+func (r *Recorder) GetSessions() ([]types.SessionSummary, error) {
+	stored, err := r.store.List()
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]types.SessionSummary, 0, len(stored))
+	for _, session := range stored {
+		summaries = append(summaries, session.Summary)
+	}
+	return summaries, nil
+}
+
+// UploadPending retries the upload of sessions that were not accepted by the backend yet
+func (r *Recorder) UploadPending() {
+	// The sessions are listed under the lock so a session uploaded meanwhile isn't read as pending
+	r.mutex.Lock()
+	stored, err := r.store.List()
+	if err != nil {
+		r.mutex.Unlock()
+		r.logger.Warn("Failed to list stored sessions", zap.Error(err))
+		return
+	}
+	pending := make([]StoredSession, 0, len(stored))
+	for _, session := range stored {
+		id := session.Summary.ID
+		if session.Uploaded || r.uploading[id] || (r.current != nil && r.current.ID == id) {
+			continue
+		}
+		r.uploading[id] = true
+		pending = append(pending, session)
+	}
+	r.mutex.Unlock()
+
+	for _, session := range pending {
+		summary := session.Summary
+		if summary.EndedAt == nil {
+			// The app exited before the session was closed, use the last time it was persisted
+			endedAt := session.ModifiedAt
+			summary.EndedAt = &endedAt
+			summary.InClientSeconds = int64(endedAt.Sub(summary.StartedAt).Seconds()) - summary.InGameSeconds
+		}
+		r.upload(summary)
+	}
+}
+
+// upload sends a session marked as uploading and clears the mark once it's done
+func (r *Recorder) upload(summary types.SessionSummary) {
+	defer func() {
+		r.mutex.Lock()
+		delete(r.uploading, summary.ID)
+		r.mutex.Unlock()
+	}()
+	if err := r.uploader.SaveSessionSummary(summary); err != nil {
+		r.logger.Warn("Failed to upload session summary, it will be retried later",
+			zap.String("sessionId", summary.ID), zap.Error(err))
+		return
+	}
+	if err := r.store.Save(summary, true); err != nil {
+		r.logger.Error("Failed to mark session summary as uploaded", zap.String("sessionId", summary.ID), zap.Error(err))
+	}
+}
+
+// seedFromStateLocked fills the session values from the account state, the state is updated
+// after the recorder is notified so it always holds the previous values
+func (r *Recorder) seedFromStateLocked() {
+	account := r.accountState.Get()
+	if account == nil {
+		return
+	}
+	if account.Currencies != nil {
+		r.observeLocked(&r.baseline.blueEssence, &r.latest.blueEssence, account.Currencies.LolBlueEssence)
+		r.observeLocked(&r.baseline.riotPoints, &r.latest.riotPoints, account.Currencies.RP)
+	}
+	if account.Rankings != nil && len(r.baseline.leaguePoints) == 0 {
+		r.observeLeaguePointsLocked(account.Rankings)
+	}
+}
+
+// observeLocked records a currency read and reports whether it changed what the session holds
+func (r *Recorder) observeLocked(baseline, latest **int, value *int) bool {
+	if value == nil {
+		return false
+	}
+	v := *value
+	if *latest != nil && **latest == v {
+		return false
+	}
+	if *baseline == nil {
+		b := v
+		*baseline = &b
+	}
+	*latest = &v
+	return true
+}
+
+func (r *Recorder) observeLeaguePointsLocked(ranking *types.RankedStatsRefresh) {
+	queues := map[string]types.RankedDetails{
+		queueSolo: ranking.RankedSolo5x5,
+		queueFlex: ranking.RankedFlexSR,
+	}
+	for queue, details := range queues {
+		if details.Tier == "" {
+			continue
+		}
+		if _, ok := r.baseline.leaguePoints[queue]; !ok {
+			r.baseline.leaguePoints[queue] = details.LeaguePoints
+		}
+		r.latest.leaguePoints[queue] = details.LeaguePoints
+	}
+}
+
+func (r *Recorder) queueKeyLocked() string {
+	if r.queue == "" {
+		return "UNKNOWN"
+	}
+	return r.queue
+}
+
+// summaryLocked builds a summary of the current session, closing the open game interval when final
+func (r *Recorder) summaryLocked(final bool) types.SessionSummary {
+	now := r.now()
+	inGame := r.inGame
+	if !r.gameStartedAt.IsZero() {
+		inGame += now.Sub(r.gameStartedAt)
+		if final {
+			r.inGame = inGame
+			r.gameStartedAt = time.Time{}
+		}
+	}
+
+	summary := *r.current
+	summary.GamesByQueue = make(map[string]int, len(r.current.GamesByQueue))
+	for queue, games := range r.current.GamesByQueue {
+		summary.GamesByQueue[queue] = games
+	}
+	summary.InGameSeconds = int64(inGame.Seconds())
+	summary.InClientSeconds = int64(now.Sub(summary.StartedAt).Seconds()) - summary.InGameSeconds
+	summary.BlueEssenceDelta = delta(r.baseline.blueEssence, r.latest.blueEssence)
+	summary.RiotPointsDelta = delta(r.baseline.riotPoints, r.latest.riotPoints)
+	summary.LeaguePointsDelta = make(map[string]int, len(r.latest.leaguePoints))
+	for queue, lp := range r.latest.leaguePoints {
+		summary.LeaguePointsDelta[queue] = lp - r.baseline.leaguePoints[queue]
+	}
+	if r.leaverBuster != nil {
+		leaverBuster := *r.leaverBuster
+		leaverBuster.Incidents = append([]types.LeaverBusterIncident{}, r.leaverBuster.Incidents...)
+		leaverBuster.TierHistory = append([]types.LeaverBusterTierChange{}, r.leaverBuster.TierHistory...)
+		summary.LeaverBuster = &leaverBuster
+	}
+	if final {
+		summary.EndedAt = &now
+	}
+	return summary
+}
+
+func (r *Recorder) persistLocked() {
+	if err := r.store.Save(r.summaryLocked(false), false); err != nil {
+		r.logger.Warn("Failed to persist ongoing session", zap.String("sessionId", r.current.ID), zap.Error(err))
+	}
+}
+
+func delta(baseline, latest *int) int {
+	if baseline == nil || latest == nil {
+		return 0
+	}
+	return *latest - *baseline
+}
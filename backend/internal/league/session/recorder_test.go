@@ -0,0 +1,198 @@
+package session
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/session/mocks"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	cfg := &config.Config{LogLevel: "error"}
+	newLogger := logger.New("TestRecorder", cfg)
+	startedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Session tracks games, currencies and league points", func(t *testing.T) {
+		mockUploader := mocks.NewUploader(t)
+		mockAccountState := mocks.NewAccountState(t)
+		blueEssence, riotPoints := 1000, 50
+		mockAccountState.EXPECT().Get().Return(&types.PartialSummonerRented{
+			Currencies: &types.CurrenciesPointer{LolBlueEssence: &blueEssence, RP: &riotPoints},
+			Rankings:   &types.RankedStatsRefresh{RankedSolo5x5: types.RankedDetails{Tier: "GOLD", LeaguePoints: 40}},
+		})
+
+		recorder := newRecorder(newLogger, mockUploader, mockAccountState, NewStore(t.TempDir()))
+		now := startedAt
+		recorder.now = func() time.Time { return now }
+		recorder.Start("Nexus1")
+
+		var session types.LolGameflowV1Session
+		session.GameData.Queue.Type = queueSolo
+		recorder.RecordGameflowSession(session)
+		now = now.Add(5 * time.Minute)
+		recorder.RecordGameflowPhase(types.LolChallengesGameflowPhaseInProgress)
+		now = now.Add(30 * time.Minute)
+		recorder.RecordGameflowPhase(types.LolChallengesGameflowPhaseEndOfGame)
+		recorder.RecordWallet(types.Wallet{LolBlueEssence: 1200})
+		// The account state is updated after the recorder is notified
+		blueEssence = 1200
+		recorder.RecordRanking(&types.RankedStatsRefresh{RankedSolo5x5: types.RankedDetails{Tier: "GOLD", LeaguePoints: 61}})
+		now = now.Add(5 * time.Minute)
+
+		summary := recorder.GetCurrentSession()
+		require.NotNil(t, summary)
+		assert.Equal(t, "nexus1", summary.Username)
+		assert.Equal(t, map[string]int{queueSolo: 1}, summary.GamesByQueue)
+		assert.Equal(t, int64(30*60), summary.InGameSeconds)
+		assert.Equal(t, int64(10*60), summary.InClientSeconds)
+		assert.Equal(t, 200, summary.BlueEssenceDelta)
+		assert.Equal(t, 0, summary.RiotPointsDelta)
+		assert.Equal(t, map[string]int{queueSolo: 21}, summary.LeaguePointsDelta)
+		assert.Nil(t, summary.EndedAt)
+	})
+
+	t.Run("Wallet changes are persisted", func(t *testing.T) {
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountState.EXPECT().Get().Return(nil)
+
+		store := NewStore(t.TempDir())
+		recorder := newRecorder(newLogger, mocks.NewUploader(t), mockAccountState, store)
+		recorder.Start("nexus1")
+		riotPoints := 50
+		recorder.RecordWallet(types.Wallet{LolBlueEssence: 1000, RP: &riotPoints})
+		riotPoints = 150
+		recorder.RecordWallet(types.Wallet{LolBlueEssence: 1300, RP: &riotPoints})
+
+		sessions, err := store.List()
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, 300, sessions[0].Summary.BlueEssenceDelta)
+		assert.Equal(t, 100, sessions[0].Summary.RiotPointsDelta)
+	})
+
+	t.Run("Leaver buster progression is recorded", func(t *testing.T) {
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountState.EXPECT().Get().Return(nil)
+
+		recorder := newRecorder(newLogger, mocks.NewUploader(t), mockAccountState, NewStore(t.TempDir()))
+		recorder.now = func() time.Time { return startedAt }
+		recorder.Start("nexus1")
+		recorder.RecordLeaverBuster(types.LeaverBusterPenalty{Tier: 1})
+		recorder.RecordLeaverBuster(types.LeaverBusterPenalty{Tier: 2, PunishedGamesRemaining: 5, LastPunishmentGameID: 42})
+
+		leaverBuster := recorder.GetCurrentSession().LeaverBuster
+		require.NotNil(t, leaverBuster)
+		assert.Equal(t, 1, leaverBuster.StartTier)
+		assert.Equal(t, 2, leaverBuster.EndTier)
+		assert.Equal(t, 5, leaverBuster.PunishedGamesAdded)
+		require.Len(t, leaverBuster.Incidents, 1)
+		assert.Equal(t, int64(42), leaverBuster.Incidents[0].GameID)
+		require.Len(t, leaverBuster.TierHistory, 1)
+		assert.Equal(t, types.LeaverBusterTierChange{From: 1, To: 2, At: startedAt}, leaverBuster.TierHistory[0])
+	})
+
+	t.Run("Switching accounts ends and uploads the previous session", func(t *testing.T) {
+		mockUploader := mocks.NewUploader(t)
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountState.EXPECT().Get().Return(nil)
+		uploaded := make(chan types.SessionSummary, 1)
+		mockUploader.EXPECT().SaveSessionSummary(mock.Anything).Run(func(summary types.SessionSummary) {
+			uploaded <- summary
+		}).Return(nil).Once()
+
+		store := NewStore(t.TempDir())
+		recorder := newRecorder(newLogger, mockUploader, mockAccountState, store)
+		recorder.Start("nexus1")
+		recorder.Start("NEXUS1")
+		recorder.Start("nexus2")
+
+		summary := <-uploaded
+		assert.Equal(t, "nexus1", summary.Username)
+		assert.NotNil(t, summary.EndedAt)
+		assert.Equal(t, "nexus2", recorder.GetCurrentSession().Username)
+		assert.Eventually(t, func() bool {
+			sessions, err := store.List()
+			return err == nil && len(sessions) == 2 && sessions[0].Uploaded
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Concurrent starts open a single session", func(t *testing.T) {
+		mockUploader := mocks.NewUploader(t)
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountState.EXPECT().Get().Return(nil)
+
+		store := NewStore(t.TempDir())
+		recorder := newRecorder(newLogger, mockUploader, mockAccountState, store)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				recorder.Start("nexus1")
+			}()
+		}
+		wg.Wait()
+
+		sessions, err := store.List()
+		require.NoError(t, err)
+		assert.Len(t, sessions, 1)
+		mockUploader.AssertNotCalled(t, "SaveSessionSummary", mock.Anything)
+	})
+
+	t.Run("Ended session is not uploaded again by UploadPending", func(t *testing.T) {
+		mockUploader := mocks.NewUploader(t)
+		mockAccountState := mocks.NewAccountState(t)
+		mockAccountState.EXPECT().Get().Return(nil)
+		release := make(chan struct{})
+		mockUploader.EXPECT().SaveSessionSummary(mock.Anything).Run(func(summary types.SessionSummary) {
+			<-release
+		}).Return(nil).Once()
+
+		store := NewStore(t.TempDir())
+		recorder := newRecorder(newLogger, mockUploader, mockAccountState, store)
+		recorder.Start("nexus1")
+		recorder.End()
+		// The upload started by End is still running
+		recorder.UploadPending()
+		close(release)
+
+		assert.Eventually(t, func() bool {
+			sessions, err := store.List()
+			return err == nil && len(sessions) == 1 && sessions[0].Uploaded
+		}, time.Second, 10*time.Millisecond)
+		recorder.UploadPending()
+	})
+
+	t.Run("Pending sessions are closed and retried", func(t *testing.T) {
+		mockUploader := mocks.NewUploader(t)
+		mockAccountState := mocks.NewAccountState(t)
+		store := NewStore(t.TempDir())
+		require.NoError(t, store.Save(types.SessionSummary{ID: "interrupted", Username: "nexus1", StartedAt: startedAt}, false))
+		require.NoError(t, store.Save(types.SessionSummary{ID: "uploaded", Username: "nexus1", StartedAt: startedAt.Add(time.Hour)}, true))
+
+		mockUploader.EXPECT().SaveSessionSummary(mock.MatchedBy(func(summary types.SessionSummary) bool {
+			return summary.ID == "interrupted" && summary.EndedAt != nil
+		})).Return(errors.New("backend unavailable")).Once()
+		recorder := newRecorder(newLogger, mockUploader, mockAccountState, store)
+		recorder.UploadPending()
+
+		sessions, err := store.List()
+		require.NoError(t, err)
+		assert.False(t, sessions[0].Uploaded, "a failed upload is kept for the next retry")
+
+		mockUploader.EXPECT().SaveSessionSummary(mock.Anything).Return(nil).Once()
+		recorder.UploadPending()
+		sessions, err = store.List()
+		require.NoError(t, err)
+		assert.True(t, sessions[0].Uploaded)
+		assert.True(t, sessions[1].Uploaded)
+	})
+}
@@ -0,0 +1,96 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+)
+
+// StoredSession is a session summary as persisted on disk
+type StoredSession struct {
+	Summary    types.SessionSummary `json:"summary"`
+	Uploaded   bool                 `json:"uploaded"`
+	ModifiedAt time.Time            `json:"modifiedAt"`
+}
+
+// Store keeps one JSON file per session in a local directory
+type Store struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+func (s *Store) Save(summary types.SessionSummary, uploaded bool) error {
+	if s.dir == "" {
+		return errors.New("session storage directory is not set")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	data, err := json.MarshalIndent(StoredSession{
+		Summary:    summary,
+		Uploaded:   uploaded,
+		ModifiedAt: time.Now(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	path := filepath.Join(s.dir, summary.ID+".json")
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return os.Rename(tempPath, path)
+}
+
+// List returns the stored sessions ordered by start time
+func (s *Store) List() ([]StoredSession, error) {
+	if s.dir == "" {
+		return nil, errors.New("session storage directory is not set")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read session directory: %w", err)
+	}
+
+	sessions := make([]StoredSession, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var session StoredSession
+		if err := json.Unmarshal(data, &session); err != nil {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Summary.StartedAt.Before(sessions[j].Summary.StartedAt)
+	})
+	return sessions, nil
+}
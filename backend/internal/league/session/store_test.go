@@ -0,0 +1,67 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	t.Run("Sessions are listed by start time", func(t *testing.T) {
+		store := NewStore(t.TempDir())
+		startedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+		require.NoError(t, store.Save(types.SessionSummary{ID: "second", Username: "nexus1", StartedAt: startedAt.Add(time.Hour)}, false))
+		require.NoError(t, store.Save(types.SessionSummary{ID: "first", Username: "nexus1", StartedAt: startedAt}, true))
+
+		sessions, err := store.List()
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		assert.Equal(t, "first", sessions[0].Summary.ID)
+		assert.True(t, sessions[0].Uploaded)
+		assert.Equal(t, "second", sessions[1].Summary.ID)
+		assert.False(t, sessions[1].Uploaded)
+		assert.False(t, sessions[1].ModifiedAt.IsZero())
+	})
+
+	t.Run("Saving a session again replaces it", func(t *testing.T) {
+		store := NewStore(t.TempDir())
+		require.NoError(t, store.Save(types.SessionSummary{ID: "session", InGameSeconds: 10}, false))
+		require.NoError(t, store.Save(types.SessionSummary{ID: "session", InGameSeconds: 20}, true))
+
+		sessions, err := store.List()
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, int64(20), sessions[0].Summary.InGameSeconds)
+		assert.True(t, sessions[0].Uploaded)
+	})
+
+	t.Run("Missing directory has no sessions", func(t *testing.T) {
+		sessions, err := NewStore(filepath.Join(t.TempDir(), "sessions")).List()
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+
+	t.Run("Unreadable files are skipped", func(t *testing.T) {
+		dir := t.TempDir()
+		store := NewStore(dir)
+		require.NoError(t, store.Save(types.SessionSummary{ID: "session"}, false))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0644))
+
+		sessions, err := store.List()
+		require.NoError(t, err)
+		assert.Len(t, sessions, 1)
+	})
+
+	t.Run("Store without a directory fails", func(t *testing.T) {
+		store := NewStore("")
+		assert.Error(t, store.Save(types.SessionSummary{ID: "session"}, false))
+		_, err := store.List()
+		assert.Error(t, err)
+	})
+}
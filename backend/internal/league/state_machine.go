@@ -0,0 +1,203 @@
+package league
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrInvalidTransition is returned when a transition is not in the transition table
+var ErrInvalidTransition = errors.New("invalid client state transition")
+
+// Transition causes recorded in the history
+const (
+	CauseRiotClientStarted = "riot_client_started"
+	CauseRiotClientClosed  = "riot_client_closed"
+	CauseLeagueStarted     = "league_client_started"
+	CauseLeagueClosed      = "league_client_closed"
+	CauseLCUReady          = "lcu_ready"
+	CauseCaptchaRequested  = "captcha_requested"
+	CauseCaptchaFailed     = "captcha_failed"
+	CauseCaptchaCancelled  = "captcha_cancelled"
+	CauseLoginRequested    = "login_requested"
+	CauseLoginFailed       = "login_failed"
+	CauseLoginSucceeded    = "login_succeeded"
+
+	CauseMultifactorRequired  = "multifactor_required"
+	CauseMultifactorSucceeded = "multifactor_succeeded"
+	CauseMultifactorFailed    = "multifactor_failed"
+	CauseMultifactorCancelled = "multifactor_cancelled"
+)
+
+// clientStateTransitions lists the states reachable from each state
+var clientStateTransitions = map[LeagueClientStateType][]LeagueClientStateType{
+	ClientStateNone:           {ClientStateClosed, ClientStateLoginReady, ClientStateLoggedIn, ClientStateWaitingLogin},
+	ClientStateClosed:         {ClientStateLoginReady, ClientStateLoggedIn, ClientStateWaitingLogin},
+	ClientStateLoginReady:     {ClientStateWaitingCaptcha, ClientStateWaitingLogin, ClientStateLoggedIn, ClientStateClosed},
+	ClientStateWaitingCaptcha: {ClientStateWaitingLogin, ClientStateLoginReady, ClientStateClosed},
+	ClientStateWaitingLogin:   {ClientStateLoggedIn, ClientStateWaitingMFA, ClientStateLoginReady, ClientStateClosed},
+	ClientStateWaitingMFA:     {ClientStateLoggedIn, ClientStateLoginReady, ClientStateClosed},
+	ClientStateLoggedIn:       {ClientStateLoginReady, ClientStateClosed},
+}
+
+// StateTransition is an entry of the state machine history
+type StateTransition struct {
+	From  LeagueClientStateType `json:"from"`
+	To    LeagueClientStateType `json:"to"`
+	Cause string                `json:"cause"`
+	At    time.Time             `json:"at"`
+}
+
+// TransitionError describes a rejected transition
+type TransitionError struct {
+	From   LeagueClientStateType
+	To     LeagueClientStateType
+	Cause  string
+	Reason error
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("transition from %q to %q (%s) rejected: %v", e.From, e.To, e.Cause, e.Reason)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return e.Reason
+}
+
+// TransitionGuard can reject a transition that is allowed by the table
+type TransitionGuard func(from, to LeagueClientStateType) error
+
+type transitionKey struct {
+	from LeagueClientStateType
+	to   LeagueClientStateType
+}
+
+// StateMachine validates client state transitions and keeps a bounded history of them
+type StateMachine struct {
+	mutex       sync.RWMutex
+	current     LeagueClientStateType
+	history     []StateTransition
+	historySize int
+	guards      map[transitionKey][]TransitionGuard
+	now         func() time.Time
+}
+
+func NewStateMachine(historySize int) *StateMachine {
+	return &StateMachine{
+		current:     ClientStateNone,
+		history:     make([]StateTransition, 0, historySize),
+		historySize: historySize,
+		guards:      make(map[transitionKey][]TransitionGuard),
+		now:         time.Now,
+	}
+}
+
+// AddGuard registers a guard for the transition, guards run in the order they were added
+func (sm *StateMachine) AddGuard(from, to LeagueClientStateType, guard TransitionGuard) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	key := transitionKey{from: from, to: to}
+	sm.guards[key] = append(sm.guards[key], guard)
+}
+
+func (sm *StateMachine) Current() LeagueClientStateType {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	return sm.current
+}
+
+// History returns the recorded transitions from the oldest to the newest
+func (sm *StateMachine) History() []StateTransition {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	history := make([]StateTransition, len(sm.history))
+	copy(history, sm.history)
+	return history
+}
+
+// CanTransition reports whether the table allows moving from one state to the other
+func CanTransition(from, to LeagueClientStateType) bool {
+	for _, allowed := range clientStateTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves to the given state, returning false without error when already in it
+func (sm *StateMachine) Transition(to LeagueClientStateType, cause string) (bool, error) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	from := sm.current
+	if from == to {
+		return false, nil
+	}
+	if !CanTransition(from, to) {
+		return false, &TransitionError{From: from, To: to, Cause: cause, Reason: ErrInvalidTransition}
+	}
+	for _, guard := range sm.guards[transitionKey{from: from, to: to}] {
+		if err := guard(from, to); err != nil {
+			return false, &TransitionError{From: from, To: to, Cause: cause, Reason: err}
+		}
+	}
+
+	sm.current = to
+	sm.history = append(sm.history, StateTransition{From: from, To: to, Cause: cause, At: sm.now()})
+	if sm.historySize > 0 && len(sm.history) > sm.historySize {
+		sm.history = sm.history[len(sm.history)-sm.historySize:]
+	}
+	return true, nil
+}
+
+// ClientSignals is a snapshot of the process and LCU lifecycle used to detect the client state
+type ClientSignals struct {
+	RiotClientRunning bool
+	LoginAvailable    bool
+	Authenticated     bool
+	LeagueRunning     bool
+	Playing           bool
+	LCUReady          bool
+}
+
+// DetectState maps the lifecycle signals to the state the client should be in. The captcha, login and
+// multifactor states are driven by the user and are only left when the processes go away
+func DetectState(current LeagueClientStateType, signals ClientSignals) (LeagueClientStateType, string) {
+	if signals.Playing || (signals.LeagueRunning && signals.LCUReady) {
+		if current == ClientStateWaitingCaptcha {
+			return current, ""
+		}
+		if signals.Playing {
+			return ClientStateLoggedIn, CauseLeagueStarted
+		}
+		return ClientStateLoggedIn, CauseLCUReady
+	}
+
+	if signals.RiotClientRunning {
+		switch {
+		case current == ClientStateWaitingCaptcha:
+			return current, ""
+		case signals.Authenticated:
+			return ClientStateLoggedIn, CauseLoginSucceeded
+		case current == ClientStateWaitingLogin, current == ClientStateWaitingMFA:
+			return current, ""
+		case signals.LoginAvailable:
+			if current == ClientStateLoggedIn {
+				return ClientStateLoginReady, CauseLeagueClosed
+			}
+			return ClientStateLoginReady, CauseRiotClientStarted
+		}
+		return current, ""
+	}
+
+	if signals.LeagueRunning {
+		// Still starting or shutting down, wait for the LCU
+		return current, ""
+	}
+	return ClientStateClosed, CauseRiotClientClosed
+}
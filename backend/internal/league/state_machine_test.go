@@ -0,0 +1,195 @@
+package league
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateMachine_Transition(t *testing.T) {
+	t.Run("Allowed transition is recorded", func(t *testing.T) {
+		sm := NewStateMachine(10)
+		at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		sm.now = func() time.Time { return at }
+
+		changed, err := sm.Transition(ClientStateLoginReady, CauseRiotClientStarted)
+
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, ClientStateLoginReady, sm.Current())
+		assert.Equal(t, []StateTransition{{
+			From:  ClientStateNone,
+			To:    ClientStateLoginReady,
+			Cause: CauseRiotClientStarted,
+			At:    at,
+		}}, sm.History())
+	})
+
+	t.Run("Same state is a no-op", func(t *testing.T) {
+		sm := NewStateMachine(10)
+		_, _ = sm.Transition(ClientStateClosed, CauseRiotClientClosed)
+
+		changed, err := sm.Transition(ClientStateClosed, CauseRiotClientClosed)
+
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Len(t, sm.History(), 1)
+	})
+
+	t.Run("Transition outside the table is rejected", func(t *testing.T) {
+		sm := NewStateMachine(10)
+		_, _ = sm.Transition(ClientStateClosed, CauseRiotClientClosed)
+
+		changed, err := sm.Transition(ClientStateWaitingMFA, CauseMultifactorRequired)
+
+		assert.False(t, changed)
+		assert.True(t, errors.Is(err, ErrInvalidTransition))
+		var transitionErr *TransitionError
+		assert.True(t, errors.As(err, &transitionErr))
+		assert.Equal(t, ClientStateClosed, transitionErr.From)
+		assert.Equal(t, ClientStateWaitingMFA, transitionErr.To)
+		assert.Equal(t, ClientStateClosed, sm.Current())
+		assert.Len(t, sm.History(), 1)
+	})
+
+	t.Run("Login can start without a captcha", func(t *testing.T) {
+		for _, from := range []LeagueClientStateType{ClientStateNone, ClientStateClosed, ClientStateLoginReady, ClientStateWaitingCaptcha} {
+			sm := NewStateMachine(10)
+			if from != ClientStateNone {
+				sm.current = from
+			}
+
+			changed, err := sm.Transition(ClientStateWaitingLogin, CauseLoginRequested)
+
+			assert.NoError(t, err, from)
+			assert.True(t, changed, from)
+			assert.Equal(t, ClientStateWaitingLogin, sm.Current())
+		}
+	})
+
+	t.Run("Guard can reject an allowed transition", func(t *testing.T) {
+		sm := NewStateMachine(10)
+		guardErr := errors.New("riot client is not running")
+		sm.AddGuard(ClientStateLoginReady, ClientStateWaitingCaptcha, func(from, to LeagueClientStateType) error {
+			return guardErr
+		})
+		_, _ = sm.Transition(ClientStateLoginReady, CauseRiotClientStarted)
+
+		changed, err := sm.Transition(ClientStateWaitingCaptcha, CauseCaptchaRequested)
+
+		assert.False(t, changed)
+		assert.True(t, errors.Is(err, guardErr))
+		assert.Equal(t, ClientStateLoginReady, sm.Current())
+	})
+
+	t.Run("History keeps only the latest transitions", func(t *testing.T) {
+		sm := NewStateMachine(2)
+
+		_, _ = sm.Transition(ClientStateLoginReady, CauseRiotClientStarted)
+		_, _ = sm.Transition(ClientStateWaitingCaptcha, CauseCaptchaRequested)
+		_, _ = sm.Transition(ClientStateWaitingLogin, CauseLoginRequested)
+
+		history := sm.History()
+		assert.Len(t, history, 2)
+		assert.Equal(t, ClientStateWaitingCaptcha, history[0].To)
+		assert.Equal(t, ClientStateWaitingLogin, history[1].To)
+	})
+}
+
+func TestDetectState(t *testing.T) {
+	tests := []struct {
+		name          string
+		current       LeagueClientStateType
+		signals       ClientSignals
+		expectedState LeagueClientStateType
+		expectedCause string
+	}{
+		{
+			name:          "Nothing running",
+			current:       ClientStateLoggedIn,
+			signals:       ClientSignals{},
+			expectedState: ClientStateClosed,
+			expectedCause: CauseRiotClientClosed,
+		},
+		{
+			name:          "Login screen",
+			current:       ClientStateClosed,
+			signals:       ClientSignals{RiotClientRunning: true, LoginAvailable: true},
+			expectedState: ClientStateLoginReady,
+			expectedCause: CauseRiotClientStarted,
+		},
+		{
+			name:          "Logged out from the league client",
+			current:       ClientStateLoggedIn,
+			signals:       ClientSignals{RiotClientRunning: true, LoginAvailable: true},
+			expectedState: ClientStateLoginReady,
+			expectedCause: CauseLeagueClosed,
+		},
+		{
+			name:          "Authenticated in the riot client",
+			current:       ClientStateWaitingLogin,
+			signals:       ClientSignals{RiotClientRunning: true, Authenticated: true},
+			expectedState: ClientStateLoggedIn,
+			expectedCause: CauseLoginSucceeded,
+		},
+		{
+			name:          "LCU ready",
+			current:       ClientStateLoginReady,
+			signals:       ClientSignals{LeagueRunning: true, LCUReady: true},
+			expectedState: ClientStateLoggedIn,
+			expectedCause: CauseLCUReady,
+		},
+		{
+			name:          "In game",
+			current:       ClientStateClosed,
+			signals:       ClientSignals{Playing: true},
+			expectedState: ClientStateLoggedIn,
+			expectedCause: CauseLeagueStarted,
+		},
+		{
+			name:          "Captcha is kept while the riot client runs",
+			current:       ClientStateWaitingCaptcha,
+			signals:       ClientSignals{RiotClientRunning: true, LoginAvailable: true},
+			expectedState: ClientStateWaitingCaptcha,
+		},
+		{
+			name:          "Login is kept until authenticated",
+			current:       ClientStateWaitingLogin,
+			signals:       ClientSignals{RiotClientRunning: true, LoginAvailable: true},
+			expectedState: ClientStateWaitingLogin,
+		},
+		{
+			name:          "Multifactor is kept until authenticated",
+			current:       ClientStateWaitingMFA,
+			signals:       ClientSignals{RiotClientRunning: true, LoginAvailable: true},
+			expectedState: ClientStateWaitingMFA,
+		},
+		{
+			name:          "Multifactor completed in the riot client",
+			current:       ClientStateWaitingMFA,
+			signals:       ClientSignals{RiotClientRunning: true, Authenticated: true},
+			expectedState: ClientStateLoggedIn,
+			expectedCause: CauseLoginSucceeded,
+		},
+		{
+			name:          "League starting without LCU",
+			current:       ClientStateLoggedIn,
+			signals:       ClientSignals{LeagueRunning: true},
+			expectedState: ClientStateLoggedIn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, cause := DetectState(tt.current, tt.signals)
+
+			assert.Equal(t, tt.expectedState, state)
+			assert.Equal(t, tt.expectedCause, cause)
+			if state != tt.current {
+				assert.True(t, CanTransition(tt.current, state))
+			}
+		})
+	}
+}
@@ -8,10 +8,10 @@ import (
 	"github.com/go-resty/resty/v2"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/hex-boost/hex-nexus-app/backend/internal/league/lcu"
 	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/riot/region"
 	"github.com/hex-boost/hex-nexus-app/backend/types"
 	"go.uber.org/zap"
 )
@@ -503,14 +503,16 @@ func (s *Client) GetLeaverBuster(currentPlatformId string) (*types.LeaverBusterR
 		s.logger.Warn(errMsg)
 		return nil, errors.New(errMsg)
 	}
-	platformIdUpper := strings.ToUpper(currentPlatformId)
-	leagueEdgeURL, ok := types.LeagueEdgeURL[platformIdUpper]
-	if !ok {
-		errMsg := fmt.Sprintf("league edge URL not found for platform ID: %s", currentPlatformId)
-		s.logger.Error(errMsg)
-		return nil, errors.New(errMsg)
+	platformRegion, err := region.Resolve(currentPlatformId)
+	if err != nil {
+		s.logger.Error("Failed to resolve the leaver buster region", zap.String("platformId", currentPlatformId), zap.Error(err))
+		return nil, err
+	}
+	leaverBusterUrl, err := platformRegion.LeaverBusterURL()
+	if err != nil {
+		s.logger.Error("Failed to build the leaver buster URL", zap.Error(err))
+		return nil, err
 	}
-	leaverBusterUrl := fmt.Sprintf("%s/leaverbuster-ledge/restrictionInfo", leagueEdgeURL)
 
 	var leaverBuster types.LeaverBusterResponse
 	riotGamesClient := resty.New()
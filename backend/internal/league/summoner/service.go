@@ -5,23 +5,36 @@ import (
 	"sync"
 
 	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/hex-boost/hex-nexus-app/backend/riot/region"
 	"github.com/hex-boost/hex-nexus-app/backend/types"
 	"golang.org/x/sync/errgroup"
 )
 
+// LeaverBusterSource defines the call used to read the leaver buster of the logged in account
+type LeaverBusterSource interface {
+	GetLeaverBuster(platformID string) (*types.LeaverBusterResponse, error)
+}
+
 type Service struct {
-	client *Client
+	client       *Client
+	leaverBuster LeaverBusterSource
 
 	logger *logger.Logger
 }
 
 func NewService(logger *logger.Logger, client *Client) *Service {
 	return &Service{
-		client: client,
-		logger: logger,
+		client:       client,
+		leaverBuster: client,
+		logger:       logger,
 	}
 }
 
+// SetLeaverBusterSource replaces the leaver buster read, the leaver buster service records every read
+func (l *Service) SetLeaverBusterSource(leaverBuster LeaverBusterSource) {
+	l.leaverBuster = leaverBuster
+}
+
 func (l *Service) UpdateFromLCU() (*types.PartialSummonerRented, error) {
 	var (
 		champions         []int
@@ -57,7 +70,7 @@ func (l *Service) UpdateFromLCU() (*types.PartialSummonerRented, error) {
 			l.logger.Error("Failed to get platform id from user info")
 			return nil
 		}
-		leaverBusterResponse, err := l.client.GetLeaverBuster(cpid)
+		leaverBusterResponse, err := l.leaverBuster.GetLeaverBuster(cpid)
 		if err != nil {
 			l.logger.Error("Failed to get leaver buster")
 			return err
@@ -156,6 +169,7 @@ func (l *Service) UpdateFromLCU() (*types.PartialSummonerRented, error) {
 			currencies.LolBlueEssence = &beInt
 		}
 	}
+	server := region.PlatformID(userinfo.LOL.CPID)
 	summoner := &types.PartialSummonerRented{
 		Username:         userinfo.Username,
 		GameName:         &userinfo.Acct.GameName,
@@ -167,7 +181,7 @@ func (l *Service) UpdateFromLCU() (*types.PartialSummonerRented, error) {
 		LeaverBuster:     &leaverBuster,
 		Currencies:       &currencies,
 		Rankings:         rankingMap,
-		Server:           &userinfo.LOL.CPID,
+		Server:           &server,
 		Ban:              &userinfo.Ban,
 		IsPhoneVerified:  &userinfo.PhoneNumberVerified,
 		PartyRestriction: &partyRestrictions,
@@ -0,0 +1,345 @@
+package lolskin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultCacheQuota is the disk space the installed mods may use before the least recently used are evicted
+const DefaultCacheQuota int64 = 2 << 30
+
+const cacheIndexFile = "cache.json"
+
+// extractingSuffix marks a mod that is still being extracted, see LolSkin.install
+const extractingSuffix = ".extracting"
+
+var ErrModNotInstalled = errors.New("mod is not installed")
+
+// SelectionSource defines where the selected skins are read, selected mods are never evicted
+type SelectionSource interface {
+	GetAllSelections() []ChampionSkin
+}
+
+// CacheEntry is an installed mod
+type CacheEntry struct {
+	ModName  string    `json:"modName"`
+	Size     int64     `json:"size"`
+	LastUsed time.Time `json:"lastUsed"`
+	Pinned   bool      `json:"pinned"`
+	Selected bool      `json:"selected"`
+}
+
+// CacheStats is the disk usage of the lolskin directory
+type CacheStats struct {
+	Quota         int64        `json:"quota"`
+	InstalledSize int64        `json:"installedSize"`
+	DownloadsSize int64        `json:"downloadsSize"`
+	LegacySize    int64        `json:"legacySize"`
+	Entries       []CacheEntry `json:"entries"`
+}
+
+// cacheIndex is the file the entries are persisted to
+type cacheIndex struct {
+	Quota   int64                  `json:"quota"`
+	Entries map[string]*CacheEntry `json:"entries"`
+}
+
+// Cache keeps the installed mods under a disk quota, evicting the least recently used ones
+type Cache struct {
+	logger     logger.Loggerer
+	dir        string
+	catalog    *CatalogService
+	selections SelectionSource
+	now        func() time.Time
+
+	mutex sync.Mutex
+	index cacheIndex
+}
+
+func NewCache(logger logger.Loggerer, dir string, catalog *CatalogService, selections SelectionSource) *Cache {
+	c := &Cache{
+		logger:     logger,
+		dir:        dir,
+		catalog:    catalog,
+		selections: selections,
+		now:        time.Now,
+		index:      cacheIndex{Quota: DefaultCacheQuota, Entries: make(map[string]*CacheEntry)},
+	}
+	data, err := os.ReadFile(filepath.Join(dir, cacheIndexFile))
+	if err == nil {
+		if err := json.Unmarshal(data, &c.index); err != nil {
+			logger.Error("Failed to parse skin cache index, rebuilding it", zap.Error(err))
+			c.index = cacheIndex{Quota: DefaultCacheQuota, Entries: make(map[string]*CacheEntry)}
+		}
+	}
+	if c.index.Entries == nil {
+		c.index.Entries = make(map[string]*CacheEntry)
+	}
+	if c.index.Quota <= 0 {
+		c.index.Quota = DefaultCacheQuota
+	}
+	c.mutex.Lock()
+	c.reconcileLocked()
+	c.mutex.Unlock()
+	return c
+}
+
+func (c *Cache) installedDir() string {
+	return filepath.Join(c.dir, "installed")
+}
+
+// Reconcile syncs the index with the installed folder, mods installed before the index existed are added
+// and deleted ones are dropped
+func (c *Cache) Reconcile() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reconcileLocked()
+}
+
+func (c *Cache) reconcileLocked() {
+	dirEntries, err := os.ReadDir(c.installedDir())
+	if err != nil && !os.IsNotExist(err) {
+		c.logger.Error("Failed to read installed skins", zap.Error(err))
+		return
+	}
+	found := make(map[string]bool, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			continue
+		}
+		modName := dirEntry.Name()
+		path := filepath.Join(c.installedDir(), modName)
+		if strings.HasSuffix(modName, extractingSuffix) {
+			// Left by an extraction that didn't finish
+			os.RemoveAll(path)
+			continue
+		}
+		found[modName] = true
+		if _, ok := c.index.Entries[modName]; ok {
+			continue
+		}
+		entry := &CacheEntry{ModName: modName, Size: dirSize(path), LastUsed: c.now()}
+		if info, err := dirEntry.Info(); err == nil {
+			entry.LastUsed = info.ModTime()
+		}
+		c.index.Entries[modName] = entry
+	}
+	for modName := range c.index.Entries {
+		if !found[modName] {
+			delete(c.index.Entries, modName)
+		}
+	}
+	c.saveLocked()
+}
+
+// Touch marks the mods as used now, mods installed since the last call are added to the index
+func (c *Cache) Touch(modNames []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.now()
+	for _, modName := range modNames {
+		entry, ok := c.index.Entries[modName]
+		if !ok {
+			path := filepath.Join(c.installedDir(), modName)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			entry = &CacheEntry{ModName: modName, Size: dirSize(path)}
+			c.index.Entries[modName] = entry
+		}
+		entry.LastUsed = now
+	}
+	c.saveLocked()
+}
+
+// Enforce evicts the least recently used mods until the installed ones fit in the quota. Pinned, selected
+// and imported mods are never evicted
+func (c *Cache) Enforce() []string {
+	selected := c.selectedMods()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	used := int64(0)
+	candidates := make([]*CacheEntry, 0, len(c.index.Entries))
+	for _, entry := range c.index.Entries {
+		used += entry.Size
+		if !entry.Pinned && !selected[entry.ModName] && !strings.HasPrefix(entry.ModName, CustomModPrefix) {
+			candidates = append(candidates, entry)
+		}
+	}
+	if used <= c.index.Quota {
+		return nil
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].LastUsed.Before(candidates[j].LastUsed) })
+
+	evicted := make([]string, 0)
+	for _, entry := range candidates {
+		if used <= c.index.Quota {
+			break
+		}
+		if err := os.RemoveAll(filepath.Join(c.installedDir(), entry.ModName)); err != nil {
+			c.logger.Error("Failed to evict skin", zap.String("mod", entry.ModName), zap.Error(err))
+			continue
+		}
+		used -= entry.Size
+		delete(c.index.Entries, entry.ModName)
+		evicted = append(evicted, entry.ModName)
+	}
+	if used > c.index.Quota {
+		c.logger.Info("Skin cache is over its quota with only pinned or selected skins left",
+			zap.Int64("used", used),
+			zap.Int64("quota", c.index.Quota))
+	}
+	if len(evicted) > 0 {
+		c.logger.Info("Evicted least recently used skins", zap.Strings("mods", evicted), zap.Int64("used", used))
+	}
+	c.saveLocked()
+	return evicted
+}
+
+// SetQuota changes the disk quota in bytes and evicts what no longer fits
+func (c *Cache) SetQuota(quota int64) error {
+	if quota <= 0 {
+		return fmt.Errorf("invalid skin cache quota: %d", quota)
+	}
+	c.mutex.Lock()
+	c.index.Quota = quota
+	c.saveLocked()
+	c.mutex.Unlock()
+	c.Enforce()
+	return nil
+}
+
+// Pin keeps a mod from being evicted
+func (c *Cache) Pin(modName string, pinned bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.index.Entries[modName]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrModNotInstalled, modName)
+	}
+	entry.Pinned = pinned
+	c.saveLocked()
+	return nil
+}
+
+// Purge removes the given mods, the selected and imported ones are kept and returned as skipped, imported
+// mods are removed through CustomMods
+func (c *Cache) Purge(modNames []string) (skipped []string, err error) {
+	selected := c.selectedMods()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var errs []error
+	for _, modName := range modNames {
+		if _, ok := c.index.Entries[modName]; !ok {
+			continue
+		}
+		if selected[modName] || strings.HasPrefix(modName, CustomModPrefix) {
+			skipped = append(skipped, modName)
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(c.installedDir(), modName)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", modName, err))
+			continue
+		}
+		delete(c.index.Entries, modName)
+	}
+	c.saveLocked()
+	return skipped, errors.Join(errs...)
+}
+
+// PurgeDownloads removes the partial downloads and the DataDragon data cached by older versions
+func (c *Cache) PurgeDownloads() error {
+	var errs []error
+	for _, folder := range []string{"temp_downloads", "dataDragon"} {
+		if err := os.RemoveAll(filepath.Join(c.dir, folder)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", folder, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetStats returns the installed mods, most recently used first, and the disk usage
+func (c *Cache) GetStats() CacheStats {
+	selected := c.selectedMods()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := CacheStats{
+		Quota:         c.index.Quota,
+		DownloadsSize: dirSize(filepath.Join(c.dir, "temp_downloads")),
+		LegacySize:    dirSize(filepath.Join(c.dir, "dataDragon")),
+		Entries:       make([]CacheEntry, 0, len(c.index.Entries)),
+	}
+	for _, entry := range c.index.Entries {
+		stats.InstalledSize += entry.Size
+		copied := *entry
+		copied.Selected = selected[entry.ModName]
+		stats.Entries = append(stats.Entries, copied)
+	}
+	sort.Slice(stats.Entries, func(i, j int) bool { return stats.Entries[i].LastUsed.After(stats.Entries[j].LastUsed) })
+	return stats
+}
+
+// selectedMods resolves the selected skins to the mods they install as
+func (c *Cache) selectedMods() map[string]bool {
+	selected := make(map[string]bool)
+	if c.selections == nil {
+		return selected
+	}
+	for _, selection := range c.selections.GetAllSelections() {
+		if selection.CustomModID != "" {
+			selected[selection.CustomModID] = true
+			continue
+		}
+		resolved, err := c.catalog.Resolve(selection.ChampionID, selection.SkinID, selection.ChromaID)
+		if err != nil {
+			continue
+		}
+		selected[resolved.ModName()] = true
+	}
+	return selected
+}
+
+func (c *Cache) saveLocked() {
+	data, err := json.MarshalIndent(c.index, "", "  ")
+	if err != nil {
+		c.logger.Error("Failed to encode skin cache index", zap.Error(err))
+		return
+	}
+	path := filepath.Join(c.dir, cacheIndexFile)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		c.logger.Error("Failed to write skin cache index", zap.Error(err))
+		return
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		c.logger.Error("Failed to write skin cache index", zap.Error(err))
+	}
+}
+
+// dirSize returns the size of the files under a directory, a missing directory is empty
+func dirSize(path string) int64 {
+	var size int64
+	filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
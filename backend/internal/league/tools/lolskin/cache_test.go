@@ -0,0 +1,140 @@
+package lolskin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// installMod creates an installed mod of the given size
+func installMod(t *testing.T, dir, modName string, size int) {
+	path := filepath.Join(dir, "installed", modName, "WAD")
+	require.NoError(t, os.MkdirAll(path, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "skin.wad.client"), make([]byte, size), 0644))
+}
+
+func TestCache(t *testing.T) {
+	newLogger := logger.New("TestCache", &config.Config{LogLevel: "error"})
+	loaded, err := LoadCatalog(fstest.MapFS{CatalogPath: {Data: []byte(testCatalog)}}, CatalogPath)
+	require.NoError(t, err)
+	catalog := NewCatalogService(newLogger, loaded)
+	startedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Least recently used mods are evicted over the quota", func(t *testing.T) {
+		dir := t.TempDir()
+		mockSelections := NewMockSelectionSource(t)
+		mockSelections.EXPECT().GetAllSelections().Return(nil).Maybe()
+		cache := NewCache(newLogger, dir, catalog, mockSelections)
+		now := startedAt
+		cache.now = func() time.Time { return now }
+		for _, modName := range []string{"99002", "99007", "266001"} {
+			installMod(t, dir, modName, 100)
+			cache.Touch([]string{modName})
+			now = now.Add(time.Minute)
+		}
+		cache.Touch([]string{"99002"})
+
+		require.NoError(t, cache.SetQuota(200))
+		assert.NoDirExists(t, filepath.Join(dir, "installed", "99007"))
+		assert.DirExists(t, filepath.Join(dir, "installed", "99002"))
+		assert.DirExists(t, filepath.Join(dir, "installed", "266001"))
+		assert.Equal(t, int64(200), cache.GetStats().InstalledSize)
+	})
+
+	t.Run("Selected and pinned mods are never evicted", func(t *testing.T) {
+		dir := t.TempDir()
+		mockSelections := NewMockSelectionSource(t)
+		mockSelections.EXPECT().GetAllSelections().Return([]ChampionSkin{ChampionSkin{ChampionID: 99, SkinID: 99002}}).Maybe()
+		cache := NewCache(newLogger, dir, catalog, mockSelections)
+		now := startedAt
+		cache.now = func() time.Time { return now }
+		for _, modName := range []string{"99002", "99007", "266001"} {
+			installMod(t, dir, modName, 100)
+			cache.Touch([]string{modName})
+			now = now.Add(time.Minute)
+		}
+		require.NoError(t, cache.Pin("99007", true))
+
+		require.NoError(t, cache.SetQuota(1))
+		assert.DirExists(t, filepath.Join(dir, "installed", "99002"))
+		assert.DirExists(t, filepath.Join(dir, "installed", "99007"))
+		assert.NoDirExists(t, filepath.Join(dir, "installed", "266001"))
+
+		stats := cache.GetStats()
+		require.Len(t, stats.Entries, 2)
+		assert.True(t, stats.Entries[0].Pinned)
+		assert.True(t, stats.Entries[1].Selected)
+	})
+
+	t.Run("Index is persisted and reconciled with the installed folder", func(t *testing.T) {
+		dir := t.TempDir()
+		installMod(t, dir, "99002", 10)
+		installMod(t, dir, "99007", 20)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "installed", "266001"+extractingSuffix), 0755))
+
+		mockSelections := NewMockSelectionSource(t)
+		mockSelections.EXPECT().GetAllSelections().Return(nil).Maybe()
+		cache := NewCache(newLogger, dir, catalog, mockSelections)
+		require.NoError(t, cache.Pin("99002", true))
+		assert.Equal(t, int64(30), cache.GetStats().InstalledSize)
+		assert.NoDirExists(t, filepath.Join(dir, "installed", "266001"+extractingSuffix))
+
+		require.NoError(t, os.RemoveAll(filepath.Join(dir, "installed", "99007")))
+		reloaded := NewCache(newLogger, dir, catalog, mockSelections)
+		stats := reloaded.GetStats()
+		require.Len(t, stats.Entries, 1)
+		assert.Equal(t, "99002", stats.Entries[0].ModName)
+		assert.True(t, stats.Entries[0].Pinned)
+	})
+
+	t.Run("Purge skips the selected mods", func(t *testing.T) {
+		dir := t.TempDir()
+		installMod(t, dir, "99007-99009", 10)
+		installMod(t, dir, "266001", 10)
+		mockSelections := NewMockSelectionSource(t)
+		mockSelections.EXPECT().GetAllSelections().Return([]ChampionSkin{ChampionSkin{ChampionID: 99, SkinID: 7, ChromaID: int32Ptr(9)}}).Maybe()
+		cache := NewCache(newLogger, dir, catalog, mockSelections)
+
+		skipped, err := cache.Purge([]string{"99007-99009", "266001", "unknown"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"99007-99009"}, skipped)
+		assert.NoDirExists(t, filepath.Join(dir, "installed", "266001"))
+		assert.Len(t, cache.GetStats().Entries, 1)
+	})
+
+	t.Run("Downloads and legacy data are purged", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "temp_downloads"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "temp_downloads", "99002.zip.part"), make([]byte, 50), 0644))
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "dataDragon"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "dataDragon", "champion_data.json"), make([]byte, 5), 0644))
+		mockSelections := NewMockSelectionSource(t)
+		mockSelections.EXPECT().GetAllSelections().Return(nil).Maybe()
+		cache := NewCache(newLogger, dir, catalog, mockSelections)
+
+		stats := cache.GetStats()
+		assert.Equal(t, int64(50), stats.DownloadsSize)
+		assert.Equal(t, int64(5), stats.LegacySize)
+
+		require.NoError(t, cache.PurgeDownloads())
+		stats = cache.GetStats()
+		assert.Zero(t, stats.DownloadsSize)
+		assert.Zero(t, stats.LegacySize)
+	})
+
+	t.Run("Invalid quota and unknown pins are rejected", func(t *testing.T) {
+		mockSelections := NewMockSelectionSource(t)
+		mockSelections.EXPECT().GetAllSelections().Return(nil).Maybe()
+		cache := NewCache(newLogger, t.TempDir(), catalog, mockSelections)
+		assert.Error(t, cache.SetQuota(0))
+		assert.ErrorIs(t, cache.Pin("missing", true), ErrModNotInstalled)
+		assert.Equal(t, DefaultCacheQuota, cache.GetStats().Quota)
+	})
+}
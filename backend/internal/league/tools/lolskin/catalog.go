@@ -0,0 +1,210 @@
+package lolskin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// CatalogPath is the path of the catalog embedded by main.go
+const CatalogPath = "backend/assets/mod-tools/catalog.json"
+
+var (
+	ErrChampionNotFound = errors.New("champion not found in the skin catalog")
+	ErrSkinNotFound     = errors.New("skin not found in the skin catalog")
+	ErrChromaNotFound   = errors.New("chroma not found in the skin catalog")
+)
+
+// LoadCatalog reads the catalog from the embedded file system
+func LoadCatalog(fsys fs.FS, path string) (*Catalog, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read skin catalog: %w", err)
+	}
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse skin catalog: %w", err)
+	}
+	return &catalog, nil
+}
+
+// catalogSkin points a skin ID back to its champion
+type catalogSkin struct {
+	champion *Champion
+	skin     *Skin
+}
+
+// CatalogService resolves champions, skins and chromas from the embedded catalog without any network access.
+// The catalog stores skin and chroma numbers, they are indexed by the LCU ID, championKey*1000+number
+type CatalogService struct {
+	logger    logger.Loggerer
+	catalog   *Catalog
+	champions map[int]*Champion
+	// aliases is keyed by the lowercase alias, mods name their WAD files after it
+	aliases map[string]*Champion
+	skins   map[int]catalogSkin
+	// chromas is keyed by skin ID then chroma ID, chroma numbers can repeat skin numbers of the same champion
+	chromas map[int]map[int]*Chroma
+	// chromaSkins maps the chroma IDs that aren't skin IDs to their skin, the LCU selects chromas by their ID
+	chromaSkins map[int]int
+}
+
+func NewCatalogService(logger logger.Loggerer, catalog *Catalog) *CatalogService {
+	if catalog == nil {
+		catalog = &Catalog{}
+	}
+	s := &CatalogService{
+		logger:      logger,
+		catalog:     catalog,
+		champions:   make(map[int]*Champion, len(catalog.Catalog)),
+		aliases:     make(map[string]*Champion, len(catalog.Catalog)),
+		skins:       make(map[int]catalogSkin),
+		chromas:     make(map[int]map[int]*Chroma),
+		chromaSkins: make(map[int]int),
+	}
+	for i := range catalog.Catalog {
+		champion := &catalog.Catalog[i]
+		s.champions[champion.ChampionKey] = champion
+		if champion.ChampionAlias != "" {
+			s.aliases[strings.ToLower(champion.ChampionAlias)] = champion
+		}
+		for j := range champion.Skins {
+			skin := &champion.Skins[j]
+			skinID := fullID(champion.ChampionKey, skin.SkinId)
+			s.skins[skinID] = catalogSkin{champion: champion, skin: skin}
+			if len(skin.Chromas) == 0 {
+				continue
+			}
+			chromas := make(map[int]*Chroma, len(skin.Chromas))
+			for k := range skin.Chromas {
+				chroma := &skin.Chromas[k]
+				chromas[fullID(champion.ChampionKey, chroma.ChromaId)] = chroma
+			}
+			s.chromas[skinID] = chromas
+		}
+	}
+	// Skins come first and a chroma listed by two skins belongs to the first one
+	for _, champion := range catalog.Catalog {
+		for _, skin := range champion.Skins {
+			for _, chroma := range skin.Chromas {
+				chromaID := fullID(champion.ChampionKey, chroma.ChromaId)
+				_, isSkin := s.skins[chromaID]
+				_, seen := s.chromaSkins[chromaID]
+				if !isSkin && !seen {
+					s.chromaSkins[chromaID] = fullID(champion.ChampionKey, skin.SkinId)
+				}
+			}
+		}
+	}
+	logger.Info("Skin catalog indexed",
+		zap.String("commit", catalog.LastCommitSha),
+		zap.Int("champions", len(s.champions)),
+		zap.Int("skins", len(s.skins)))
+	return s
+}
+
+// Version returns the commit of the skin repository the catalog was built from
+func (s *CatalogService) Version() string {
+	return s.catalog.LastCommitSha
+}
+
+// GetChampions returns the champions of the catalog sorted by name
+func (s *CatalogService) GetChampions() []Champion {
+	champions := make([]Champion, len(s.catalog.Catalog))
+	copy(champions, s.catalog.Catalog)
+	sort.Slice(champions, func(i, j int) bool { return champions[i].ChampionName < champions[j].ChampionName })
+	return champions
+}
+
+// GetChampion returns a champion by its key
+func (s *CatalogService) GetChampion(championKey int) (*Champion, error) {
+	champion, ok := s.champions[championKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrChampionNotFound, championKey)
+	}
+	return champion, nil
+}
+
+// GetChampionByAlias returns a champion by its alias, like MonkeyKing for Wukong
+func (s *CatalogService) GetChampionByAlias(alias string) (*Champion, error) {
+	champion, ok := s.aliases[strings.ToLower(alias)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrChampionNotFound, alias)
+	}
+	return champion, nil
+}
+
+// GetChromas returns the chromas of a skin with their LCU IDs
+func (s *CatalogService) GetChromas(championID, skinID int32) ([]Chroma, error) {
+	entry, err := s.skin(int(championID), int(skinID))
+	if err != nil {
+		return nil, err
+	}
+	chromas := make([]Chroma, 0, len(entry.skin.Chromas))
+	for _, chroma := range entry.skin.Chromas {
+		chroma.ChromaId = fullID(entry.champion.ChampionKey, chroma.ChromaId)
+		chromas = append(chromas, chroma)
+	}
+	return chromas, nil
+}
+
+// Resolve returns the download of a skin, or of one of its chromas when chromaID is set. Both IDs may be
+// given as LCU IDs or as catalog numbers, a chroma ID given as the skin is resolved to its chroma
+func (s *CatalogService) Resolve(championID, skinID int32, chromaID *int32) (*ResolvedSkin, error) {
+	championKey := int(championID)
+	id := fullID(championKey, int(skinID))
+	if parent, isChroma := s.chromaSkins[id]; isChroma && chromaID == nil {
+		chromaID = &skinID
+		id = parent
+	}
+	entry, err := s.skin(championKey, id)
+	if err != nil {
+		return nil, err
+	}
+	resolved := &ResolvedSkin{
+		ChampionKey:  championKey,
+		ChampionName: entry.champion.ChampionName,
+		SkinID:       id,
+		SkinName:     entry.skin.SkinName,
+		DownloadUrl:  entry.skin.DownloadUrl,
+		Sha256:       entry.skin.Sha256,
+	}
+	if chromaID == nil {
+		return resolved, nil
+	}
+	chromaKey := fullID(championKey, int(*chromaID))
+	chroma, ok := s.chromas[id][chromaKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: skin %d chroma %d", ErrChromaNotFound, id, *chromaID)
+	}
+	resolved.ChromaID = &chromaKey
+	resolved.DownloadUrl = chroma.DownloadUrl
+	resolved.Sha256 = chroma.Sha256
+	return resolved, nil
+}
+
+func (s *CatalogService) skin(championKey, skinID int) (catalogSkin, error) {
+	if _, ok := s.champions[championKey]; !ok {
+		return catalogSkin{}, fmt.Errorf("%w: %d", ErrChampionNotFound, championKey)
+	}
+	id := fullID(championKey, skinID)
+	entry, ok := s.skins[id]
+	if !ok || entry.champion.ChampionKey != championKey {
+		return catalogSkin{}, fmt.Errorf("%w: champion %d skin %d", ErrSkinNotFound, championKey, skinID)
+	}
+	return entry, nil
+}
+
+// fullID converts a catalog number to the LCU ID, IDs that already carry the champion are kept
+func fullID(championKey, id int) int {
+	if id >= 1000 {
+		return id
+	}
+	return championKey*1000 + id
+}
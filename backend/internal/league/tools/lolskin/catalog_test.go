@@ -0,0 +1,159 @@
+package lolskin
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCatalog = `{
+	"lastCommitSha": "abc123",
+	"catalog": [
+		{
+			"championName": "Lux",
+			"championKey": 99,
+			"championAlias": "Lux",
+			"skins": [
+				{"skinName": "Spellthief Lux", "skinId": 2, "downloadUrl": "https://skins.test/99/2.fantome"},
+				{
+					"skinName": "Elementalist Lux",
+					"skinId": 7,
+					"downloadUrl": "https://skins.test/99/7.fantome",
+					"chromas": [
+						{"chromaId": 2, "downloadUrl": "https://skins.test/99/7-2.fantome"},
+						{"chromaId": 9, "downloadUrl": "https://skins.test/99/9.fantome"}
+					]
+				}
+			]
+		},
+		{
+			"championName": "Aatrox",
+			"championKey": 266,
+			"championAlias": "Aatrox",
+			"skins": [{"skinName": "Justicar Aatrox", "skinId": 1, "downloadUrl": "https://skins.test/266/1.fantome"}]
+		}
+	]
+}`
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestCatalogService(t *testing.T) {
+	loaded, err := LoadCatalog(fstest.MapFS{CatalogPath: {Data: []byte(testCatalog)}}, CatalogPath)
+	require.NoError(t, err)
+	catalog := NewCatalogService(logger.New("TestCatalog", &config.Config{LogLevel: "error"}), loaded)
+
+	t.Run("Skins resolve by LCU ID and by catalog number", func(t *testing.T) {
+		for _, skinID := range []int32{99002, 2} {
+			resolved, err := catalog.Resolve(99, skinID, nil)
+			require.NoError(t, err)
+			assert.Equal(t, 99002, resolved.SkinID)
+			assert.Equal(t, "Spellthief Lux", resolved.SkinName)
+			assert.Equal(t, "https://skins.test/99/2.fantome", resolved.DownloadUrl)
+			assert.Nil(t, resolved.ChromaID)
+		}
+	})
+
+	t.Run("Chromas resolve within their skin", func(t *testing.T) {
+		// Chroma 2 of Elementalist Lux shares its number with Spellthief Lux
+		resolved, err := catalog.Resolve(99, 99007, int32Ptr(99002))
+		require.NoError(t, err)
+		require.NotNil(t, resolved.ChromaID)
+		assert.Equal(t, 99002, *resolved.ChromaID)
+		assert.Equal(t, 99007, resolved.SkinID)
+		assert.Equal(t, "https://skins.test/99/7-2.fantome", resolved.DownloadUrl)
+	})
+
+	t.Run("Chroma selected by the LCU as the skin", func(t *testing.T) {
+		resolved, err := catalog.Resolve(99, 99009, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 99007, resolved.SkinID)
+		assert.Equal(t, "https://skins.test/99/9.fantome", resolved.DownloadUrl)
+		assert.Equal(t, "99007-99009", resolved.ModName())
+	})
+
+	t.Run("Chromas of a skin install to their own folder", func(t *testing.T) {
+		base, err := catalog.Resolve(99, 7, nil)
+		require.NoError(t, err)
+		chroma, err := catalog.Resolve(99, 7, int32Ptr(2))
+		require.NoError(t, err)
+		assert.Equal(t, "99007", base.ModName())
+		assert.Equal(t, "99007-99002", chroma.ModName())
+	})
+
+	t.Run("Chromas are listed with their LCU IDs", func(t *testing.T) {
+		chromas, err := catalog.GetChromas(99, 7)
+		require.NoError(t, err)
+		require.Len(t, chromas, 2)
+		assert.Equal(t, 99002, chromas[0].ChromaId)
+		assert.Equal(t, 99009, chromas[1].ChromaId)
+
+		chromas, err = catalog.GetChromas(99, 2)
+		require.NoError(t, err)
+		assert.Empty(t, chromas)
+	})
+
+	t.Run("Unknown entries are reported", func(t *testing.T) {
+		_, err := catalog.Resolve(1, 1001, nil)
+		assert.ErrorIs(t, err, ErrChampionNotFound)
+
+		_, err = catalog.Resolve(99, 99050, nil)
+		assert.ErrorIs(t, err, ErrSkinNotFound)
+
+		_, err = catalog.Resolve(99, 266001, nil)
+		assert.ErrorIs(t, err, ErrSkinNotFound, "a skin of another champion is not resolved")
+
+		_, err = catalog.Resolve(99, 99002, int32Ptr(99009))
+		assert.ErrorIs(t, err, ErrChromaNotFound)
+	})
+
+	t.Run("Champions are listed by name", func(t *testing.T) {
+		champions := catalog.GetChampions()
+		require.Len(t, champions, 2)
+		assert.Equal(t, "Aatrox", champions[0].ChampionName)
+		assert.Equal(t, "abc123", catalog.Version())
+
+		champion, err := catalog.GetChampion(266)
+		require.NoError(t, err)
+		assert.Equal(t, "Aatrox", champion.ChampionName)
+
+		champion, err = catalog.GetChampionByAlias("lux")
+		require.NoError(t, err)
+		assert.Equal(t, 99, champion.ChampionKey)
+		_, err = catalog.GetChampionByAlias("MonkeyKing")
+		assert.ErrorIs(t, err, ErrChampionNotFound)
+	})
+
+	t.Run("Invalid catalog is rejected", func(t *testing.T) {
+		_, err := LoadCatalog(fstest.MapFS{CatalogPath: {Data: []byte("{")}}, CatalogPath)
+		assert.Error(t, err)
+		_, err = LoadCatalog(fstest.MapFS{}, CatalogPath)
+		assert.Error(t, err)
+	})
+}
+
+func TestEmbeddedCatalog(t *testing.T) {
+	catalog, err := LoadCatalog(os.DirFS("../../../../.."), CatalogPath)
+	require.NoError(t, err)
+	service := NewCatalogService(logger.New("TestCatalog", &config.Config{LogLevel: "error"}), catalog)
+
+	for _, champion := range catalog.Catalog {
+		for _, skin := range champion.Skins {
+			resolved, err := service.Resolve(int32(champion.ChampionKey), int32(skin.SkinId), nil)
+			require.NoError(t, err)
+			assert.NotEmpty(t, resolved.DownloadUrl, "%s has no download", skin.SkinName)
+			for _, chroma := range skin.Chromas {
+				chromaID := int32(chroma.ChromaId)
+				resolved, err := service.Resolve(int32(champion.ChampionKey), int32(skin.SkinId), &chromaID)
+				require.NoError(t, err)
+				assert.Equal(t, chroma.DownloadUrl, resolved.DownloadUrl)
+			}
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package lolskin
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// champSelectDebounce is how long the pick must stay unchanged before acting on it
+const champSelectDebounce = 400 * time.Millisecond
+
+// ChampionInjector defines how the skin of a champion is prefetched and injected
+type ChampionInjector interface {
+	Prefetch(selection ChampionSkin)
+	Inject(selection ChampionSkin) error
+}
+
+// SelectionLookup defines where the selected skin of a champion is read
+type SelectionLookup interface {
+	GetChampionSkin(championID int32) (ChampionSkin, bool)
+}
+
+// ChampSelect follows the local pick in champ select, the selected skin is downloaded while the champion
+// is hovered and the overlay is rebuilt for it once it's locked. Rapid changes are debounced
+type ChampSelect struct {
+	logger     logger.Loggerer
+	selections SelectionLookup
+	injector   ChampionInjector
+	delay      time.Duration
+
+	mutex      sync.Mutex
+	hoverTimer *time.Timer
+	lockTimer  *time.Timer
+	hovered    int32
+	locked     int32
+	// injected is the selection of the last successful injection, a failed one leaves the previous overlay
+	injected *ChampionSkin
+	// injectMutex keeps injections from overlapping
+	injectMutex sync.Mutex
+}
+
+func NewChampSelect(logger logger.Loggerer, selections SelectionLookup, injector ChampionInjector) *ChampSelect {
+	return &ChampSelect{
+		logger:     logger,
+		selections: selections,
+		injector:   injector,
+		delay:      champSelectDebounce,
+	}
+}
+
+// Hover prefetches the selected skin of a hovered champion
+func (c *ChampSelect) Hover(championID int32) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if championID == 0 || championID == c.hovered || championID == c.locked {
+		return
+	}
+	c.hovered = championID
+	if c.hoverTimer != nil {
+		c.hoverTimer.Stop()
+	}
+	c.hoverTimer = time.AfterFunc(c.delay, func() {
+		selection, ok := c.selections.GetChampionSkin(championID)
+		if !ok {
+			return
+		}
+		c.logger.Debug("Prefetching skin of hovered champion", zap.Int32("championId", championID))
+		c.injector.Prefetch(selection)
+	})
+}
+
+// Lock rebuilds the overlay for the locked champion
+func (c *ChampSelect) Lock(championID int32) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if championID == 0 || championID == c.locked {
+		return
+	}
+	c.locked = championID
+	if c.hoverTimer != nil {
+		c.hoverTimer.Stop()
+	}
+	c.scheduleLocked(false)
+}
+
+// Reinject rebuilds the overlay of the locked champion, used when its selected skin changed or the
+// patcher was restarted
+func (c *ChampSelect) Reinject() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.locked == 0 {
+		c.logger.Debug("No champion locked, waiting for champ select to inject")
+		return
+	}
+	c.scheduleLocked(true)
+}
+
+// Reset forgets the pick when champ select ends, the injected overlay is kept for the game
+func (c *ChampSelect) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, timer := range []*time.Timer{c.hoverTimer, c.lockTimer} {
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+	c.hovered = 0
+	c.locked = 0
+	c.injected = nil
+}
+
+// Locked returns the locked champion, 0 when there is none
+func (c *ChampSelect) Locked() int32 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.locked
+}
+
+func (c *ChampSelect) scheduleLocked(force bool) {
+	if c.lockTimer != nil {
+		c.lockTimer.Stop()
+	}
+	championID := c.locked
+	c.lockTimer = time.AfterFunc(c.delay, func() { c.inject(championID, force) })
+}
+
+func (c *ChampSelect) inject(championID int32, force bool) {
+	c.injectMutex.Lock()
+	defer c.injectMutex.Unlock()
+	c.mutex.Lock()
+	locked, injected := c.locked, c.injected
+	c.mutex.Unlock()
+	if locked != championID {
+		// The pick changed while waiting for the previous injection
+		return
+	}
+	selection, ok := c.selections.GetChampionSkin(championID)
+	if !ok {
+		c.logger.Info("No skin selected for the locked champion", zap.Int32("championId", championID))
+		return
+	}
+	if !force && injected != nil && sameSelection(*injected, selection) {
+		return
+	}
+
+	c.logger.Info("Injecting skin of locked champion",
+		zap.Int32("championId", championID),
+		zap.Int32("skinId", selection.SkinID))
+	if err := c.injector.Inject(selection); err != nil {
+		c.logger.Error("Failed to inject locked champion, keeping the previous overlay",
+			zap.Int32("championId", championID),
+			zap.Error(err))
+		return
+	}
+	c.mutex.Lock()
+	c.injected = &selection
+	c.mutex.Unlock()
+}
+
+func sameSelection(a, b ChampionSkin) bool {
+	if a.ChampionID != b.ChampionID || a.SkinID != b.SkinID || a.CustomModID != b.CustomModID {
+		return false
+	}
+	if a.ChromaID == nil || b.ChromaID == nil {
+		return a.ChromaID == b.ChromaID
+	}
+	return *a.ChromaID == *b.ChromaID
+}
@@ -0,0 +1,152 @@
+package lolskin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// champion matches the selection of championID
+func champion(championID int32) interface{} {
+	return mock.MatchedBy(func(selection ChampionSkin) bool {
+		return selection.ChampionID == championID
+	})
+}
+
+// settle waits for the debounced calls to run
+func settle(champSelect *ChampSelect) {
+	time.Sleep(5 * champSelect.delay)
+}
+
+func TestChampSelect(t *testing.T) {
+	newLogger := logger.New("TestChampSelect", &config.Config{LogLevel: "error"})
+
+	t.Run("Only the last hovered champion is prefetched", func(t *testing.T) {
+		mockInjector := NewMockChampionInjector(t)
+		mockInjector.EXPECT().Prefetch(champion(266)).Return().Once()
+
+		state := NewState()
+		state.SetChampionSkin(99, 99002, nil)
+		state.SetChampionSkin(266, 266001, nil)
+		champSelect := NewChampSelect(newLogger, state, mockInjector)
+		champSelect.delay = 20 * time.Millisecond
+		defer champSelect.Reset()
+		champSelect.Hover(99)
+		champSelect.Hover(1)
+		champSelect.Hover(266)
+		settle(champSelect)
+	})
+
+	t.Run("Champions without a selected skin aren't prefetched", func(t *testing.T) {
+		mockInjector := NewMockChampionInjector(t)
+
+		state := NewState()
+		state.SetChampionSkin(99, 99002, nil)
+		state.SetChampionSkin(266, 266001, nil)
+		champSelect := NewChampSelect(newLogger, state, mockInjector)
+		champSelect.delay = 20 * time.Millisecond
+		defer champSelect.Reset()
+		champSelect.Hover(1)
+		settle(champSelect)
+
+		mockInjector.AssertNotCalled(t, "Prefetch", mock.Anything)
+	})
+
+	t.Run("Locked champion is injected once", func(t *testing.T) {
+		mockInjector := NewMockChampionInjector(t)
+		mockInjector.EXPECT().Inject(champion(99)).Return(nil).Once()
+
+		state := NewState()
+		state.SetChampionSkin(99, 99002, nil)
+		state.SetChampionSkin(266, 266001, nil)
+		champSelect := NewChampSelect(newLogger, state, mockInjector)
+		champSelect.delay = 20 * time.Millisecond
+		defer champSelect.Reset()
+		champSelect.Hover(99)
+		champSelect.Lock(99)
+		champSelect.Lock(99)
+		settle(champSelect)
+
+		mockInjector.AssertNotCalled(t, "Prefetch", mock.Anything)
+		assert.Equal(t, int32(99), champSelect.Locked())
+	})
+
+	t.Run("Rapid lock changes are debounced", func(t *testing.T) {
+		mockInjector := NewMockChampionInjector(t)
+		mockInjector.EXPECT().Inject(champion(266)).Return(nil).Once()
+
+		state := NewState()
+		state.SetChampionSkin(99, 99002, nil)
+		state.SetChampionSkin(266, 266001, nil)
+		champSelect := NewChampSelect(newLogger, state, mockInjector)
+		champSelect.delay = 20 * time.Millisecond
+		defer champSelect.Reset()
+		champSelect.Lock(99)
+		champSelect.Lock(266)
+		settle(champSelect)
+	})
+
+	t.Run("Reinject rebuilds the overlay of the locked champion", func(t *testing.T) {
+		mockInjector := NewMockChampionInjector(t)
+
+		state := NewState()
+		state.SetChampionSkin(99, 99002, nil)
+		state.SetChampionSkin(266, 266001, nil)
+		champSelect := NewChampSelect(newLogger, state, mockInjector)
+		champSelect.delay = 20 * time.Millisecond
+		defer champSelect.Reset()
+		champSelect.Reinject()
+		settle(champSelect)
+		mockInjector.AssertNotCalled(t, "Inject", mock.Anything)
+
+		mockInjector.EXPECT().Inject(champion(99)).Return(nil).Times(2)
+		champSelect.Lock(99)
+		settle(champSelect)
+		champSelect.Reinject()
+		settle(champSelect)
+	})
+
+	t.Run("Failed injection is retried on reinject", func(t *testing.T) {
+		mockInjector := NewMockChampionInjector(t)
+		mockInjector.EXPECT().Inject(champion(99)).Return(errors.New("overlay failed")).Once()
+
+		state := NewState()
+		state.SetChampionSkin(99, 99002, nil)
+		state.SetChampionSkin(266, 266001, nil)
+		champSelect := NewChampSelect(newLogger, state, mockInjector)
+		champSelect.delay = 20 * time.Millisecond
+		defer champSelect.Reset()
+		champSelect.Lock(99)
+		settle(champSelect)
+		champSelect.mutex.Lock()
+		assert.Nil(t, champSelect.injected)
+		champSelect.mutex.Unlock()
+
+		mockInjector.EXPECT().Inject(champion(99)).Return(nil).Once()
+		champSelect.Reinject()
+		settle(champSelect)
+	})
+
+	t.Run("Reset cancels a pending injection", func(t *testing.T) {
+		mockInjector := NewMockChampionInjector(t)
+
+		state := NewState()
+		state.SetChampionSkin(99, 99002, nil)
+		state.SetChampionSkin(266, 266001, nil)
+		champSelect := NewChampSelect(newLogger, state, mockInjector)
+		champSelect.delay = 20 * time.Millisecond
+		defer champSelect.Reset()
+		champSelect.Lock(99)
+		champSelect.Reset()
+		settle(champSelect)
+
+		mockInjector.AssertNotCalled(t, "Inject", mock.Anything)
+		require.Zero(t, champSelect.Locked())
+	})
+}
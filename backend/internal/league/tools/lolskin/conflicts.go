@@ -0,0 +1,328 @@
+package lolskin
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// EventModConflicts carries the []ModConflict found before building an overlay
+const EventModConflicts = "lolskin:mods:conflicts"
+
+// rawTarget groups the files a mod replaces outside of the WAD files
+const rawTarget = "RAW"
+
+// WAD v3 layout, the table of contents follows the header and starts every entry with the path hash
+const (
+	wadHeaderSize = 272
+	wadEntrySize  = 32
+)
+
+var ErrUnsupportedWad = errors.New("unsupported WAD file")
+
+// ModConflict is a WAD file whose entries are replaced by more than one mod. Mods are in overlay order,
+// mod-tools keeps the entry of the first one
+type ModConflict struct {
+	Wad     string   `json:"wad"`
+	Mods    []string `json:"mods"`
+	Entries int      `json:"entries"`
+	Winner  string   `json:"winner"`
+}
+
+// ConflictResolution is the priority order and the exclusions applied to the mods of the current selection
+type ConflictResolution struct {
+	Priority []string `json:"priority"`
+	Excluded []string `json:"excluded"`
+}
+
+// ModTargets maps the lowercase name of every WAD file a mod replaces to the path hashes of its entries,
+// the RAW files are grouped under RAW
+type ModTargets map[string]map[uint64]struct{}
+
+// ReadModTargets lists the WAD entries an installed mod replaces. WAD files are read from their table of
+// contents and unpacked WAD folders are hashed from their file paths
+func ReadModTargets(modDir string) (ModTargets, error) {
+	targets := make(ModTargets)
+	wadDir := filepath.Join(modDir, "WAD")
+	wads, err := os.ReadDir(wadDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, wad := range wads {
+		name := strings.ToLower(wad.Name())
+		wadPath := filepath.Join(wadDir, wad.Name())
+		if wad.IsDir() {
+			if err := hashFolder(wadPath, targets.entries(name)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		hashes, err := readWadHashes(wadPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", wad.Name(), err)
+		}
+		entries := targets.entries(name)
+		for _, hash := range hashes {
+			entries[hash] = struct{}{}
+		}
+	}
+
+	rawDir := filepath.Join(modDir, "RAW")
+	if _, err := os.Stat(rawDir); err == nil {
+		if err := hashFolder(rawDir, targets.entries(rawTarget)); err != nil {
+			return nil, err
+		}
+	}
+	return targets, nil
+}
+
+func (t ModTargets) entries(wad string) map[uint64]struct{} {
+	entries, ok := t[wad]
+	if !ok {
+		entries = make(map[uint64]struct{})
+		t[wad] = entries
+	}
+	return entries
+}
+
+// hashFolder adds the hash of every file of an unpacked folder. Files the unpacker couldn't name are
+// written as their hash in hex
+func hashFolder(dir string, entries map[uint64]struct{}) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = strings.ToLower(filepath.ToSlash(rel))
+		if hash, ok := hexHash(rel); ok {
+			entries[hash] = struct{}{}
+			return nil
+		}
+		entries[xxhash.Sum64String(rel)] = struct{}{}
+		return nil
+	})
+}
+
+// hexHash parses file names like 1a2b3c4d5e6f7a8b.bin
+func hexHash(rel string) (uint64, bool) {
+	if strings.Contains(rel, "/") {
+		return 0, false
+	}
+	name := strings.SplitN(rel, ".", 2)[0]
+	if len(name) != 16 {
+		return 0, false
+	}
+	decoded, err := hex.DecodeString(name)
+	if err != nil {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(decoded), true
+}
+
+// readWadHashes reads the path hashes from the table of contents of a v3 WAD file
+func readWadHashes(path string) ([]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	header := make([]byte, wadHeaderSize)
+	if _, err := io.ReadFull(file, header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedWad, err)
+	}
+	if string(header[:2]) != "RW" || header[2] != 3 {
+		return nil, fmt.Errorf("%w: version %d", ErrUnsupportedWad, header[2])
+	}
+	count := binary.LittleEndian.Uint32(header[268:272])
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if int64(count)*wadEntrySize > info.Size()-wadHeaderSize {
+		return nil, fmt.Errorf("%w: truncated table of contents", ErrUnsupportedWad)
+	}
+
+	toc := make([]byte, int(count)*wadEntrySize)
+	if _, err := io.ReadFull(file, toc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedWad, err)
+	}
+	hashes := make([]uint64, count)
+	for i := range hashes {
+		hashes[i] = binary.LittleEndian.Uint64(toc[i*wadEntrySize:])
+	}
+	return hashes, nil
+}
+
+// DetectConflicts reports the WAD files replaced by more than one of the installed mods, in overlay order
+func DetectConflicts(installedDir string, mods []string) ([]ModConflict, error) {
+	type owner struct {
+		wad  string
+		hash uint64
+	}
+	owners := make(map[owner][]string)
+	var errs []error
+	for _, mod := range mods {
+		targets, err := ReadModTargets(filepath.Join(installedDir, mod))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", mod, err))
+			continue
+		}
+		for wad, entries := range targets {
+			for hash := range entries {
+				key := owner{wad: wad, hash: hash}
+				owners[key] = append(owners[key], mod)
+			}
+		}
+	}
+
+	conflicts := make(map[string]*ModConflict)
+	for key, modNames := range owners {
+		if len(modNames) < 2 {
+			continue
+		}
+		id := key.wad + "|" + strings.Join(modNames, "/")
+		conflict, ok := conflicts[id]
+		if !ok {
+			conflict = &ModConflict{Wad: key.wad, Mods: modNames, Winner: modNames[0]}
+			conflicts[id] = conflict
+		}
+		conflict.Entries++
+	}
+
+	result := make([]ModConflict, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		result = append(result, *conflict)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Wad != result[j].Wad {
+			return result[i].Wad < result[j].Wad
+		}
+		return strings.Join(result[i].Mods, "/") < strings.Join(result[j].Mods, "/")
+	})
+	return result, errors.Join(errs...)
+}
+
+// ConflictResolver orders the mods of the overlay by the user's priority and leaves out the excluded ones
+type ConflictResolver struct {
+	logger     logger.Loggerer
+	dir        string
+	catalog    *CatalogService
+	selections SelectionSource
+	globalMods GlobalModSource
+
+	mutex      sync.Mutex
+	resolution ConflictResolution
+}
+
+func NewConflictResolver(logger logger.Loggerer, dir string, catalog *CatalogService, selections SelectionSource) *ConflictResolver {
+	return &ConflictResolver{
+		logger:     logger,
+		dir:        dir,
+		catalog:    catalog,
+		selections: selections,
+	}
+}
+
+// SetGlobalMods adds the imported global mods to the checked selection, they are part of every overlay
+func (r *ConflictResolver) SetGlobalMods(globalMods GlobalModSource) {
+	r.globalMods = globalMods
+}
+
+// SetPriority sets the mods that win a conflict, the first one wins over the others
+func (r *ConflictResolver) SetPriority(mods []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.resolution.Priority = slices.Clone(mods)
+}
+
+// SetExcluded sets the mods left out of the overlay
+func (r *ConflictResolver) SetExcluded(mods []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.resolution.Excluded = slices.Clone(mods)
+}
+
+// GetResolution returns the priority order and the exclusions
+func (r *ConflictResolver) GetResolution() ConflictResolution {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return ConflictResolution{
+		Priority: slices.Clone(r.resolution.Priority),
+		Excluded: slices.Clone(r.resolution.Excluded),
+	}
+}
+
+// Apply removes the excluded mods and moves the prioritized ones first, the others keep their order
+func (r *ConflictResolver) Apply(mods []string) []string {
+	resolution := r.GetResolution()
+	ordered := make([]string, 0, len(mods))
+	for _, mod := range resolution.Priority {
+		if slices.Contains(mods, mod) && !slices.Contains(resolution.Excluded, mod) && !slices.Contains(ordered, mod) {
+			ordered = append(ordered, mod)
+		}
+	}
+	for _, mod := range mods {
+		if !slices.Contains(resolution.Excluded, mod) && !slices.Contains(ordered, mod) {
+			ordered = append(ordered, mod)
+		}
+	}
+	return ordered
+}
+
+// Check reports the conflicts between the installed mods of the current selection, after the priority
+// and the exclusions are applied
+func (r *ConflictResolver) Check() ([]ModConflict, error) {
+	mods := make([]string, 0)
+	for _, selection := range r.selections.GetAllSelections() {
+		modName := selection.CustomModID
+		if modName == "" {
+			resolved, err := r.catalog.Resolve(selection.ChampionID, selection.SkinID, selection.ChromaID)
+			if err != nil {
+				continue
+			}
+			modName = resolved.ModName()
+		}
+		if _, err := os.Stat(filepath.Join(r.dir, "installed", modName)); err == nil {
+			mods = append(mods, modName)
+		}
+	}
+	sort.Strings(mods)
+	if r.globalMods != nil {
+		mods = append(mods, r.globalMods.GlobalMods()...)
+	}
+	return DetectConflicts(filepath.Join(r.dir, "installed"), r.Apply(mods))
+}
+
+// Resolve applies the priority and the exclusions to the mods of an overlay and logs the conflicts left
+func (r *ConflictResolver) Resolve(mods []string) ([]string, []ModConflict) {
+	ordered := r.Apply(mods)
+	conflicts, err := DetectConflicts(filepath.Join(r.dir, "installed"), ordered)
+	if err != nil {
+		r.logger.Error("Failed to inspect mods for conflicts", zap.Error(err))
+	}
+	for _, conflict := range conflicts {
+		r.logger.Info("Mods replace the same WAD entries",
+			zap.String("wad", conflict.Wad),
+			zap.Strings("mods", conflict.Mods),
+			zap.Int("entries", conflict.Entries),
+			zap.String("winner", conflict.Winner))
+	}
+	return ordered, conflicts
+}
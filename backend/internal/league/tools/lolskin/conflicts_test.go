@@ -0,0 +1,176 @@
+package lolskin
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conflictFixtures holds installed mods, 266001 and custom-aatrox-recolor replace the same Aatrox texture
+// and custom-aatrox-recolor and custom-ui-pack replace the same RAW file
+const conflictFixtures = "testdata/conflicts"
+
+// writeWad writes a v3 WAD file whose table of contents lists the given path hashes
+func writeWad(t *testing.T, path string, hashes ...uint64) {
+	data := make([]byte, wadHeaderSize+len(hashes)*wadEntrySize)
+	copy(data, "RW")
+	data[2] = 3
+	data[3] = 4
+	binary.LittleEndian.PutUint32(data[268:], uint32(len(hashes)))
+	for i, hash := range hashes {
+		binary.LittleEndian.PutUint64(data[wadHeaderSize+i*wadEntrySize:], hash)
+	}
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+func TestReadModTargets(t *testing.T) {
+	t.Run("Unpacked WAD folders are hashed from their paths", func(t *testing.T) {
+		targets, err := ReadModTargets(filepath.Join(conflictFixtures, "installed", "custom-aatrox-recolor"))
+		require.NoError(t, err)
+		assert.Equal(t, ModTargets{
+			"aatrox.wad.client": {
+				xxhash.Sum64String("assets/characters/aatrox/skins/skin01/aatrox_skin01_tx_cm.dds"): {},
+				0x0123456789abcdef: {},
+			},
+			rawTarget: {xxhash.Sum64String("data/menu/fontconfig_en_us.txt"): {}},
+		}, targets)
+	})
+
+	t.Run("WAD files are read from their table of contents", func(t *testing.T) {
+		modDir := t.TempDir()
+		skin := xxhash.Sum64String("data/characters/aatrox/skins/skin0.bin")
+		writeWad(t, filepath.Join(modDir, "WAD", "Aatrox.wad.client"), skin, 42)
+
+		targets, err := ReadModTargets(modDir)
+		require.NoError(t, err)
+		assert.Equal(t, ModTargets{"aatrox.wad.client": {skin: {}, 42: {}}}, targets)
+	})
+
+	t.Run("Unsupported WAD files are rejected", func(t *testing.T) {
+		modDir := t.TempDir()
+		path := filepath.Join(modDir, "WAD", "Aatrox.wad.client")
+		writeWad(t, path, 1)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		data[2] = 2
+		require.NoError(t, os.WriteFile(path, data, 0644))
+		_, err = ReadModTargets(modDir)
+		assert.ErrorIs(t, err, ErrUnsupportedWad)
+
+		data[2] = 3
+		binary.LittleEndian.PutUint32(data[268:], 1000)
+		require.NoError(t, os.WriteFile(path, data, 0644))
+		_, err = ReadModTargets(modDir)
+		assert.ErrorIs(t, err, ErrUnsupportedWad)
+	})
+}
+
+func TestDetectConflicts(t *testing.T) {
+	installed := filepath.Join(conflictFixtures, "installed")
+
+	t.Run("Overlapping entries are reported with the mods involved", func(t *testing.T) {
+		conflicts, err := DetectConflicts(installed, []string{"266001", "custom-aatrox-recolor", "99002", "custom-ui-pack"})
+		require.NoError(t, err)
+		assert.Equal(t, []ModConflict{
+			{Wad: rawTarget, Mods: []string{"custom-aatrox-recolor", "custom-ui-pack"}, Entries: 1, Winner: "custom-aatrox-recolor"},
+			{Wad: "aatrox.wad.client", Mods: []string{"266001", "custom-aatrox-recolor"}, Entries: 1, Winner: "266001"},
+		}, conflicts)
+	})
+
+	t.Run("Mods without overlapping entries don't conflict", func(t *testing.T) {
+		conflicts, err := DetectConflicts(installed, []string{"266001", "99002", "custom-ui-pack"})
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+	})
+
+	t.Run("Packed and unpacked WADs conflict on the same entries", func(t *testing.T) {
+		dir := t.TempDir()
+		writeWad(t, filepath.Join(dir, "packed", "WAD", "Aatrox.wad.client"), 0x0123456789abcdef)
+		writeWad(t, filepath.Join(dir, "other", "WAD", "Lux.wad.client"), 0x0123456789abcdef)
+		require.NoError(t, os.CopyFS(filepath.Join(dir, "custom-aatrox-recolor"), os.DirFS(filepath.Join(installed, "custom-aatrox-recolor"))))
+
+		conflicts, err := DetectConflicts(dir, []string{"packed", "other", "custom-aatrox-recolor"})
+		require.NoError(t, err)
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, []string{"packed", "custom-aatrox-recolor"}, conflicts[0].Mods)
+	})
+
+	t.Run("Unreadable mods are reported and skipped", func(t *testing.T) {
+		conflicts, err := DetectConflicts(installed, []string{"266001", "custom-aatrox-recolor", "missing"})
+		assert.NoError(t, err, "a missing mod has nothing to conflict with")
+		assert.Len(t, conflicts, 1)
+
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "broken", "WAD"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "broken", "WAD", "Aatrox.wad.client"), []byte("RW"), 0644))
+		_, err = DetectConflicts(dir, []string{"broken"})
+		assert.ErrorIs(t, err, ErrUnsupportedWad)
+	})
+}
+
+func TestConflictResolver(t *testing.T) {
+	selections := []ChampionSkin{
+		{ChampionID: 266, SkinID: 266001},
+		{ChampionID: 266, CustomModID: "custom-aatrox-recolor"},
+		{ChampionID: 99, SkinID: 2},
+		{ChampionID: 1, CustomModID: "custom-ui-pack"},
+		{ChampionID: 99, SkinID: 7, ChromaID: int32Ptr(9)},
+	}
+	newLogger := logger.New("TestConflicts", &config.Config{LogLevel: "error"})
+	loaded, err := LoadCatalog(fstest.MapFS{CatalogPath: {Data: []byte(testCatalog)}}, CatalogPath)
+	require.NoError(t, err)
+	catalog := NewCatalogService(newLogger, loaded)
+
+	t.Run("Priority moves mods first and exclusions remove them", func(t *testing.T) {
+		mockSelections := NewMockSelectionSource(t)
+		mockSelections.EXPECT().GetAllSelections().Return(selections).Maybe()
+		resolver := NewConflictResolver(newLogger, conflictFixtures, catalog, mockSelections)
+		resolver.SetPriority([]string{"custom-ui-pack", "unknown", "custom-aatrox-recolor"})
+		resolver.SetExcluded([]string{"99002"})
+
+		assert.Equal(t, []string{"custom-ui-pack", "custom-aatrox-recolor", "266001"},
+			resolver.Apply([]string{"266001", "99002", "custom-aatrox-recolor", "custom-ui-pack"}))
+		assert.Equal(t, ConflictResolution{
+			Priority: []string{"custom-ui-pack", "unknown", "custom-aatrox-recolor"},
+			Excluded: []string{"99002"},
+		}, resolver.GetResolution())
+	})
+
+	t.Run("Current selection is checked with the resolution applied", func(t *testing.T) {
+		mockSelections := NewMockSelectionSource(t)
+		mockSelections.EXPECT().GetAllSelections().Return(selections).Maybe()
+		resolver := NewConflictResolver(newLogger, conflictFixtures, catalog, mockSelections)
+		conflicts, err := resolver.Check()
+		require.NoError(t, err)
+		require.Len(t, conflicts, 2, "mods that aren't installed are left out")
+
+		resolver.SetPriority([]string{"custom-ui-pack"})
+		resolver.SetExcluded([]string{"266001"})
+		conflicts, err = resolver.Check()
+		require.NoError(t, err)
+		assert.Equal(t, []ModConflict{
+			{Wad: rawTarget, Mods: []string{"custom-ui-pack", "custom-aatrox-recolor"}, Entries: 1, Winner: "custom-ui-pack"},
+		}, conflicts)
+	})
+
+	t.Run("Resolve returns the overlay order", func(t *testing.T) {
+		mockSelections := NewMockSelectionSource(t)
+		mockSelections.EXPECT().GetAllSelections().Return(selections).Maybe()
+		resolver := NewConflictResolver(newLogger, conflictFixtures, catalog, mockSelections)
+		resolver.SetExcluded([]string{"custom-ui-pack"})
+		mods, conflicts := resolver.Resolve([]string{"custom-ui-pack", "custom-aatrox-recolor", "266001"})
+		assert.Equal(t, []string{"custom-aatrox-recolor", "266001"}, mods)
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, "custom-aatrox-recolor", conflicts[0].Winner)
+	})
+}
@@ -0,0 +1,432 @@
+package lolskin
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// CustomModPrefix starts the mod name of every imported mod, they can't be downloaded again so the cache
+// never evicts them
+const CustomModPrefix = "custom-"
+
+const customModsFile = "custom_mods.json"
+
+// Limits of an imported mod package
+const (
+	maxModEntries   = 10000
+	maxModEntrySize = 1 << 30
+	maxModSize      = 2 << 30
+	maxModInfoSize  = 64 << 10
+)
+
+var (
+	ErrInvalidMod        = errors.New("invalid mod package")
+	ErrCustomModNotFound = errors.New("custom mod not found")
+)
+
+// ModInfo is the META/info.json of a fantome package
+type ModInfo struct {
+	Name        string `json:"Name"`
+	Author      string `json:"Author"`
+	Version     string `json:"Version"`
+	Description string `json:"Description"`
+}
+
+// CustomMod is a mod imported by the user, it's selected with its ID like a catalog skin. Global mods like UI,
+// map or RAW mods aren't tied to a champion, they are added to every overlay
+type CustomMod struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Author       string    `json:"author"`
+	Version      string    `json:"version"`
+	Description  string    `json:"description"`
+	ChampionID   int32     `json:"championId"`
+	ChampionName string    `json:"championName"`
+	Global       bool      `json:"global,omitempty"`
+	Size         int64     `json:"size"`
+	ImportedAt   time.Time `json:"importedAt"`
+}
+
+// GlobalModSource defines the imported mods added to every overlay
+type GlobalModSource interface {
+	GlobalMods() []string
+}
+
+// CustomMods imports .fantome and .zip mod packages into the installed folder and keeps their metadata
+type CustomMods struct {
+	logger  logger.Loggerer
+	dir     string
+	catalog *CatalogService
+	now     func() time.Time
+
+	mutex sync.Mutex
+	mods  map[string]*CustomMod
+}
+
+func NewCustomMods(logger logger.Loggerer, dir string, catalog *CatalogService) *CustomMods {
+	m := &CustomMods{
+		logger:  logger,
+		dir:     dir,
+		catalog: catalog,
+		now:     time.Now,
+		mods:    make(map[string]*CustomMod),
+	}
+	data, err := os.ReadFile(filepath.Join(dir, customModsFile))
+	if err == nil {
+		if err := json.Unmarshal(data, &m.mods); err != nil {
+			logger.Error("Failed to parse custom mods", zap.Error(err))
+			m.mods = make(map[string]*CustomMod)
+		}
+	}
+	// Mods deleted from the installed folder can't be selected anymore
+	for id := range m.mods {
+		if _, err := os.Stat(filepath.Join(dir, "installed", id)); err != nil {
+			delete(m.mods, id)
+		}
+	}
+	return m
+}
+
+// Import validates a mod package and installs it for a champion. When championID is 0 the champion is
+// detected from the WAD files of the package
+func (m *CustomMods) Import(packagePath string, championID int32) (CustomMod, error) {
+	return m.importPackage(packagePath, championID, false)
+}
+
+// ImportGlobal validates a mod package and installs it as a global mod, it's injected with every champion
+func (m *CustomMods) ImportGlobal(packagePath string) (CustomMod, error) {
+	return m.importPackage(packagePath, 0, true)
+}
+
+func (m *CustomMods) importPackage(packagePath string, championID int32, global bool) (CustomMod, error) {
+	extension := strings.ToLower(filepath.Ext(packagePath))
+	if extension != ".fantome" && extension != ".zip" {
+		return CustomMod{}, fmt.Errorf("%w: only .fantome and .zip packages are supported", ErrInvalidMod)
+	}
+	reader, err := zip.OpenReader(packagePath)
+	if err != nil {
+		return CustomMod{}, fmt.Errorf("%w: %v", ErrInvalidMod, err)
+	}
+	defer reader.Close()
+
+	if err := validateModArchive(&reader.Reader); err != nil {
+		return CustomMod{}, err
+	}
+	info, err := readModInfo(&reader.Reader)
+	if err != nil {
+		return CustomMod{}, err
+	}
+	champion := &Champion{}
+	if !global {
+		champion, err = m.targetChampion(&reader.Reader, championID)
+		if err != nil {
+			return CustomMod{}, err
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	id := m.uniqueIDLocked(info.Name)
+	installedPath := filepath.Join(m.dir, "installed", id)
+	extractPath := installedPath + extractingSuffix
+	os.RemoveAll(extractPath)
+	if err := extractModArchive(&reader.Reader, extractPath); err != nil {
+		os.RemoveAll(extractPath)
+		return CustomMod{}, err
+	}
+	if err := os.Rename(extractPath, installedPath); err != nil {
+		os.RemoveAll(extractPath)
+		return CustomMod{}, fmt.Errorf("failed to move imported mod: %w", err)
+	}
+
+	mod := &CustomMod{
+		ID:           id,
+		Name:         info.Name,
+		Author:       info.Author,
+		Version:      info.Version,
+		Description:  info.Description,
+		ChampionID:   int32(champion.ChampionKey),
+		ChampionName: champion.ChampionName,
+		Global:       global,
+		Size:         dirSize(installedPath),
+		ImportedAt:   m.now(),
+	}
+	m.mods[id] = mod
+	m.saveLocked()
+	m.logger.Info("Custom mod imported",
+		zap.String("id", id),
+		zap.String("name", mod.Name),
+		zap.String("champion", mod.ChampionName),
+		zap.Bool("global", global))
+	return *mod, nil
+}
+
+// List returns the imported mods sorted by name
+func (m *CustomMods) List() []CustomMod {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	mods := make([]CustomMod, 0, len(m.mods))
+	for _, mod := range m.mods {
+		mods = append(mods, *mod)
+	}
+	sort.Slice(mods, func(i, j int) bool { return mods[i].Name < mods[j].Name })
+	return mods
+}
+
+// GlobalMods returns the names of the global mods in the order they are added to the overlays
+func (m *CustomMods) GlobalMods() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	mods := make([]string, 0)
+	for id, mod := range m.mods {
+		if mod.Global {
+			mods = append(mods, id)
+		}
+	}
+	sort.Strings(mods)
+	return mods
+}
+
+// Get returns an imported mod
+func (m *CustomMods) Get(id string) (CustomMod, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	mod, ok := m.mods[id]
+	if !ok {
+		return CustomMod{}, fmt.Errorf("%w: %s", ErrCustomModNotFound, id)
+	}
+	return *mod, nil
+}
+
+// Remove deletes an imported mod
+func (m *CustomMods) Remove(id string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, ok := m.mods[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrCustomModNotFound, id)
+	}
+	if err := os.RemoveAll(filepath.Join(m.dir, "installed", id)); err != nil {
+		return fmt.Errorf("failed to delete custom mod: %w", err)
+	}
+	delete(m.mods, id)
+	m.saveLocked()
+	return nil
+}
+
+// targetChampion returns the given champion, or the champion the WAD files of the package are named after
+func (m *CustomMods) targetChampion(reader *zip.Reader, championID int32) (*Champion, error) {
+	if championID != 0 {
+		return m.catalog.GetChampion(int(championID))
+	}
+	for _, file := range reader.File {
+		name := strings.ReplaceAll(file.Name, "\\", "/")
+		if !strings.HasPrefix(name, "WAD/") {
+			continue
+		}
+		wad := strings.SplitN(strings.TrimPrefix(name, "WAD/"), "/", 2)[0]
+		alias := strings.SplitN(wad, ".", 2)[0]
+		if champion, err := m.catalog.GetChampionByAlias(alias); err == nil {
+			return champion, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: the target champion couldn't be detected, select it when importing", ErrInvalidMod)
+}
+
+// uniqueIDLocked returns a mod name for the mod that isn't used yet, it can't contain the / the
+// mod-tools use to separate mods
+func (m *CustomMods) uniqueIDLocked(name string) string {
+	var slug strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			slug.WriteRune(r)
+		case slug.Len() > 0 && !strings.HasSuffix(slug.String(), "-"):
+			slug.WriteRune('-')
+		}
+	}
+	base := strings.Trim(slug.String(), "-")
+	if len(base) > 40 {
+		base = strings.Trim(base[:40], "-")
+	}
+	if base == "" {
+		base = "mod"
+	}
+	base = CustomModPrefix + base
+
+	id := base
+	for i := 2; ; i++ {
+		_, taken := m.mods[id]
+		if _, err := os.Stat(filepath.Join(m.dir, "installed", id)); !taken && os.IsNotExist(err) {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+func (m *CustomMods) saveLocked() {
+	data, err := json.MarshalIndent(m.mods, "", "  ")
+	if err != nil {
+		m.logger.Error("Failed to encode custom mods", zap.Error(err))
+		return
+	}
+	if err := os.WriteFile(filepath.Join(m.dir, customModsFile), data, 0644); err != nil {
+		m.logger.Error("Failed to write custom mods", zap.Error(err))
+	}
+}
+
+// modEntryPath returns the cleaned path of an archive entry, false when it would escape the destination
+func modEntryPath(name string) (string, bool) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, ":") {
+		return "", false
+	}
+	cleaned := path.Clean(name)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", false
+	}
+	return cleaned, true
+}
+
+// validateModArchive rejects packages with entries escaping the destination, links, oversized entries or
+// without the META/info.json and WAD or RAW content of a fantome
+func validateModArchive(reader *zip.Reader) error {
+	if err := validateArchiveEntries(reader); err != nil {
+		return err
+	}
+	hasInfo, hasContent := false, false
+	for _, file := range reader.File {
+		name, _ := modEntryPath(file.Name)
+		switch {
+		case strings.EqualFold(name, "META/info.json"):
+			hasInfo = true
+		case strings.HasPrefix(name, "WAD/"), strings.HasPrefix(name, "RAW/"):
+			hasContent = true
+		}
+	}
+	if !hasInfo {
+		return fmt.Errorf("%w: META/info.json is missing", ErrInvalidMod)
+	}
+	if !hasContent {
+		return fmt.Errorf("%w: the package has no WAD or RAW files", ErrInvalidMod)
+	}
+	return nil
+}
+
+// validateArchiveEntries rejects archives with entries escaping the destination, links or oversized entries
+func validateArchiveEntries(reader *zip.Reader) error {
+	if len(reader.File) > maxModEntries {
+		return fmt.Errorf("%w: more than %d entries", ErrInvalidMod, maxModEntries)
+	}
+	var total uint64
+	for _, file := range reader.File {
+		if _, ok := modEntryPath(file.Name); !ok {
+			return fmt.Errorf("%w: entry %q escapes the mod folder", ErrInvalidMod, file.Name)
+		}
+		if file.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("%w: entry %q is a link", ErrInvalidMod, file.Name)
+		}
+		if file.UncompressedSize64 > maxModEntrySize {
+			return fmt.Errorf("%w: entry %q is too large", ErrInvalidMod, file.Name)
+		}
+		total += file.UncompressedSize64
+		if total > maxModSize {
+			return fmt.Errorf("%w: the package is larger than %d bytes", ErrInvalidMod, maxModSize)
+		}
+	}
+	return nil
+}
+
+// readModInfo parses META/info.json, some tools write it with a byte order mark
+func readModInfo(reader *zip.Reader) (ModInfo, error) {
+	var info ModInfo
+	for _, file := range reader.File {
+		name, _ := modEntryPath(file.Name)
+		if !strings.EqualFold(name, "META/info.json") {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return info, fmt.Errorf("%w: %v", ErrInvalidMod, err)
+		}
+		data, err := io.ReadAll(io.LimitReader(rc, maxModInfoSize+1))
+		rc.Close()
+		if err != nil {
+			return info, fmt.Errorf("%w: %v", ErrInvalidMod, err)
+		}
+		if len(data) > maxModInfoSize {
+			return info, fmt.Errorf("%w: META/info.json is too large", ErrInvalidMod)
+		}
+		if err := json.Unmarshal(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), &info); err != nil {
+			return info, fmt.Errorf("%w: META/info.json: %v", ErrInvalidMod, err)
+		}
+		info.Name = strings.TrimSpace(info.Name)
+		if info.Name == "" {
+			return info, fmt.Errorf("%w: META/info.json has no name", ErrInvalidMod)
+		}
+		return info, nil
+	}
+	return info, fmt.Errorf("%w: META/info.json is missing", ErrInvalidMod)
+}
+
+// extractModArchive extracts a validated package, entries are never written past their declared size
+func extractModArchive(reader *zip.Reader, destPath string) error {
+	if err := os.MkdirAll(destPath, 0755); err != nil {
+		return err
+	}
+	for _, file := range reader.File {
+		name, ok := modEntryPath(file.Name)
+		if !ok {
+			return fmt.Errorf("%w: entry %q escapes the mod folder", ErrInvalidMod, file.Name)
+		}
+		target := filepath.Join(destPath, filepath.FromSlash(name))
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := extractModEntry(file, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractModEntry(file *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	written, err := io.Copy(out, io.LimitReader(rc, int64(file.UncompressedSize64)+1))
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", file.Name, err)
+	}
+	if uint64(written) > file.UncompressedSize64 {
+		return fmt.Errorf("%w: entry %q is larger than declared", ErrInvalidMod, file.Name)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package lolskin
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testModInfo = `{"Name": "Star Guardian Lux Remix", "Author": "modder", "Version": "1.2", "Description": "Recolor"}`
+
+// writeModPackage writes a mod package with the given entries
+func writeModPackage(t *testing.T, name string, entries map[string]string) string {
+	packagePath := filepath.Join(t.TempDir(), name)
+	file, err := os.Create(packagePath)
+	require.NoError(t, err)
+	writer := zip.NewWriter(file)
+	for entryName, content := range entries {
+		entry, err := writer.Create(entryName)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, file.Close())
+	return packagePath
+}
+
+func TestCustomMods(t *testing.T) {
+	newLogger := logger.New("TestCustomMods", &config.Config{LogLevel: "error"})
+	loaded, err := LoadCatalog(fstest.MapFS{CatalogPath: {Data: []byte(testCatalog)}}, CatalogPath)
+	require.NoError(t, err)
+	catalog := NewCatalogService(newLogger, loaded)
+
+	t.Run("Fantome is imported with its metadata and detected champion", func(t *testing.T) {
+		dir := t.TempDir()
+		mods := NewCustomMods(newLogger, dir, catalog)
+		// The import time is compared after a reload, it has no monotonic reading or local zone
+		mods.now = func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) }
+		packagePath := writeModPackage(t, "remix.fantome", map[string]string{
+			"META/info.json":                    "\xef\xbb\xbf" + testModInfo,
+			"WAD/Lux.wad.client/data/skin0.bin": "skin",
+		})
+
+		mod, err := mods.Import(packagePath, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "custom-star-guardian-lux-remix", mod.ID)
+		assert.Equal(t, "Star Guardian Lux Remix", mod.Name)
+		assert.Equal(t, "modder", mod.Author)
+		assert.Equal(t, "1.2", mod.Version)
+		assert.Equal(t, int32(99), mod.ChampionID)
+		assert.Equal(t, "Lux", mod.ChampionName)
+		assert.Equal(t, int64(len(testModInfo)+3+len("skin")), mod.Size)
+		assert.FileExists(t, filepath.Join(dir, "installed", mod.ID, "WAD", "Lux.wad.client", "data", "skin0.bin"))
+
+		reloaded := NewCustomMods(newLogger, dir, catalog)
+		assert.Equal(t, []CustomMod{mod}, reloaded.List())
+	})
+
+	t.Run("Imported mods get unique names", func(t *testing.T) {
+		mods := NewCustomMods(newLogger, t.TempDir(), catalog)
+		packagePath := writeModPackage(t, "remix.zip", map[string]string{
+			"META/info.json": testModInfo,
+			"RAW/data.bin":   "raw",
+		})
+
+		first, err := mods.Import(packagePath, 266)
+		require.NoError(t, err)
+		second, err := mods.Import(packagePath, 266)
+		require.NoError(t, err)
+		assert.Equal(t, "Aatrox", first.ChampionName)
+		assert.Equal(t, first.ID+"-2", second.ID)
+		assert.Len(t, mods.List(), 2)
+	})
+
+	t.Run("Global mods aren't tied to a champion", func(t *testing.T) {
+		mods := NewCustomMods(newLogger, t.TempDir(), catalog)
+		packagePath := writeModPackage(t, "map.fantome", map[string]string{
+			"META/info.json":                  `{"Name": "Winter Rift"}`,
+			"WAD/Map11.wad.client/data/x.bin": "map",
+		})
+
+		_, err := mods.Import(packagePath, 0)
+		assert.ErrorIs(t, err, ErrInvalidMod, "a champion mod needs a champion")
+		mod, err := mods.ImportGlobal(packagePath)
+		require.NoError(t, err)
+		assert.True(t, mod.Global)
+		assert.Zero(t, mod.ChampionID)
+		assert.Equal(t, []string{mod.ID}, mods.GlobalMods())
+	})
+
+	t.Run("Invalid packages are rejected", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			file    string
+			entries map[string]string
+		}{
+			{"Unsupported extension", "mod.rar", map[string]string{"META/info.json": testModInfo, "WAD/Lux.wad.client": "x"}},
+			{"Missing info", "mod.fantome", map[string]string{"WAD/Lux.wad.client": "x"}},
+			{"Invalid info", "mod.fantome", map[string]string{"META/info.json": "{", "WAD/Lux.wad.client": "x"}},
+			{"Info without name", "mod.fantome", map[string]string{"META/info.json": `{"Author": "modder"}`, "WAD/Lux.wad.client": "x"}},
+			{"No content", "mod.fantome", map[string]string{"META/info.json": testModInfo}},
+			{"Path traversal", "mod.fantome", map[string]string{"META/info.json": testModInfo, "WAD/../../evil.dll": "x"}},
+			{"Windows path traversal", "mod.fantome", map[string]string{"META/info.json": testModInfo, "WAD\\..\\..\\evil.dll": "x"}},
+			{"Absolute path", "mod.fantome", map[string]string{"META/info.json": testModInfo, "/WAD/Lux.wad.client": "x"}},
+			{"Unknown champion", "mod.fantome", map[string]string{"META/info.json": testModInfo, "WAD/Map11.wad.client": "x"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				dir := t.TempDir()
+				mods := NewCustomMods(newLogger, dir, catalog)
+				_, err := mods.Import(writeModPackage(t, tt.file, tt.entries), 0)
+				assert.ErrorIs(t, err, ErrInvalidMod)
+				assert.Empty(t, mods.List())
+				assert.NoDirExists(t, filepath.Join(dir, "installed"))
+			})
+		}
+	})
+
+	t.Run("Oversized entries are rejected", func(t *testing.T) {
+		packagePath := filepath.Join(t.TempDir(), "bomb.fantome")
+		file, err := os.Create(packagePath)
+		require.NoError(t, err)
+		writer := zip.NewWriter(file)
+		entry, err := writer.Create("META/info.json")
+		require.NoError(t, err)
+		entry.Write([]byte(testModInfo))
+		// The declared size is checked before anything is extracted
+		_, err = writer.CreateRaw(&zip.FileHeader{Name: "WAD/Lux.wad.client", Method: zip.Store, UncompressedSize64: maxModEntrySize + 1})
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		require.NoError(t, file.Close())
+
+		mods := NewCustomMods(newLogger, t.TempDir(), catalog)
+		_, err = mods.Import(packagePath, 0)
+		assert.ErrorIs(t, err, ErrInvalidMod)
+		assert.Contains(t, err.Error(), "too large")
+	})
+
+	t.Run("Removed mods are deleted", func(t *testing.T) {
+		dir := t.TempDir()
+		mods := NewCustomMods(newLogger, dir, catalog)
+		mod, err := mods.Import(writeModPackage(t, "remix.fantome", map[string]string{
+			"META/info.json":     testModInfo,
+			"WAD/Lux.wad.client": "x",
+		}), 0)
+		require.NoError(t, err)
+
+		require.NoError(t, mods.Remove(mod.ID))
+		assert.NoDirExists(t, filepath.Join(dir, "installed", mod.ID))
+		_, err = mods.Get(mod.ID)
+		assert.ErrorIs(t, err, ErrCustomModNotFound)
+		assert.ErrorIs(t, mods.Remove(mod.ID), ErrCustomModNotFound)
+	})
+
+	t.Run("Imported mods are never evicted", func(t *testing.T) {
+		dir := t.TempDir()
+		installMod(t, dir, "custom-remix", 100)
+		installMod(t, dir, "99002", 100)
+		mockSelections := NewMockSelectionSource(t)
+		mockSelections.EXPECT().GetAllSelections().Return(nil).Maybe()
+		cache := NewCache(newLogger, dir, catalog, mockSelections)
+
+		require.NoError(t, cache.SetQuota(1))
+		assert.DirExists(t, filepath.Join(dir, "installed", "custom-remix"))
+		assert.NoDirExists(t, filepath.Join(dir, "installed", "99002"))
+
+		skipped, err := cache.Purge([]string{"custom-remix"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"custom-remix"}, skipped)
+	})
+}
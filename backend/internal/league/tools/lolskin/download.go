@@ -0,0 +1,372 @@
+package lolskin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// EventDownloadProgress carries a DownloadProgress for every download update
+const EventDownloadProgress = "lolskin:download:progress"
+
+// Download statuses
+const (
+	DownloadQueued      = "queued"
+	DownloadDownloading = "downloading"
+	DownloadCompleted   = "completed"
+	DownloadFailed      = "failed"
+)
+
+// partSuffix marks the file a download is written to until it's verified
+const partSuffix = ".part"
+
+// etagSuffix marks the file holding the ETag of the response a part was written from
+const etagSuffix = ".etag"
+
+var (
+	ErrChecksumMismatch = errors.New("downloaded file checksum mismatch")
+	// ErrFileNotFound is a file missing from a mirror, it says nothing about the health of the mirror
+	ErrFileNotFound = errors.New("file not found")
+)
+
+// AppEmitter defines the interface for emitting events
+type AppEmitter interface {
+	EmitEvent(name string, data ...any)
+}
+
+// MirrorReporter records the outcome of every download attempt from a source
+type MirrorReporter interface {
+	ReportMirror(source string, err error)
+}
+
+// Mirror is a location a file can be downloaded from, Source names the SkinSource it comes from
+type Mirror struct {
+	Source string `json:"source"`
+	URL    string `json:"url"`
+}
+
+// DownloadRequest is a file to download, Sha256 is verified before the file is moved to its destination. A
+// request without one is reported as unverified and its part is only resumed when the server validates it with
+// an ETag. Mirrors are tried in order until one succeeds, URL is used when there are none
+type DownloadRequest struct {
+	ID          string
+	URL         string
+	Mirrors     []Mirror
+	Destination string
+	Sha256      string
+}
+
+func (r DownloadRequest) mirrors() []Mirror {
+	if len(r.Mirrors) > 0 {
+		return r.Mirrors
+	}
+	return []Mirror{{URL: r.URL}}
+}
+
+// DownloadProgress is the state of a download sent to the frontend
+type DownloadProgress struct {
+	ID         string `json:"id"`
+	Status     string `json:"status"`
+	Downloaded int64  `json:"downloaded"`
+	Total      int64  `json:"total"`
+	// Verified is set on completion when the file matched the checksum of the request
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+}
+
+// DownloadResult is the outcome of a DownloadRequest
+type DownloadResult struct {
+	Request DownloadRequest
+	Err     error
+}
+
+// DownloadManager downloads files with a bounded worker pool. Files are written next to their destination
+// with a .part suffix, resumed with a range request when the part is left from a failed download and only
+// renamed to the destination once verified. A part is resumed only when it can be verified, by the checksum
+// of the request or by the ETag of the response it was written from
+type DownloadManager struct {
+	logger   logger.Loggerer
+	client   *http.Client
+	workers  int
+	interval time.Duration
+
+	mutex    sync.Mutex
+	app      AppEmitter
+	reporter MirrorReporter
+}
+
+func NewDownloadManager(logger logger.Loggerer, workers int) *DownloadManager {
+	if workers < 1 {
+		workers = 1
+	}
+	// Local skin sources are downloaded from file URLs
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.RegisterProtocol("file", fileTransport{})
+	return &DownloadManager{
+		logger:   logger,
+		client:   &http.Client{Transport: transport},
+		workers:  workers,
+		interval: 250 * time.Millisecond,
+	}
+}
+
+func (m *DownloadManager) SetApp(app AppEmitter) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.app = app
+}
+
+// SetReporter sets where the outcome of the downloads from every source is recorded
+func (m *DownloadManager) SetReporter(reporter MirrorReporter) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.reporter = reporter
+}
+
+// Download runs the requests in parallel and returns their results in the same order
+func (m *DownloadManager) Download(ctx context.Context, requests []DownloadRequest) []DownloadResult {
+	results := make([]DownloadResult, len(requests))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for _, request := range requests {
+		m.emit(DownloadProgress{ID: request.ID, Status: DownloadQueued})
+	}
+	for worker := 0; worker < min(m.workers, len(requests)); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = DownloadResult{Request: requests[i], Err: m.download(ctx, requests[i])}
+			}
+		}()
+	}
+	for i := range requests {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func (m *DownloadManager) download(ctx context.Context, request DownloadRequest) error {
+	var errs []error
+	for i, mirror := range request.mirrors() {
+		if i > 0 && request.Sha256 == "" {
+			// Without a checksum a part can't be trusted to match the file of another mirror
+			removePart(request.Destination + partSuffix)
+		}
+		err := m.fetch(ctx, request, mirror.URL)
+		if ctx.Err() != nil {
+			// A cancelled download says nothing about the mirror
+			errs = append(errs, err)
+			break
+		}
+		if !errors.Is(err, ErrFileNotFound) {
+			m.report(mirror, err)
+		}
+		if err == nil {
+			return nil
+		}
+		m.logger.Error("Failed to download file",
+			zap.String("id", request.ID),
+			zap.String("source", mirror.Source),
+			zap.String("url", mirror.URL),
+			zap.Error(err))
+		errs = append(errs, err)
+	}
+	err := errors.Join(errs...)
+	m.emit(DownloadProgress{ID: request.ID, Status: DownloadFailed, Error: err.Error()})
+	return err
+}
+
+func (m *DownloadManager) report(mirror Mirror, err error) {
+	m.mutex.Lock()
+	reporter := m.reporter
+	m.mutex.Unlock()
+	if reporter != nil && mirror.Source != "" {
+		reporter.ReportMirror(mirror.Source, err)
+	}
+}
+
+func (m *DownloadManager) fetch(ctx context.Context, request DownloadRequest, url string) error {
+	if err := os.MkdirAll(filepath.Dir(request.Destination), 0755); err != nil {
+		return fmt.Errorf("failed to create download directory: %w", err)
+	}
+	partPath := request.Destination + partSuffix
+
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+	etag := readETag(partPath)
+	if offset > 0 && request.Sha256 == "" && etag == "" {
+		// Nothing can tell whether the part belongs to the current file, it's downloaded again
+		removePart(partPath)
+		offset = 0
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		if etag != "" {
+			// The server sends the whole file if it changed since the part was written
+			req.Header.Set("If-Range", etag)
+		}
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_WRONLY | os.O_CREATE
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		flags |= os.O_APPEND
+		m.logger.Info("Resuming download", zap.String("id", request.ID), zap.Int64("offset", offset))
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0 && request.Sha256 != "":
+		// The part is already complete, it only has to be verified
+		return m.finish(request, partPath, offset)
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// Without a checksum a complete part can't be told from a stale one, the download starts over
+		resp.Body.Close()
+		removePart(partPath)
+		return m.fetch(ctx, request, url)
+	case resp.StatusCode == http.StatusOK:
+		// The server ignored the range or the file changed, the download starts over
+		flags |= os.O_TRUNC
+		offset = 0
+		writeETag(partPath, resp.Header.Get("ETag"))
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return fmt.Errorf("%w: %s", ErrFileNotFound, resp.Status)
+	default:
+		return fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+	out, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	progress := &progressWriter{
+		manager:    m,
+		progress:   DownloadProgress{ID: request.ID, Status: DownloadDownloading, Downloaded: offset, Total: total},
+		lastReport: time.Now(),
+	}
+	_, err = io.Copy(out, io.TeeReader(resp.Body, progress))
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// The part is kept so the next attempt resumes it
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return m.finish(request, partPath, progress.progress.Downloaded)
+}
+
+// finish verifies the part and moves it to the destination
+func (m *DownloadManager) finish(request DownloadRequest, partPath string, size int64) error {
+	if request.Sha256 != "" {
+		sum, err := fileSha256(partPath)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(sum, request.Sha256) {
+			// A corrupted part can't be resumed
+			removePart(partPath)
+			return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, request.Sha256, sum)
+		}
+	}
+	if err := os.Rename(partPath, request.Destination); err != nil {
+		return fmt.Errorf("failed to move downloaded file: %w", err)
+	}
+	os.Remove(partPath + etagSuffix)
+	m.emit(DownloadProgress{
+		ID:         request.ID,
+		Status:     DownloadCompleted,
+		Downloaded: size,
+		Total:      size,
+		Verified:   request.Sha256 != "",
+	})
+	return nil
+}
+
+// readETag returns the ETag the part was written from, empty when the server didn't send a strong one
+func readETag(partPath string) string {
+	etag, err := os.ReadFile(partPath + etagSuffix)
+	if err != nil {
+		return ""
+	}
+	return string(etag)
+}
+
+// writeETag stores the ETag of a new part, weak ETags can't validate a range request and aren't kept
+func writeETag(partPath string, etag string) {
+	if etag == "" || strings.HasPrefix(etag, "W/") {
+		os.Remove(partPath + etagSuffix)
+		return
+	}
+	os.WriteFile(partPath+etagSuffix, []byte(etag), 0644)
+}
+
+func removePart(partPath string) {
+	os.Remove(partPath)
+	os.Remove(partPath + etagSuffix)
+}
+
+func (m *DownloadManager) emit(progress DownloadProgress) {
+	m.mutex.Lock()
+	app := m.app
+	m.mutex.Unlock()
+	if app != nil {
+		app.EmitEvent(EventDownloadProgress, progress)
+	}
+}
+
+// progressWriter counts the downloaded bytes and reports them at most once per interval
+type progressWriter struct {
+	manager    *DownloadManager
+	progress   DownloadProgress
+	lastReport time.Time
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.progress.Downloaded += int64(len(p))
+	if time.Since(w.lastReport) >= w.manager.interval {
+		w.lastReport = time.Now()
+		w.manager.emit(w.progress)
+	}
+	return len(p), nil
+}
+
+func fileSha256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open downloaded file: %w", err)
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to hash downloaded file: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,343 @@
+package lolskin
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// expectDownloadStatus expects one progress of the download id with status and accepts the others
+func expectDownloadStatus(mockApp *MockAppEmitter, id, status string, downloaded int64) {
+	mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.MatchedBy(func(progress DownloadProgress) bool {
+		return progress.ID == id && progress.Status == status && (downloaded == 0 || progress.Downloaded == downloaded)
+	})).Return().Once()
+	mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.Anything).Return().Maybe()
+}
+
+// fileServer serves files by path with range support and counts the requests, etags are sent with the
+// files that have one
+type fileServer struct {
+	files    map[string][]byte
+	etags    map[string]string
+	requests atomic.Int32
+	ranges   atomic.Int32
+	active   atomic.Int32
+	peak     atomic.Int32
+	delay    time.Duration
+	failPath string
+}
+
+func (f *fileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	active := f.active.Add(1)
+	defer f.active.Add(-1)
+	for peak := f.peak.Load(); active > peak && !f.peak.CompareAndSwap(peak, active); peak = f.peak.Load() {
+	}
+	time.Sleep(f.delay)
+
+	if r.URL.Path == f.failPath {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	data, ok := f.files[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	f.requests.Add(1)
+	if r.Header.Get("Range") != "" {
+		f.ranges.Add(1)
+	}
+	if etag, ok := f.etags[r.URL.Path]; ok {
+		w.Header().Set("ETag", etag)
+	}
+	http.ServeContent(w, r, r.URL.Path, time.Time{}, bytes.NewReader(data))
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDownloadManager(t *testing.T) {
+	newLogger := logger.New("TestDownloads", &config.Config{LogLevel: "error"})
+	content := bytes.Repeat([]byte("fantome"), 4096)
+
+	t.Run("Downloads and verifies the checksum", func(t *testing.T) {
+		server := httptest.NewServer(&fileServer{files: map[string][]byte{"/skin.fantome": content}})
+		defer server.Close()
+		mockApp := NewMockAppEmitter(t)
+		expectDownloadStatus(mockApp, "skin", DownloadCompleted, int64(len(content)))
+		manager := NewDownloadManager(newLogger, 2)
+		manager.SetApp(mockApp)
+		destination := filepath.Join(t.TempDir(), "skin.zip")
+
+		results := manager.Download(context.Background(), []DownloadRequest{{
+			ID:          "skin",
+			URL:         server.URL + "/skin.fantome",
+			Destination: destination,
+			Sha256:      checksum(content),
+		}})
+		require.Len(t, results, 1)
+		require.NoError(t, results[0].Err)
+
+		data, err := os.ReadFile(destination)
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+		assert.NoFileExists(t, destination+partSuffix)
+	})
+
+	t.Run("Completion reports whether the file was verified", func(t *testing.T) {
+		server := httptest.NewServer(&fileServer{files: map[string][]byte{"/skin.fantome": content}})
+		defer server.Close()
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.MatchedBy(func(progress DownloadProgress) bool {
+			return progress.ID == "verified" && progress.Status == DownloadCompleted && progress.Verified
+		})).Return().Once()
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.MatchedBy(func(progress DownloadProgress) bool {
+			return progress.ID == "unverified" && progress.Status == DownloadCompleted && !progress.Verified
+		})).Return().Once()
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.Anything).Return().Maybe()
+		manager := NewDownloadManager(newLogger, 2)
+		manager.SetApp(mockApp)
+		dir := t.TempDir()
+
+		results := manager.Download(context.Background(), []DownloadRequest{
+			{ID: "verified", URL: server.URL + "/skin.fantome", Destination: filepath.Join(dir, "verified.zip"), Sha256: checksum(content)},
+			{ID: "unverified", URL: server.URL + "/skin.fantome", Destination: filepath.Join(dir, "unverified.zip")},
+		})
+		require.NoError(t, results[0].Err)
+		require.NoError(t, results[1].Err)
+	})
+
+	t.Run("Resumes a partial download with a range request", func(t *testing.T) {
+		files := &fileServer{files: map[string][]byte{"/skin.fantome": content}}
+		server := httptest.NewServer(files)
+		defer server.Close()
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.Anything).Return().Maybe()
+		manager := NewDownloadManager(newLogger, 1)
+		manager.SetApp(mockApp)
+		destination := filepath.Join(t.TempDir(), "skin.zip")
+		require.NoError(t, os.WriteFile(destination+partSuffix, content[:1000], 0644))
+
+		results := manager.Download(context.Background(), []DownloadRequest{{
+			ID:          "skin",
+			URL:         server.URL + "/skin.fantome",
+			Destination: destination,
+			Sha256:      checksum(content),
+		}})
+		require.NoError(t, results[0].Err)
+		assert.Equal(t, int32(1), files.ranges.Load())
+
+		data, err := os.ReadFile(destination)
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+	})
+
+	t.Run("Completed part is only verified", func(t *testing.T) {
+		server := httptest.NewServer(&fileServer{files: map[string][]byte{"/skin.fantome": content}})
+		defer server.Close()
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.Anything).Return().Maybe()
+		manager := NewDownloadManager(newLogger, 1)
+		manager.SetApp(mockApp)
+		destination := filepath.Join(t.TempDir(), "skin.zip")
+		require.NoError(t, os.WriteFile(destination+partSuffix, content, 0644))
+
+		results := manager.Download(context.Background(), []DownloadRequest{{
+			ID:          "skin",
+			URL:         server.URL + "/skin.fantome",
+			Destination: destination,
+			Sha256:      checksum(content),
+		}})
+		require.NoError(t, results[0].Err)
+		assert.FileExists(t, destination)
+	})
+
+	t.Run("Part without a checksum or an ETag is downloaded again", func(t *testing.T) {
+		files := &fileServer{files: map[string][]byte{"/skin.fantome": content}}
+		server := httptest.NewServer(files)
+		defer server.Close()
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.Anything).Return().Maybe()
+		manager := NewDownloadManager(newLogger, 1)
+		manager.SetApp(mockApp)
+		destination := filepath.Join(t.TempDir(), "skin.zip")
+		require.NoError(t, os.WriteFile(destination+partSuffix, []byte("stale"), 0644))
+
+		results := manager.Download(context.Background(), []DownloadRequest{{
+			ID:          "skin",
+			URL:         server.URL + "/skin.fantome",
+			Destination: destination,
+		}})
+		require.NoError(t, results[0].Err)
+		assert.Zero(t, files.ranges.Load())
+
+		data, err := os.ReadFile(destination)
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+	})
+
+	t.Run("Resumes a part validated by its ETag", func(t *testing.T) {
+		files := &fileServer{files: map[string][]byte{"/skin.fantome": content}, etags: map[string]string{"/skin.fantome": `"v1"`}}
+		server := httptest.NewServer(files)
+		defer server.Close()
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.Anything).Return().Maybe()
+		manager := NewDownloadManager(newLogger, 1)
+		manager.SetApp(mockApp)
+		destination := filepath.Join(t.TempDir(), "skin.zip")
+		require.NoError(t, os.WriteFile(destination+partSuffix, content[:1000], 0644))
+		require.NoError(t, os.WriteFile(destination+partSuffix+etagSuffix, []byte(`"v1"`), 0644))
+
+		results := manager.Download(context.Background(), []DownloadRequest{{
+			ID:          "skin",
+			URL:         server.URL + "/skin.fantome",
+			Destination: destination,
+		}})
+		require.NoError(t, results[0].Err)
+		assert.Equal(t, int32(1), files.ranges.Load())
+
+		data, err := os.ReadFile(destination)
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+		assert.NoFileExists(t, destination+partSuffix+etagSuffix)
+	})
+
+	t.Run("Changed file replaces the part", func(t *testing.T) {
+		files := &fileServer{files: map[string][]byte{"/skin.fantome": content}, etags: map[string]string{"/skin.fantome": `"v2"`}}
+		server := httptest.NewServer(files)
+		defer server.Close()
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.Anything).Return().Maybe()
+		manager := NewDownloadManager(newLogger, 1)
+		manager.SetApp(mockApp)
+		destination := filepath.Join(t.TempDir(), "skin.zip")
+		require.NoError(t, os.WriteFile(destination+partSuffix, []byte("stale"), 0644))
+		require.NoError(t, os.WriteFile(destination+partSuffix+etagSuffix, []byte(`"v1"`), 0644))
+
+		results := manager.Download(context.Background(), []DownloadRequest{{
+			ID:          "skin",
+			URL:         server.URL + "/skin.fantome",
+			Destination: destination,
+		}})
+		require.NoError(t, results[0].Err)
+
+		data, err := os.ReadFile(destination)
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+	})
+
+	t.Run("Complete part without a checksum is downloaded again", func(t *testing.T) {
+		files := &fileServer{files: map[string][]byte{"/skin.fantome": content}, etags: map[string]string{"/skin.fantome": `"v1"`}}
+		server := httptest.NewServer(files)
+		defer server.Close()
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.Anything).Return().Maybe()
+		manager := NewDownloadManager(newLogger, 1)
+		manager.SetApp(mockApp)
+		destination := filepath.Join(t.TempDir(), "skin.zip")
+		require.NoError(t, os.WriteFile(destination+partSuffix, content, 0644))
+		require.NoError(t, os.WriteFile(destination+partSuffix+etagSuffix, []byte(`"v1"`), 0644))
+
+		results := manager.Download(context.Background(), []DownloadRequest{{
+			ID:          "skin",
+			URL:         server.URL + "/skin.fantome",
+			Destination: destination,
+		}})
+		require.NoError(t, results[0].Err)
+		assert.Equal(t, int32(2), files.requests.Load(), "the unsatisfiable range is followed by a full download")
+
+		data, err := os.ReadFile(destination)
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+	})
+
+	t.Run("Checksum mismatch leaves nothing behind", func(t *testing.T) {
+		server := httptest.NewServer(&fileServer{files: map[string][]byte{"/skin.fantome": content}})
+		defer server.Close()
+		mockApp := NewMockAppEmitter(t)
+		expectDownloadStatus(mockApp, "skin", DownloadFailed, 0)
+		manager := NewDownloadManager(newLogger, 1)
+		manager.SetApp(mockApp)
+		destination := filepath.Join(t.TempDir(), "skin.zip")
+
+		results := manager.Download(context.Background(), []DownloadRequest{{
+			ID:          "skin",
+			URL:         server.URL + "/skin.fantome",
+			Destination: destination,
+			Sha256:      checksum([]byte("other")),
+		}})
+		assert.ErrorIs(t, results[0].Err, ErrChecksumMismatch)
+		assert.NoFileExists(t, destination)
+		assert.NoFileExists(t, destination+partSuffix)
+	})
+
+	t.Run("Failed downloads are reported per item", func(t *testing.T) {
+		server := httptest.NewServer(&fileServer{
+			files:    map[string][]byte{"/ok.fantome": content},
+			failPath: "/broken.fantome",
+		})
+		defer server.Close()
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.Anything).Return().Maybe()
+		manager := NewDownloadManager(newLogger, 2)
+		manager.SetApp(mockApp)
+		dir := t.TempDir()
+
+		results := manager.Download(context.Background(), []DownloadRequest{
+			{ID: "broken", URL: server.URL + "/broken.fantome", Destination: filepath.Join(dir, "broken.zip")},
+			{ID: "ok", URL: server.URL + "/ok.fantome", Destination: filepath.Join(dir, "ok.zip")},
+		})
+		require.Len(t, results, 2)
+		assert.Error(t, results[0].Err)
+		assert.Equal(t, "broken", results[0].Request.ID)
+		assert.NoError(t, results[1].Err)
+		assert.NoFileExists(t, filepath.Join(dir, "broken.zip"))
+		assert.FileExists(t, filepath.Join(dir, "ok.zip"))
+	})
+
+	t.Run("Worker pool bounds the parallel downloads", func(t *testing.T) {
+		files := &fileServer{files: map[string][]byte{}, delay: 20 * time.Millisecond}
+		requests := make([]DownloadRequest, 0, 8)
+		dir := t.TempDir()
+		for i := 0; i < 8; i++ {
+			path := fmt.Sprintf("/%d.fantome", i)
+			files.files[path] = content
+		}
+		server := httptest.NewServer(files)
+		defer server.Close()
+		for i := 0; i < 8; i++ {
+			requests = append(requests, DownloadRequest{
+				ID:          fmt.Sprint(i),
+				URL:         fmt.Sprintf("%s/%d.fantome", server.URL, i),
+				Destination: filepath.Join(dir, fmt.Sprintf("%d.zip", i)),
+			})
+		}
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.Anything).Return().Maybe()
+		manager := NewDownloadManager(newLogger, 3)
+		manager.SetApp(mockApp)
+
+		for _, result := range manager.Download(context.Background(), requests) {
+			assert.NoError(t, result.Err)
+		}
+		assert.LessOrEqual(t, files.peak.Load(), int32(3))
+		assert.Greater(t, files.peak.Load(), int32(1))
+	})
+}
@@ -2,34 +2,30 @@ package lolskin
 
 import (
 	"archive/zip"
-	"bufio"
-	"bytes"
+	"context"
 	"embed"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/hex-boost/hex-nexus-app/backend/pkg/command"
 	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
 	"go.uber.org/zap"
 	"io"
-	"net/http"
-	"net/url"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
 )
 
 type LolSkin struct {
-	modToolsExe     embed.FS
-	csLolDLL        embed.FS
-	game            string
-	mutex           sync.Mutex
-	logger          *logger.Logger
-	patcherProcess  *exec.Cmd
-	patcherStdin    *os.File
-	onStatusChanged func(string)
+	modToolsExe embed.FS
+	csLolDLL    embed.FS
+	catalog     *CatalogService
+	downloads   *DownloadManager
+	game        string
+	logger      *logger.Logger
+	patcher     *Patcher
+	conflicts   *ConflictResolver
+	sources     *SkinSources
+	app         AppEmitter
 
 	tempDir string // new field to store the temp directory
 }
@@ -40,7 +36,10 @@ type LolSkin struct {
 const ModToolsExe = "mod-tools.exe"
 const CsLolDLL = "cslol-dll.dll"
 
-func New(logger *logger.Logger, leaguePath string, catalog, csLolDLL, modToolsExe embed.FS) *LolSkin {
+// downloadWorkers is the number of skins downloaded at the same time
+const downloadWorkers = 4
+
+func New(logger *logger.Logger, leaguePath string, catalog *CatalogService, csLolDLL, modToolsExe embed.FS) *LolSkin {
 	// Create a temporary directory for our extracted files
 
 	exePath, err := os.Executable()
@@ -64,6 +63,9 @@ func New(logger *logger.Logger, leaguePath string, catalog, csLolDLL, modToolsEx
 		logger:      logger,
 		modToolsExe: modToolsExe,
 		csLolDLL:    csLolDLL,
+		catalog:     catalog,
+		patcher:     NewPatcher(logger, filepath.Join(tempDir, ModToolsExe)),
+		downloads:   NewDownloadManager(logger, downloadWorkers),
 		game:        leaguePath,
 		tempDir:     tempDir,
 	}
@@ -121,58 +123,30 @@ func (c *LolSkin) changeLeaguePath() {
 
 // StopRunningPatcher safely stops any running patcher process
 func (c *LolSkin) StopRunningPatcher() {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
 	c.logger.Info("StopRunningPatcher called")
+	c.patcher.Stop()
+}
 
-	if c.patcherProcess == nil {
-		c.logger.Info("No patcher process running, nothing to stop")
-		return
-	}
-
-	var pid int
-	if c.patcherProcess.Process != nil {
-		pid = c.patcherProcess.Process.Pid
-	}
-	c.logger.Info("Attempting to stop patcher process", zap.Int("pid", pid))
-
-	// Try graceful termination first
-	if c.patcherStdin != nil {
-		c.logger.Info("Sending termination signal via stdin", zap.Int("pid", pid))
-		if _, err := c.patcherStdin.Write([]byte("\n")); err != nil {
-			c.logger.Warn("Failed to write to patcher stdin", zap.Error(err))
-		}
-	} else {
-		c.logger.Info("No stdin pipe available for graceful termination")
-	}
-
-	// Wait for a moment to let the process terminate gracefully
-	c.logger.Info("Waiting for graceful termination", zap.Duration("timeout", 500*time.Millisecond))
-	time.Sleep(500 * time.Millisecond)
-
-	// Force kill if still running
-	if c.patcherProcess != nil && c.patcherProcess.Process != nil {
-		c.logger.Info("Process still running, forcing termination", zap.Int("pid", pid))
-		if err := c.patcherProcess.Process.Kill(); err != nil {
-			c.logger.Error("Failed to kill patcher process", zap.Error(err), zap.Int("pid", pid))
-		} else {
-			c.logger.Info("Successfully killed patcher process", zap.Int("pid", pid))
-		}
-
-		c.patcherProcess = nil
-		c.patcherStdin = nil
-	}
-
-	c.logger.Info("Patcher stopped, state set to idle")
+// GetPatcherStatus returns the state of the patcher supervisor
+func (c *LolSkin) GetPatcherStatus() PatcherStatus {
+	return c.patcher.Status()
 }
-func (c *LolSkin) InjectFantome(mods []string) error {
+
+// InjectFantome builds the overlay of a skin profile with the given mods and starts the patcher
+func (c *LolSkin) InjectFantome(profileName string, mods []string) error {
 
 	// Change League Path
 	c.changeLeaguePath()
 
 	gameDir := filepath.Dir(c.game)
-	profileName := "Default Profile" // Always use the same profile
+
+	if c.conflicts != nil {
+		var conflicts []ModConflict
+		mods, conflicts = c.conflicts.Resolve(mods)
+		if len(conflicts) > 0 && c.app != nil {
+			c.app.EmitEvent(EventModConflicts, conflicts)
+		}
+	}
 
 	// Create log file
 	logFile, _ := os.Create(filepath.Join(c.tempDir, "mod-tools-log.txt"))
@@ -195,11 +169,16 @@ func (c *LolSkin) InjectFantome(mods []string) error {
 		profileFile.Close()
 	}
 
+	// The overlay is built next to the one in use so a failed build doesn't leave the game without skins
+	overlayDir := filepath.Join(c.tempDir, "profiles", profileName)
+	stagingDir := overlayDir + ".next"
+	os.RemoveAll(stagingDir)
+
 	// Create overlay with all selected mods
 	overlayArgs := []string{
 		"mkoverlay",
 		filepath.Join(c.tempDir, "installed"),
-		filepath.Join(c.tempDir, "profiles", profileName),
+		stagingDir,
 		"--game:" + gameDir,
 	}
 
@@ -207,155 +186,67 @@ func (c *LolSkin) InjectFantome(mods []string) error {
 		overlayArgs = append(overlayArgs, "--mods:"+strings.Join(mods, "/"))
 	}
 
-	if err := c.executeCommand(ModToolsExe, overlayArgs, "OVERLAY", logFile); err != nil {
-		return err
-	}
-
 	// Run the patcher
 	patcherArgs := []string{
 		"runoverlay",
-		filepath.Join(c.tempDir, "profiles", profileName),
+		overlayDir,
 		filepath.Join(c.tempDir, "profiles", profileName+".config"),
 		"--game:" + gameDir,
 		"--opts:none",
 	}
 
-	c.runPatcher(patcherArgs)
-	return nil
-}
-
-func (c *LolSkin) executeCommand(executable string, args []string, logPrefix string, logFile *os.File) error {
-	execPath := filepath.Join(c.tempDir, executable)
-
-	c.logger.Debug("Executing command", zap.String("executable", execPath), zap.Strings("args", args))
-
+	var overlayLog io.Writer
 	if logFile != nil {
-		fmt.Fprintf(logFile, "\n==== %s COMMAND ====\n", logPrefix)
-		fmt.Fprintf(logFile, "Command: %s\n", execPath)
-		fmt.Fprintf(logFile, "Arguments: %v\n", args)
+		fmt.Fprintf(logFile, "\n==== OVERLAY COMMAND ====\n")
+		overlayLog = logFile
 	}
-	commander := command.New()
-	cmd := commander.Exec(execPath, args...)
-
-	// Capture output
-	var stdoutBuf, stderrBuf bytes.Buffer
-	stdoutWriter := io.MultiWriter(&stdoutBuf, logFile)
-	stderrWriter := io.MultiWriter(&stderrBuf, logFile)
-
-	cmd.Stdout = stdoutWriter
-	cmd.Stderr = stderrWriter
-
-	if err := cmd.Run(); err != nil {
-		errMsg := stderrBuf.String()
-		c.logger.Error("Command execution failed",
-			zap.String("executable", execPath),
-			zap.Strings("args", args),
-			zap.Error(err),
-			zap.String("stderr", errMsg))
-		return fmt.Errorf("command failed: %v - %s", err, errMsg)
+	if err := c.patcher.Build(overlayArgs, overlayLog); err != nil {
+		os.RemoveAll(stagingDir)
+		c.fallbackToPreviousOverlay(overlayDir, patcherArgs)
+		return err
 	}
 
-	// Log the output
-	c.logger.Info("Command output", zap.String("stdout", stdoutBuf.String()))
-
+	// The patcher holds the overlay files open, it must be stopped before they are replaced. The previous
+	// overlay is moved aside and only removed once the new one is in place
+	c.patcher.Stop()
+	previousDir := overlayDir + ".previous"
+	os.RemoveAll(previousDir)
+	if err := os.Rename(overlayDir, previousDir); err != nil && !errors.Is(err, os.ErrNotExist) {
+		os.RemoveAll(stagingDir)
+		c.fallbackToPreviousOverlay(overlayDir, patcherArgs)
+		return fmt.Errorf("failed to move previous overlay aside: %w", err)
+	}
+	if err := os.Rename(stagingDir, overlayDir); err != nil {
+		os.RemoveAll(stagingDir)
+		if restoreErr := os.Rename(previousDir, overlayDir); restoreErr != nil && !errors.Is(restoreErr, os.ErrNotExist) {
+			c.logger.Error("Failed to restore the previous overlay", zap.Error(restoreErr))
+		}
+		c.fallbackToPreviousOverlay(overlayDir, patcherArgs)
+		return fmt.Errorf("failed to move overlay into place: %w", err)
+	}
+	os.RemoveAll(previousDir)
+	// The supervisor keeps the patcher running, its state is sent as events
+	c.patcher.Run(patcherArgs)
 	return nil
 }
 
-// RunPatcher starts the patcher process with the specified arguments
-func (c *LolSkin) runPatcher(args []string) {
-	// Create a completion channel
-	completed := make(chan struct{})
-
-	// Create the command
-	execPath := filepath.Join(c.tempDir, ModToolsExe)
-	commander := command.New()
-	c.patcherProcess = commander.Exec(execPath, args...)
-
-	c.logger.Info("Starting patcher process", zap.String("executable", execPath), zap.Strings("args", args))
-
-	// Set up pipes
-	stdout, _ := c.patcherProcess.StdoutPipe()
-	stderr, _ := c.patcherProcess.StderrPipe()
-	stdin, _ := c.patcherProcess.StdinPipe()
-	c.patcherStdin = stdin.(*os.File)
-
-	// Monitor stdout
-	go func() {
-		scanner := bufio.NewScanner(stdout)
-		for scanner.Scan() {
-			line := scanner.Text()
-			c.logger.Info("Patcher output", zap.String("message", line))
-
-			// Check for successful completion message
-			if strings.Contains(line, "Waiting for league match to start") {
-				c.logger.Info("Successfully injected all skins")
-				close(completed)
-				return
-			}
-		}
-	}()
-
-	// Monitor stderr
-	go func() {
-		scanner := bufio.NewScanner(stderr)
-		for scanner.Scan() {
-			line := scanner.Text()
-			c.logger.Error("Patcher error", zap.String("message", line))
-		}
-	}()
-
-	// Start the process
-	err := c.patcherProcess.Start()
-	if err != nil {
-		errorDetails := fmt.Sprintf("arguments:\n  %s\n", strings.Join(args, "\n  "))
-		c.logger.Error("Failed to start patcher", zap.Error(err), zap.String("details", errorDetails))
-		close(completed)
+// fallbackToPreviousOverlay makes sure the patcher runs the previous overlay after a failed rebuild
+func (c *LolSkin) fallbackToPreviousOverlay(overlayDir string, patcherArgs []string) {
+	if c.patcher.IsRunning() {
+		c.logger.Info("Keeping the previous overlay after a failed rebuild")
 		return
 	}
-
-	// Wait for process completion
-	go func() {
-		processToWait := c.patcherProcess
-		if processToWait == nil {
-			return // Process was already cleaned up
-		}
-
-		err := processToWait.Wait()
-		if err != nil {
-			// Avoid logging "exec: not started" if the process was killed intentionally
-			if exitErr, ok := err.(*exec.ExitError); !ok || !strings.Contains(exitErr.Error(), "killed") {
-				c.logger.Error("Patcher process failed", zap.Error(err))
-			}
-		}
-		c.logger.Info("Patcher process completed")
-
-		c.mutex.Lock()
-		// Only clear the main struct's fields if they still refer to the same process
-		if c.patcherProcess == processToWait {
-			c.patcherProcess = nil
-			c.patcherStdin = nil
-		}
-		c.mutex.Unlock()
-	}()
-	// Wait for completion signal or timeout
-	select {
-	case <-completed:
-		// Successful injection, we can return now
-		return
-	case <-time.After(30 * time.Second):
-		// Timeout waiting for success message
-		c.logger.Warn("Timeout waiting for successful injection")
+	if _, err := os.Stat(overlayDir); err != nil {
+		c.logger.Info("No previous overlay to fall back to")
 		return
 	}
+	c.logger.Info("Falling back to the previous overlay", zap.String("overlay", overlayDir))
+	c.patcher.Run(patcherArgs)
 }
 
 // StopProfile terminates the injection process
 func (c *LolSkin) StopProfile() {
-	c.mutex.Lock()
-	if c.patcherStdin != nil {
-		c.patcherStdin.Write([]byte("\n"))
-	}
-	c.mutex.Unlock()
+	c.patcher.Stop()
 }
 
 // Cleanup removes the temporary directory
@@ -376,295 +267,150 @@ func (c *LolSkin) writeCurrentProfile(profile string) {
 	file.WriteString(profile + "\n")
 }
 
-func (c *LolSkin) DownloadSkins(championID int32, skinID int32) (string, error) {
-	// Get champion and skin names from ID
-	championData, skinName, err := c.resolveChampionAndSkinData(championID, skinID)
-	if err != nil {
-		return "", fmt.Errorf("failed to resolve names: %w", err)
-	}
-
-	// Define the direct path in the installed folder
-	installedPath := filepath.Join(c.tempDir, "installed", skinName)
-
-	// Check if the skin folder already exists in installed directory
-	if _, err := os.Stat(installedPath); err == nil {
-		c.logger.Info("Using already installed skin",
-			zap.Int32("championId", championID),
-			zap.Int32("skinId", skinID),
-			zap.String("path", installedPath))
-		return skinName, nil
-	}
-
-	// Create temp directory for download
-	tempDownloadDir := filepath.Join(c.tempDir, "temp_downloads")
-	if err := os.MkdirAll(tempDownloadDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create temp download directory: %w", err)
-	}
-
-	zipFilePath := filepath.Join(tempDownloadDir, skinName+".zip")
-
-	// Download the zip file
-	downloadUrl := fmt.Sprintf("https://github.com/darkseal-org/lol-skins/raw/main/skins/%s/%s.zip",
-		url.PathEscape(championData.Name), url.PathEscape(skinName))
-
-	c.logger.Info("Downloading skin zip",
-		zap.String("champion", championData.Name),
-		zap.String("skin", skinName),
-		zap.String("url", downloadUrl))
-
-	// Create the download request
-	resp, err := http.Get(downloadUrl)
-	if err != nil {
-		return "", fmt.Errorf("failed to download: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("bad status: %s", resp.Status)
-	}
-
-	// Create the zip file
-	out, err := os.Create(zipFilePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to create file: %w", err)
-	}
-	defer out.Close()
-
-	// Write the file
-	_, err = io.Copy(out, resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to write file: %w", err)
-	}
-
-	// Extract directly to installed folder
-	if err := c.extractZip(zipFilePath, installedPath); err != nil {
-		return "", fmt.Errorf("failed to extract zip: %w", err)
-	}
-
-	// Clean up the temporary zip file
-	os.Remove(zipFilePath)
-
-	c.logger.Info("Successfully downloaded and extracted skin",
-		zap.String("champion", championData.Name),
-		zap.String("skin", skinName))
-
-	// Return just the skin name instead of the full path
-	return skinName, nil
-}
-
-// Helper function to extract a zip file
-func (c *LolSkin) extractZip(zipPath, destPath string) error {
-	// Open the zip file
-	r, err := zip.OpenReader(zipPath)
-	if err != nil {
-		return err
-	}
-	defer r.Close()
-
-	// Create the destination directory
-	if err := os.MkdirAll(destPath, 0755); err != nil {
-		return err
-	}
+// DownloadSkins installs the selected skins and chromas, the missing ones are downloaded in parallel. It returns
+// the mod names to inject in the order of the selections, the ones that fail are skipped
+func (c *LolSkin) DownloadSkins(ctx context.Context, selections []ChampionSkin) []string {
+	modNames := make([]string, 0, len(selections))
+	requests := make([]DownloadRequest, 0, len(selections))
+	queued := make(map[string]bool)
+
+	for _, selection := range selections {
+		// Imported mods are already installed, they have nothing to download
+		if selection.CustomModID != "" {
+			if _, err := os.Stat(filepath.Join(c.tempDir, "installed", selection.CustomModID)); err != nil {
+				c.logger.Error("Selected custom mod is not installed",
+					zap.Int32("championId", selection.ChampionID),
+					zap.String("customModId", selection.CustomModID))
+				continue
+			}
+			modNames = append(modNames, selection.CustomModID)
+			continue
+		}
 
-	// Extract each file
-	for _, file := range r.File {
-		rc, err := file.Open()
+		// Resolve the skin from the embedded catalog
+		resolved, err := c.catalog.Resolve(selection.ChampionID, selection.SkinID, selection.ChromaID)
 		if err != nil {
-			return err
+			c.logger.Error("Failed to resolve skin",
+				zap.Int32("championId", selection.ChampionID),
+				zap.Int32("skinId", selection.SkinID),
+				zap.Any("chromaId", selection.ChromaID),
+				zap.Error(err))
+			continue
 		}
-		defer rc.Close()
-
-		path := filepath.Join(destPath, file.Name)
-
-		if file.FileInfo().IsDir() {
-			os.MkdirAll(path, 0755)
-		} else {
-			// Create the file
-			os.MkdirAll(filepath.Dir(path), 0755)
-			f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
-			if err != nil {
-				return err
-			}
-			defer f.Close()
-
-			// Copy the file contents
-			_, err = io.Copy(f, rc)
-			if err != nil {
-				return err
-			}
+		modName := resolved.ModName()
+		modNames = append(modNames, modName)
+
+		// Check if the skin folder already exists in installed directory
+		installedPath := filepath.Join(c.tempDir, "installed", modName)
+		if _, err := os.Stat(installedPath); err == nil {
+			c.logger.Info("Using already installed skin",
+				zap.String("skin", resolved.SkinName),
+				zap.String("path", installedPath))
+			continue
 		}
-	}
-
-	return nil
-}
-
-// Helper to resolve champion and skin names from DataDragon
-type ChampionData struct {
-	ID    string
-	Name  string
-	Skins []struct {
-		ID   string
-		Name string
-		Num  int
-	}
-}
-
-func (c *LolSkin) resolveChampionAndSkinData(championID int32, skinID int32) (*ChampionData, string, error) {
-	// Create a cache if needed for DataDragon data
-	cachePath := filepath.Join(c.tempDir, "dataDragon")
-	os.MkdirAll(cachePath, 0755)
-
-	// Check for cached data first
-	cacheFile := filepath.Join(cachePath, "champion_data.json")
-	var champions map[string]ChampionData
-
-	// Try to load from cache
-	data, err := os.ReadFile(cacheFile)
-	if err == nil {
-		if err := json.Unmarshal(data, &champions); err == nil {
-			// Find champion by ID
-			for _, champion := range champions {
-				if champion.ID == fmt.Sprint(championID) {
-					// Find skin by num
-					skinNum := int(skinID) % 1000 // Remove champion base ID
-					for _, skin := range champion.Skins {
-						if skin.Num == skinNum {
-							return &champion, skin.Name, nil
-						}
-					}
-				}
-			}
+		if queued[modName] {
+			continue
 		}
-	}
+		queued[modName] = true
 
-	// If cache doesn't exist or champion/skin not found, fetch from DataDragon API
-	c.logger.Info("Fetching champion data from DataDragon API")
-
-	// Get latest version first
-	versionURL := "https://ddragon.leagueoflegends.com/api/versions.json"
-	vResp, err := http.Get(versionURL)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to get versions: %w", err)
-	}
-	defer vResp.Body.Close()
-
-	var versions []string
-	if err := json.NewDecoder(vResp.Body).Decode(&versions); err != nil {
-		return nil, "", fmt.Errorf("failed to parse versions: %w", err)
-	}
-
-	if len(versions) == 0 {
-		return nil, "", fmt.Errorf("no versions available")
-	}
-
-	latestVersion := versions[0]
-
-	// Get all champions data
-	championsURL := fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/%s/data/en_US/champion.json", latestVersion)
-	cResp, err := http.Get(championsURL)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to get champion list: %w", err)
-	}
-	defer cResp.Body.Close()
-
-	var champListResp struct {
-		Data map[string]struct {
-			ID   string `json:"id"`
-			Key  string `json:"key"` // This is the numeric ID as string
-			Name string `json:"name"`
-		} `json:"data"`
-	}
-
-	if err := json.NewDecoder(cResp.Body).Decode(&champListResp); err != nil {
-		return nil, "", fmt.Errorf("failed to parse champion list: %w", err)
+		var mirrors []Mirror
+		checksum := resolved.Sha256
+		if c.sources != nil {
+			mirrors = c.sources.Mirrors(*resolved)
+			checksum = c.sources.Checksum(*resolved)
+		}
+		if checksum == "" {
+			c.logger.Error("Skin has no checksum, it's only downloaded from the catalog and installed unverified",
+				zap.String("mod", modName),
+				zap.String("url", resolved.DownloadUrl))
+		}
+		c.logger.Info("Downloading skin",
+			zap.String("champion", resolved.ChampionName),
+			zap.String("skin", resolved.SkinName),
+			zap.Any("chromaId", resolved.ChromaID),
+			zap.String("url", resolved.DownloadUrl),
+			zap.Int("mirrors", len(mirrors)))
+		// Fantome files are zip archives
+		requests = append(requests, DownloadRequest{
+			ID:          modName,
+			URL:         resolved.DownloadUrl,
+			Mirrors:     mirrors,
+			Destination: filepath.Join(c.tempDir, "temp_downloads", modName+".zip"),
+			Sha256:      checksum,
+		})
+	}
+
+	failed := make(map[string]bool)
+	for _, result := range c.downloads.Download(ctx, requests) {
+		modName := result.Request.ID
+		if result.Err != nil {
+			failed[modName] = true
+			continue
+		}
+		if err := c.install(result.Request.Destination, modName); err != nil {
+			c.logger.Error("Failed to install skin", zap.String("mod", modName), zap.Error(err))
+			failed[modName] = true
+			continue
+		}
+		c.logger.Info("Successfully downloaded and extracted skin", zap.String("mod", modName))
 	}
 
-	// Find the champion with matching ID
-	var champKey string
-	for _, champ := range champListResp.Data {
-		if champ.Key == fmt.Sprint(championID) {
-			champKey = champ.ID
-			break
+	installed := modNames[:0]
+	for _, modName := range modNames {
+		if !failed[modName] {
+			installed = append(installed, modName)
 		}
 	}
+	return installed
+}
 
-	if champKey == "" {
-		return nil, "", fmt.Errorf("champion with ID %d not found", championID)
-	}
+// install extracts a downloaded zip next to the installed folder and renames it in place, so a failed
+// extraction never leaves a partial mod behind
+func (c *LolSkin) install(zipPath, modName string) error {
+	installedPath := filepath.Join(c.tempDir, "installed", modName)
+	extractPath := installedPath + extractingSuffix
+	os.RemoveAll(extractPath)
 
-	// Get detailed champion data including skins
-	champDetailURL := fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/%s/data/en_US/champion/%s.json",
-		latestVersion, champKey)
-	dResp, err := http.Get(champDetailURL)
+	reader, err := zip.OpenReader(zipPath)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to get champion details: %w", err)
-	}
-	defer dResp.Body.Close()
-
-	var champDetailResp struct {
-		Data map[string]struct {
-			ID    string `json:"id"`
-			Key   string `json:"key"`
-			Name  string `json:"name"`
-			Skins []struct {
-				ID   string `json:"id"`
-				Num  int    `json:"num"`
-				Name string `json:"name"`
-			} `json:"skins"`
-		} `json:"data"`
+		return fmt.Errorf("failed to open zip: %w", err)
 	}
-
-	if err := json.NewDecoder(dResp.Body).Decode(&champDetailResp); err != nil {
-		return nil, "", fmt.Errorf("failed to parse champion details: %w", err)
+	defer reader.Close()
+	// Downloaded skins are extracted with the same guards as the imported mods
+	if err := validateArchiveEntries(&reader.Reader); err != nil {
+		return err
 	}
-
-	// Extract the champion data
-	var champData ChampionData
-	for _, detail := range champDetailResp.Data {
-		champData.ID = detail.Key
-		champData.Name = detail.Name
-
-		// Copy skins one by one
-		champData.Skins = make([]struct {
-			ID   string
-			Name string
-			Num  int
-		}, len(detail.Skins))
-
-		for i, skin := range detail.Skins {
-			champData.Skins[i].ID = skin.ID
-			champData.Skins[i].Name = skin.Name
-			champData.Skins[i].Num = skin.Num
-		}
-
-		break // There should be only one champion in the response
+	if err := extractModArchive(&reader.Reader, extractPath); err != nil {
+		os.RemoveAll(extractPath)
+		return fmt.Errorf("failed to extract zip: %w", err)
 	}
-	// Find skin by num
-	skinNum := int(skinID) % 1000
-	var skinName string
-	for _, skin := range champData.Skins {
-		if skin.Num == skinNum {
-			skinName = skin.Name
-			break
-		}
+	if err := os.Rename(extractPath, installedPath); err != nil {
+		os.RemoveAll(extractPath)
+		return fmt.Errorf("failed to move extracted skin: %w", err)
 	}
 
-	if skinName == "" {
-		return nil, "", fmt.Errorf("skin with num %d not found for champion %s", skinNum, champData.Name)
-	}
+	// Clean up the temporary zip file
+	reader.Close()
+	os.Remove(zipPath)
+	return nil
+}
 
-	// Cache the data for future use
-	if champions == nil {
-		champions = make(map[string]ChampionData)
-	}
-	champions[champData.ID] = champData
+// SetApp lets the download manager and the patcher report to the frontend
+func (c *LolSkin) SetApp(app AppEmitter) {
+	c.app = app
+	c.downloads.SetApp(app)
+	c.patcher.SetApp(app)
+}
 
-	cacheData, err := json.Marshal(champions)
-	if err == nil {
-		os.WriteFile(cacheFile, cacheData, 0644)
-	}
+// SetConflictResolver applies the user's priority and exclusions to the mods of every overlay
+func (c *LolSkin) SetConflictResolver(conflicts *ConflictResolver) {
+	c.conflicts = conflicts
+}
 
-	return &champData, skinName, nil
+// SetSkinSources downloads skins from the configured sources and records their health
+func (c *LolSkin) SetSkinSources(sources *SkinSources) {
+	c.sources = sources
+	c.downloads.SetReporter(sources)
 }
 
 // GetTempDir returns the temporary directory path
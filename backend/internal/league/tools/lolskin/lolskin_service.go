@@ -45,22 +45,50 @@ type Service struct {
 	accountState   AccountState
 	lolSkin        *LolSkin
 	lolSkinState   LolSkinState
+	cache          *Cache
+	profiles       *ProfileManager
+	globalMods     GlobalModSource
+	champSelect    *ChampSelect
+	enabled        bool
+	enabledMutex   sync.Mutex
 	eventMutex     sync.Mutex
 	ctx            context.Context
 	lolSkinService *Service
 }
 
 func NewService(logger logger.Loggerer, accountState AccountState, accountClient AccountClient, lolSkin *LolSkin, state LolSkinState) *Service {
-	return &Service{
+	service := &Service{
 		logger:        logger,
 		accountClient: accountClient, // Should be set externally
 		accountState:  accountState,  // Should be set externally
 		lolSkin:       lolSkin,
 		lolSkinState:  state,
+		enabled:       true,
 		ctx:           context.Background(),
 	}
+	service.champSelect = NewChampSelect(logger, state, service)
+	return service
 }
+
+// SetProfiles makes the injection use the overlay of the active skin profile
+func (h *Service) SetProfiles(profiles *ProfileManager) {
+	h.profiles = profiles
+}
+
+// SetGlobalMods adds the imported global mods to every overlay
+func (h *Service) SetGlobalMods(globalMods GlobalModSource) {
+	h.globalMods = globalMods
+}
+
+// SetCache lets the injection keep the installed skins under the cache quota
+func (h *Service) SetCache(cache *Cache) {
+	h.cache = cache
+}
+
 func (h *Service) ToggleLolSkinEnabled(enabled bool) {
+	h.enabledMutex.Lock()
+	h.enabled = enabled
+	h.enabledMutex.Unlock()
 	if !enabled {
 		h.lolSkin.StopRunningPatcher()
 	} else {
@@ -81,16 +109,28 @@ func (h *Service) InvalidateCache() error {
 		return fmt.Errorf("temp directory not found")
 	}
 
+	var errors []string
+
+	// Delete the downloaded skins, imported mods can't be downloaded again so they are kept
+	installedDir := filepath.Join(tempDir, "installed")
+	installed, _ := os.ReadDir(installedDir)
+	for _, entry := range installed {
+		if strings.HasPrefix(entry.Name(), CustomModPrefix) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(installedDir, entry.Name())); err != nil {
+			h.logger.Error("Failed to delete skin", zap.String("skin", entry.Name()), zap.Error(err))
+			errors = append(errors, fmt.Sprintf("failed to delete %s: %v", entry.Name(), err))
+		}
+	}
+
 	// Define folders to delete
 	foldersToDelete := []string{
-		"installed",      // Downloaded and extracted skins
 		"profiles",       // Profile configurations
 		"temp_downloads", // Temporary download files
 		"dataDragon",     // Cached DataDragon API data
 	}
 
-	var errors []string
-
 	// Delete each folder
 	for _, folder := range foldersToDelete {
 		folderPath := filepath.Join(tempDir, folder)
@@ -145,6 +185,10 @@ func (h *Service) InvalidateCache() error {
 		}
 	}
 
+	if h.cache != nil {
+		h.cache.Reconcile()
+	}
+
 	if len(errors) > 0 {
 		return fmt.Errorf("cache invalidation completed with errors: %s", strings.Join(errors, "; "))
 	}
@@ -153,56 +197,74 @@ func (h *Service) InvalidateCache() error {
 	return nil
 }
 
-// Modify StartInjection method in lolskin_service.go
+// StartInjection rebuilds the overlay of the champion locked in champ select, skins are only injected once
+// a champion is locked
 func (h *Service) StartInjection() {
+	h.champSelect.Reinject()
+}
 
-	// Stop any running patcher before starting a new one
-	h.lolSkin.StopRunningPatcher()
-
-	// Get all selected skins
-	skinsSelected := h.lolSkinState.GetAllSelections()
-	if len(skinsSelected) == 0 {
-		h.logger.Info("No skins selected, nothing to inject")
-		return
-	}
+// ChampionHovered prefetches the selected skin of the champion hovered in champ select
+func (h *Service) ChampionHovered(championID int32) {
+	h.champSelect.Hover(championID)
+}
 
-	// Store just skin names for injection
-	var skinNames []string
+// ChampionLocked injects the selected skin of the champion locked in champ select
+func (h *Service) ChampionLocked(championID int32) {
+	h.champSelect.Lock(championID)
+}
 
-	// Download all selected skins
-	for _, skin := range skinsSelected {
-		if skin.ChampionID == 0 || skin.SkinID == 0 {
-			h.logger.Info("Skipping injection for invalid skin selection",
-				zap.Int32("championId", skin.ChampionID),
-				zap.Int32("skinId", skin.SkinID))
-			continue
-		}
+// ChampSelectEnded forgets the champ select pick, the injected overlay is kept for the game
+func (h *Service) ChampSelectEnded() {
+	h.champSelect.Reset()
+}
 
-		// Download the skin - but use the modified version that saves to installed folder
-		skinName, err := h.lolSkin.DownloadSkins(skin.ChampionID, skin.SkinID)
-		if err != nil {
-			h.logger.Error("Failed to download skin",
-				zap.Int32("championId", skin.ChampionID),
-				zap.Int32("skinId", skin.SkinID),
-				zap.Error(err))
-			continue
-		}
+// Prefetch downloads and installs a skin without injecting it
+func (h *Service) Prefetch(selection ChampionSkin) {
+	skinNames := h.lolSkin.DownloadSkins(h.ctx, []ChampionSkin{selection})
+	if h.cache != nil {
+		h.cache.Touch(skinNames)
+	}
+}
 
-		// Add just the skin name to inject
-		skinNames = append(skinNames, skinName)
+// Inject rebuilds the overlay with the skin of the champion and the global mods, when it fails the patcher keeps
+// the previous overlay
+func (h *Service) Inject(selection ChampionSkin) error {
+	if !h.isEnabled() {
+		h.logger.Info("LolSkin is disabled, skipping injection", zap.Int32("championId", selection.ChampionID))
+		return nil
+	}
+	if !selection.IsValid() {
+		return fmt.Errorf("invalid skin selection: champion %d skin %d", selection.ChampionID, selection.SkinID)
 	}
 
-	// If we have skins to inject
-	if len(skinNames) > 0 {
-		h.logger.Info("Injecting skins", zap.Int("count", len(skinNames)))
+	skinNames := h.lolSkin.DownloadSkins(h.ctx, []ChampionSkin{selection})
+	if len(skinNames) == 0 {
+		return fmt.Errorf("failed to download skin %d of champion %d", selection.SkinID, selection.ChampionID)
+	}
+	if h.cache != nil {
+		h.cache.Touch(skinNames)
+		h.cache.Enforce()
+	}
 
-		// Inject all skins at once using just their names
-		err := h.lolSkin.InjectFantome(skinNames)
-		if err != nil {
-			h.logger.Error("Failed to inject skins", zap.Error(err))
-			return
-		}
+	// The skin comes first so it wins the conflicts with the global mods unless the user prioritized them
+	mods := append([]string{}, skinNames...)
+	if h.globalMods != nil {
+		mods = append(mods, h.globalMods.GlobalMods()...)
+	}
 
-		h.logger.Info("Successfully injected all skins")
+	profileName := "Default Profile"
+	if h.profiles != nil {
+		profileName = h.profiles.Active().ID
+	}
+	if err := h.lolSkin.InjectFantome(profileName, mods); err != nil {
+		return err
 	}
+	h.logger.Info("Successfully injected skin", zap.Strings("mods", mods))
+	return nil
+}
+
+func (h *Service) isEnabled() bool {
+	h.enabledMutex.Lock()
+	defer h.enabledMutex.Unlock()
+	return h.enabled
 }
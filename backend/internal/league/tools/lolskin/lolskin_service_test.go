@@ -0,0 +1,107 @@
+package lolskin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Inject(t *testing.T) {
+	testLogger := logger.New("TestLolSkinService", &config.Config{LogLevel: "error"})
+	hudPackage := map[string]string{
+		"META/info.json":        `{"Name": "Clean HUD"}`,
+		"RAW/assets/ux/hud.png": "hud",
+	}
+
+	t.Run("Global mods are injected with the skin and checked for conflicts", func(t *testing.T) {
+		dir := t.TempDir()
+		loaded, err := LoadCatalog(fstest.MapFS{CatalogPath: {Data: []byte(testCatalog)}}, CatalogPath)
+		require.NoError(t, err)
+		catalog := NewCatalogService(testLogger, loaded)
+		resolved, err := catalog.Resolve(99, 99002, nil)
+		require.NoError(t, err)
+		skin := resolved.ModName()
+		// The installed skin replaces the same RAW file as the HUD mod
+		writeModFile(t, filepath.Join(dir, "installed", skin, "RAW", "assets", "ux", "hud.png"))
+
+		customMods := NewCustomMods(testLogger, dir, catalog)
+		hud, err := customMods.ImportGlobal(writeModPackage(t, "hud.fantome", hudPackage))
+		require.NoError(t, err)
+
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventModConflicts, mock.MatchedBy(func(conflicts []ModConflict) bool {
+			return len(conflicts) == 1 && conflicts[0].Wad == rawTarget && conflicts[0].Winner == skin &&
+				assert.ObjectsAreEqual([]string{skin, hud.ID}, conflicts[0].Mods)
+		})).Return().Once()
+
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "profiles"), 0755))
+		patcher := NewPatcher(testLogger, "mod-tools")
+		patcher.command = fakePatcher(t, "inject")
+		defer patcher.Stop()
+		lolSkin := &LolSkin{
+			logger:    testLogger,
+			catalog:   catalog,
+			downloads: NewDownloadManager(testLogger, 1),
+			game:      filepath.Join(dir, "Game", "League of Legends.exe"),
+			patcher:   patcher,
+			conflicts: NewConflictResolver(testLogger, dir, catalog, NewState()),
+			app:       mockApp,
+			tempDir:   dir,
+		}
+		service := NewService(testLogger, nil, nil, lolSkin, NewState())
+		service.SetGlobalMods(customMods)
+		require.NoError(t, service.Inject(ChampionSkin{ChampionID: 99, SkinID: 99002}))
+		waitForState(t, lolSkin.patcher, PatcherInjected)
+
+		profile, err := os.ReadFile(filepath.Join(dir, "profiles", "Default Profile.profile"))
+		require.NoError(t, err)
+		assert.Equal(t, skin+"\n"+hud.ID+"\n", string(profile))
+		assert.DirExists(t, filepath.Join(dir, "profiles", "Default Profile"))
+	})
+
+	t.Run("Prioritized global mods win over the skin", func(t *testing.T) {
+		dir := t.TempDir()
+		loaded, err := LoadCatalog(fstest.MapFS{CatalogPath: {Data: []byte(testCatalog)}}, CatalogPath)
+		require.NoError(t, err)
+		catalog := NewCatalogService(testLogger, loaded)
+		resolved, err := catalog.Resolve(99, 99002, nil)
+		require.NoError(t, err)
+		writeModFile(t, filepath.Join(dir, "installed", resolved.ModName(), "RAW", "assets", "ux", "hud.png"))
+
+		customMods := NewCustomMods(testLogger, dir, catalog)
+		hud, err := customMods.ImportGlobal(writeModPackage(t, "hud.fantome", hudPackage))
+		require.NoError(t, err)
+
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventModConflicts, mock.MatchedBy(func(conflicts []ModConflict) bool {
+			return len(conflicts) == 1 && conflicts[0].Winner == hud.ID
+		})).Return().Once()
+
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "profiles"), 0755))
+		patcher := NewPatcher(testLogger, "mod-tools")
+		patcher.command = fakePatcher(t, "inject")
+		defer patcher.Stop()
+		lolSkin := &LolSkin{
+			logger:    testLogger,
+			catalog:   catalog,
+			downloads: NewDownloadManager(testLogger, 1),
+			game:      filepath.Join(dir, "Game", "League of Legends.exe"),
+			patcher:   patcher,
+			conflicts: NewConflictResolver(testLogger, dir, catalog, NewState()),
+			app:       mockApp,
+			tempDir:   dir,
+		}
+		lolSkin.conflicts.SetPriority([]string{hud.ID})
+		service := NewService(testLogger, nil, nil, lolSkin, NewState())
+		service.SetGlobalMods(customMods)
+		require.NoError(t, service.Inject(ChampionSkin{ChampionID: 99, SkinID: 99002}))
+		waitForState(t, lolSkin.patcher, PatcherInjected)
+	})
+}
@@ -9,12 +9,20 @@ type ChampionSkin struct {
 	ChampionID int32  `json:"championId"`
 	SkinID     int32  `json:"skinId"`
 	ChromaID   *int32 `json:"chromaId,omitempty"` // Optional chroma ID
+	// CustomModID selects an imported mod instead of a catalog skin
+	CustomModID string `json:"customModId,omitempty"`
+}
+
+// IsValid reports whether the selection points to a catalog skin or an imported mod
+func (s ChampionSkin) IsValid() bool {
+	return s.ChampionID > 0 && (s.SkinID > 0 || s.CustomModID != "")
 }
 
 // State stores the selected skins for champions
 type State struct {
 	selections map[int32]ChampionSkin // Map with champion ID as key
 	mutex      sync.RWMutex           // For thread safety
+	onChange   func(selections []ChampionSkin)
 }
 
 // NewState creates a new State instance
@@ -24,21 +32,36 @@ func NewState() *State {
 	}
 }
 
-// UpdateSelections updates multiple champion skin selections at once
-func (s *State) UpdateSelections(selections []ChampionSkin) {
+// SetOnChange registers a callback called with all the selections after every change
+func (s *State) SetOnChange(onChange func(selections []ChampionSkin)) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
+	s.onChange = onChange
+}
 
+// changed calls the onChange callback, it must be called without holding the lock
+func (s *State) changed() {
+	s.mutex.RLock()
+	onChange := s.onChange
+	s.mutex.RUnlock()
+	if onChange != nil {
+		onChange(s.GetAllSelections())
+	}
+}
+
+// UpdateSelections updates multiple champion skin selections at once
+func (s *State) UpdateSelections(selections []ChampionSkin) {
+	s.mutex.Lock()
 	for _, selection := range selections {
 		s.selections[selection.ChampionID] = selection
 	}
+	s.mutex.Unlock()
+	s.changed()
 }
 
 // ReplaceAllSelections replaces all existing selections with the provided ones
 func (s *State) ReplaceAllSelections(selections []ChampionSkin) {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
 	// Clear existing selections
 	s.selections = make(map[int32]ChampionSkin)
 
@@ -46,6 +69,8 @@ func (s *State) ReplaceAllSelections(selections []ChampionSkin) {
 	for _, selection := range selections {
 		s.selections[selection.ChampionID] = selection
 	}
+	s.mutex.Unlock()
+	s.changed()
 }
 
 // GetChampionSkin returns the selected skin for a champion
@@ -72,8 +97,6 @@ func (s *State) GetAllSelections() []ChampionSkin {
 // SetChampionSkin updates or adds a skin selection for a champion
 func (s *State) SetChampionSkin(championID, skinID int32, chromaID *int32) {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
 	// Store previous skin (if any)
 	previousSkin, exists := s.selections[championID]
 
@@ -83,20 +106,39 @@ func (s *State) SetChampionSkin(championID, skinID int32, chromaID *int32) {
 		SkinID:     skinID,
 		ChromaID:   chromaID,
 	}
+	s.mutex.Unlock()
 
-	// Only emit event if this is a change
+	// Only notify if this is a change
 	if !exists || previousSkin.SkinID != skinID ||
 		(previousSkin.ChromaID == nil && chromaID != nil) ||
 		(previousSkin.ChromaID != nil && chromaID == nil) ||
 		(previousSkin.ChromaID != nil && chromaID != nil && *previousSkin.ChromaID != *chromaID) {
+		s.changed()
+	}
+}
+
+// SetCustomMod selects an imported mod for a champion
+func (s *State) SetCustomMod(championID int32, customModID string) {
+	s.mutex.Lock()
+	previous, exists := s.selections[championID]
+	s.selections[championID] = ChampionSkin{
+		ChampionID:  championID,
+		CustomModID: customModID,
+	}
+	s.mutex.Unlock()
 
+	if !exists || previous.CustomModID != customModID {
+		s.changed()
 	}
 }
 
 // RemoveChampionSkin removes a skin selection for a champion
 func (s *State) RemoveChampionSkin(championID int32) {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
+	_, exists := s.selections[championID]
 	delete(s.selections, championID)
+	s.mutex.Unlock()
+	if exists {
+		s.changed()
+	}
 }
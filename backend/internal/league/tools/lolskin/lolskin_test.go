@@ -0,0 +1,46 @@
+package lolskin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeModFile(t *testing.T, path string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("mod"), 0644))
+}
+
+func TestLolSkin_install(t *testing.T) {
+	newLogger := logger.New("TestLolSkin", &config.Config{LogLevel: "error"})
+
+	t.Run("Downloaded skins are installed", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "installed"), 0755))
+		lolSkin := &LolSkin{logger: newLogger, tempDir: dir}
+		zipPath := writeModPackage(t, "99002.zip", map[string]string{"WAD/Lux.wad.client": "skin"})
+
+		require.NoError(t, lolSkin.install(zipPath, "99002"))
+		assert.FileExists(t, filepath.Join(dir, "installed", "99002", "WAD", "Lux.wad.client"))
+		assert.NoFileExists(t, zipPath)
+	})
+
+	t.Run("Entries escaping the mod folder are refused", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "installed"), 0755))
+		lolSkin := &LolSkin{logger: newLogger, tempDir: dir}
+		zipPath := writeModPackage(t, "99002.zip", map[string]string{
+			"WAD/Lux.wad.client":    "skin",
+			"..\\..\\cslol-dll.dll": "evil",
+		})
+
+		assert.ErrorIs(t, lolSkin.install(zipPath, "99002"), ErrInvalidMod)
+		assert.NoDirExists(t, filepath.Join(dir, "installed", "99002"))
+		assert.NoFileExists(t, filepath.Join(filepath.Dir(dir), "cslol-dll.dll"))
+	})
+}
@@ -0,0 +1,92 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package lolskin
+
+import (
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAccountClient is an autogenerated mock type for the AccountClient type
+type MockAccountClient struct {
+	mock.Mock
+}
+
+type MockAccountClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccountClient) EXPECT() *MockAccountClient_Expecter {
+	return &MockAccountClient_Expecter{mock: &_m.Mock}
+}
+
+// UserMe provides a mock function with no fields
+func (_m *MockAccountClient) UserMe() (*types.User, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserMe")
+	}
+
+	var r0 *types.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.User, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.User); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountClient_UserMe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserMe'
+type MockAccountClient_UserMe_Call struct {
+	*mock.Call
+}
+
+// UserMe is a helper method to define mock.On call
+func (_e *MockAccountClient_Expecter) UserMe() *MockAccountClient_UserMe_Call {
+	return &MockAccountClient_UserMe_Call{Call: _e.mock.On("UserMe")}
+}
+
+func (_c *MockAccountClient_UserMe_Call) Run(run func()) *MockAccountClient_UserMe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAccountClient_UserMe_Call) Return(_a0 *types.User, _a1 error) *MockAccountClient_UserMe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountClient_UserMe_Call) RunAndReturn(run func() (*types.User, error)) *MockAccountClient_UserMe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAccountClient creates a new instance of MockAccountClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccountClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccountClient {
+	mock := &MockAccountClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,185 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package lolskin
+
+import (
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAccountState is an autogenerated mock type for the AccountState type
+type MockAccountState struct {
+	mock.Mock
+}
+
+type MockAccountState_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccountState) EXPECT() *MockAccountState_Expecter {
+	return &MockAccountState_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with no fields
+func (_m *MockAccountState) Get() *types.PartialSummonerRented {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *types.PartialSummonerRented
+	if rf, ok := ret.Get(0).(func() *types.PartialSummonerRented); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.PartialSummonerRented)
+		}
+	}
+
+	return r0
+}
+
+// MockAccountState_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockAccountState_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+func (_e *MockAccountState_Expecter) Get() *MockAccountState_Get_Call {
+	return &MockAccountState_Get_Call{Call: _e.mock.On("Get")}
+}
+
+func (_c *MockAccountState_Get_Call) Run(run func()) *MockAccountState_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAccountState_Get_Call) Return(_a0 *types.PartialSummonerRented) *MockAccountState_Get_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAccountState_Get_Call) RunAndReturn(run func() *types.PartialSummonerRented) *MockAccountState_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsNexusAccount provides a mock function with no fields
+func (_m *MockAccountState) IsNexusAccount() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsNexusAccount")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockAccountState_IsNexusAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsNexusAccount'
+type MockAccountState_IsNexusAccount_Call struct {
+	*mock.Call
+}
+
+// IsNexusAccount is a helper method to define mock.On call
+func (_e *MockAccountState_Expecter) IsNexusAccount() *MockAccountState_IsNexusAccount_Call {
+	return &MockAccountState_IsNexusAccount_Call{Call: _e.mock.On("IsNexusAccount")}
+}
+
+func (_c *MockAccountState_IsNexusAccount_Call) Run(run func()) *MockAccountState_IsNexusAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAccountState_IsNexusAccount_Call) Return(_a0 bool) *MockAccountState_IsNexusAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAccountState_IsNexusAccount_Call) RunAndReturn(run func() bool) *MockAccountState_IsNexusAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: update
+func (_m *MockAccountState) Update(update *types.PartialSummonerRented) (*types.PartialSummonerRented, error) {
+	ret := _m.Called(update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *types.PartialSummonerRented
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*types.PartialSummonerRented) (*types.PartialSummonerRented, error)); ok {
+		return rf(update)
+	}
+	if rf, ok := ret.Get(0).(func(*types.PartialSummonerRented) *types.PartialSummonerRented); ok {
+		r0 = rf(update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.PartialSummonerRented)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*types.PartialSummonerRented) error); ok {
+		r1 = rf(update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountState_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockAccountState_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - update *types.PartialSummonerRented
+func (_e *MockAccountState_Expecter) Update(update interface{}) *MockAccountState_Update_Call {
+	return &MockAccountState_Update_Call{Call: _e.mock.On("Update", update)}
+}
+
+func (_c *MockAccountState_Update_Call) Run(run func(update *types.PartialSummonerRented)) *MockAccountState_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*types.PartialSummonerRented))
+	})
+	return _c
+}
+
+func (_c *MockAccountState_Update_Call) Return(_a0 *types.PartialSummonerRented, _a1 error) *MockAccountState_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountState_Update_Call) RunAndReturn(run func(*types.PartialSummonerRented) (*types.PartialSummonerRented, error)) *MockAccountState_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAccountState creates a new instance of MockAccountState. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccountState(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccountState {
+	mock := &MockAccountState{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,111 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package lolskin
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockChampionInjector is an autogenerated mock type for the ChampionInjector type
+type MockChampionInjector struct {
+	mock.Mock
+}
+
+type MockChampionInjector_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChampionInjector) EXPECT() *MockChampionInjector_Expecter {
+	return &MockChampionInjector_Expecter{mock: &_m.Mock}
+}
+
+// Inject provides a mock function with given fields: selection
+func (_m *MockChampionInjector) Inject(selection ChampionSkin) error {
+	ret := _m.Called(selection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Inject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ChampionSkin) error); ok {
+		r0 = rf(selection)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockChampionInjector_Inject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Inject'
+type MockChampionInjector_Inject_Call struct {
+	*mock.Call
+}
+
+// Inject is a helper method to define mock.On call
+//   - selection ChampionSkin
+func (_e *MockChampionInjector_Expecter) Inject(selection interface{}) *MockChampionInjector_Inject_Call {
+	return &MockChampionInjector_Inject_Call{Call: _e.mock.On("Inject", selection)}
+}
+
+func (_c *MockChampionInjector_Inject_Call) Run(run func(selection ChampionSkin)) *MockChampionInjector_Inject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(ChampionSkin))
+	})
+	return _c
+}
+
+func (_c *MockChampionInjector_Inject_Call) Return(_a0 error) *MockChampionInjector_Inject_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChampionInjector_Inject_Call) RunAndReturn(run func(ChampionSkin) error) *MockChampionInjector_Inject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Prefetch provides a mock function with given fields: selection
+func (_m *MockChampionInjector) Prefetch(selection ChampionSkin) {
+	_m.Called(selection)
+}
+
+// MockChampionInjector_Prefetch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Prefetch'
+type MockChampionInjector_Prefetch_Call struct {
+	*mock.Call
+}
+
+// Prefetch is a helper method to define mock.On call
+//   - selection ChampionSkin
+func (_e *MockChampionInjector_Expecter) Prefetch(selection interface{}) *MockChampionInjector_Prefetch_Call {
+	return &MockChampionInjector_Prefetch_Call{Call: _e.mock.On("Prefetch", selection)}
+}
+
+func (_c *MockChampionInjector_Prefetch_Call) Run(run func(selection ChampionSkin)) *MockChampionInjector_Prefetch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(ChampionSkin))
+	})
+	return _c
+}
+
+func (_c *MockChampionInjector_Prefetch_Call) Return() *MockChampionInjector_Prefetch_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockChampionInjector_Prefetch_Call) RunAndReturn(run func(ChampionSkin)) *MockChampionInjector_Prefetch_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockChampionInjector creates a new instance of MockChampionInjector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChampionInjector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChampionInjector {
+	mock := &MockChampionInjector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,168 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package lolskin
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockLolSkinState is an autogenerated mock type for the LolSkinState type
+type MockLolSkinState struct {
+	mock.Mock
+}
+
+type MockLolSkinState_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLolSkinState) EXPECT() *MockLolSkinState_Expecter {
+	return &MockLolSkinState_Expecter{mock: &_m.Mock}
+}
+
+// GetAllSelections provides a mock function with no fields
+func (_m *MockLolSkinState) GetAllSelections() []ChampionSkin {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllSelections")
+	}
+
+	var r0 []ChampionSkin
+	if rf, ok := ret.Get(0).(func() []ChampionSkin); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ChampionSkin)
+		}
+	}
+
+	return r0
+}
+
+// MockLolSkinState_GetAllSelections_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllSelections'
+type MockLolSkinState_GetAllSelections_Call struct {
+	*mock.Call
+}
+
+// GetAllSelections is a helper method to define mock.On call
+func (_e *MockLolSkinState_Expecter) GetAllSelections() *MockLolSkinState_GetAllSelections_Call {
+	return &MockLolSkinState_GetAllSelections_Call{Call: _e.mock.On("GetAllSelections")}
+}
+
+func (_c *MockLolSkinState_GetAllSelections_Call) Run(run func()) *MockLolSkinState_GetAllSelections_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockLolSkinState_GetAllSelections_Call) Return(_a0 []ChampionSkin) *MockLolSkinState_GetAllSelections_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLolSkinState_GetAllSelections_Call) RunAndReturn(run func() []ChampionSkin) *MockLolSkinState_GetAllSelections_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChampionSkin provides a mock function with given fields: championID
+func (_m *MockLolSkinState) GetChampionSkin(championID int32) (ChampionSkin, bool) {
+	ret := _m.Called(championID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChampionSkin")
+	}
+
+	var r0 ChampionSkin
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(int32) (ChampionSkin, bool)); ok {
+		return rf(championID)
+	}
+	if rf, ok := ret.Get(0).(func(int32) ChampionSkin); ok {
+		r0 = rf(championID)
+	} else {
+		r0 = ret.Get(0).(ChampionSkin)
+	}
+
+	if rf, ok := ret.Get(1).(func(int32) bool); ok {
+		r1 = rf(championID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockLolSkinState_GetChampionSkin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChampionSkin'
+type MockLolSkinState_GetChampionSkin_Call struct {
+	*mock.Call
+}
+
+// GetChampionSkin is a helper method to define mock.On call
+//   - championID int32
+func (_e *MockLolSkinState_Expecter) GetChampionSkin(championID interface{}) *MockLolSkinState_GetChampionSkin_Call {
+	return &MockLolSkinState_GetChampionSkin_Call{Call: _e.mock.On("GetChampionSkin", championID)}
+}
+
+func (_c *MockLolSkinState_GetChampionSkin_Call) Run(run func(championID int32)) *MockLolSkinState_GetChampionSkin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int32))
+	})
+	return _c
+}
+
+func (_c *MockLolSkinState_GetChampionSkin_Call) Return(_a0 ChampionSkin, _a1 bool) *MockLolSkinState_GetChampionSkin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLolSkinState_GetChampionSkin_Call) RunAndReturn(run func(int32) (ChampionSkin, bool)) *MockLolSkinState_GetChampionSkin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSelections provides a mock function with given fields: selections
+func (_m *MockLolSkinState) UpdateSelections(selections []ChampionSkin) {
+	_m.Called(selections)
+}
+
+// MockLolSkinState_UpdateSelections_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSelections'
+type MockLolSkinState_UpdateSelections_Call struct {
+	*mock.Call
+}
+
+// UpdateSelections is a helper method to define mock.On call
+//   - selections []ChampionSkin
+func (_e *MockLolSkinState_Expecter) UpdateSelections(selections interface{}) *MockLolSkinState_UpdateSelections_Call {
+	return &MockLolSkinState_UpdateSelections_Call{Call: _e.mock.On("UpdateSelections", selections)}
+}
+
+func (_c *MockLolSkinState_UpdateSelections_Call) Run(run func(selections []ChampionSkin)) *MockLolSkinState_UpdateSelections_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]ChampionSkin))
+	})
+	return _c
+}
+
+func (_c *MockLolSkinState_UpdateSelections_Call) Return() *MockLolSkinState_UpdateSelections_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockLolSkinState_UpdateSelections_Call) RunAndReturn(run func([]ChampionSkin)) *MockLolSkinState_UpdateSelections_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockLolSkinState creates a new instance of MockLolSkinState. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLolSkinState(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLolSkinState {
+	mock := &MockLolSkinState{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
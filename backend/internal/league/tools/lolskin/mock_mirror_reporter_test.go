@@ -0,0 +1,66 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package lolskin
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockMirrorReporter is an autogenerated mock type for the MirrorReporter type
+type MockMirrorReporter struct {
+	mock.Mock
+}
+
+type MockMirrorReporter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMirrorReporter) EXPECT() *MockMirrorReporter_Expecter {
+	return &MockMirrorReporter_Expecter{mock: &_m.Mock}
+}
+
+// ReportMirror provides a mock function with given fields: source, err
+func (_m *MockMirrorReporter) ReportMirror(source string, err error) {
+	_m.Called(source, err)
+}
+
+// MockMirrorReporter_ReportMirror_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReportMirror'
+type MockMirrorReporter_ReportMirror_Call struct {
+	*mock.Call
+}
+
+// ReportMirror is a helper method to define mock.On call
+//   - source string
+//   - err error
+func (_e *MockMirrorReporter_Expecter) ReportMirror(source interface{}, err interface{}) *MockMirrorReporter_ReportMirror_Call {
+	return &MockMirrorReporter_ReportMirror_Call{Call: _e.mock.On("ReportMirror", source, err)}
+}
+
+func (_c *MockMirrorReporter_ReportMirror_Call) Run(run func(source string, err error)) *MockMirrorReporter_ReportMirror_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(error))
+	})
+	return _c
+}
+
+func (_c *MockMirrorReporter_ReportMirror_Call) Return() *MockMirrorReporter_ReportMirror_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMirrorReporter_ReportMirror_Call) RunAndReturn(run func(string, error)) *MockMirrorReporter_ReportMirror_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockMirrorReporter creates a new instance of MockMirrorReporter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMirrorReporter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMirrorReporter {
+	mock := &MockMirrorReporter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,145 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package lolskin
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockProfileState is an autogenerated mock type for the ProfileState type
+type MockProfileState struct {
+	mock.Mock
+}
+
+type MockProfileState_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProfileState) EXPECT() *MockProfileState_Expecter {
+	return &MockProfileState_Expecter{mock: &_m.Mock}
+}
+
+// GetAllSelections provides a mock function with no fields
+func (_m *MockProfileState) GetAllSelections() []ChampionSkin {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllSelections")
+	}
+
+	var r0 []ChampionSkin
+	if rf, ok := ret.Get(0).(func() []ChampionSkin); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ChampionSkin)
+		}
+	}
+
+	return r0
+}
+
+// MockProfileState_GetAllSelections_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllSelections'
+type MockProfileState_GetAllSelections_Call struct {
+	*mock.Call
+}
+
+// GetAllSelections is a helper method to define mock.On call
+func (_e *MockProfileState_Expecter) GetAllSelections() *MockProfileState_GetAllSelections_Call {
+	return &MockProfileState_GetAllSelections_Call{Call: _e.mock.On("GetAllSelections")}
+}
+
+func (_c *MockProfileState_GetAllSelections_Call) Run(run func()) *MockProfileState_GetAllSelections_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockProfileState_GetAllSelections_Call) Return(_a0 []ChampionSkin) *MockProfileState_GetAllSelections_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProfileState_GetAllSelections_Call) RunAndReturn(run func() []ChampionSkin) *MockProfileState_GetAllSelections_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceAllSelections provides a mock function with given fields: selections
+func (_m *MockProfileState) ReplaceAllSelections(selections []ChampionSkin) {
+	_m.Called(selections)
+}
+
+// MockProfileState_ReplaceAllSelections_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceAllSelections'
+type MockProfileState_ReplaceAllSelections_Call struct {
+	*mock.Call
+}
+
+// ReplaceAllSelections is a helper method to define mock.On call
+//   - selections []ChampionSkin
+func (_e *MockProfileState_Expecter) ReplaceAllSelections(selections interface{}) *MockProfileState_ReplaceAllSelections_Call {
+	return &MockProfileState_ReplaceAllSelections_Call{Call: _e.mock.On("ReplaceAllSelections", selections)}
+}
+
+func (_c *MockProfileState_ReplaceAllSelections_Call) Run(run func(selections []ChampionSkin)) *MockProfileState_ReplaceAllSelections_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]ChampionSkin))
+	})
+	return _c
+}
+
+func (_c *MockProfileState_ReplaceAllSelections_Call) Return() *MockProfileState_ReplaceAllSelections_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockProfileState_ReplaceAllSelections_Call) RunAndReturn(run func([]ChampionSkin)) *MockProfileState_ReplaceAllSelections_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetOnChange provides a mock function with given fields: onChange
+func (_m *MockProfileState) SetOnChange(onChange func([]ChampionSkin)) {
+	_m.Called(onChange)
+}
+
+// MockProfileState_SetOnChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOnChange'
+type MockProfileState_SetOnChange_Call struct {
+	*mock.Call
+}
+
+// SetOnChange is a helper method to define mock.On call
+//   - onChange func([]ChampionSkin)
+func (_e *MockProfileState_Expecter) SetOnChange(onChange interface{}) *MockProfileState_SetOnChange_Call {
+	return &MockProfileState_SetOnChange_Call{Call: _e.mock.On("SetOnChange", onChange)}
+}
+
+func (_c *MockProfileState_SetOnChange_Call) Run(run func(onChange func([]ChampionSkin))) *MockProfileState_SetOnChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(func([]ChampionSkin)))
+	})
+	return _c
+}
+
+func (_c *MockProfileState_SetOnChange_Call) Return() *MockProfileState_SetOnChange_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockProfileState_SetOnChange_Call) RunAndReturn(run func(func([]ChampionSkin))) *MockProfileState_SetOnChange_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockProfileState creates a new instance of MockProfileState. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProfileState(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProfileState {
+	mock := &MockProfileState{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,78 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package lolskin
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockProfileSync is an autogenerated mock type for the ProfileSync type
+type MockProfileSync struct {
+	mock.Mock
+}
+
+type MockProfileSync_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProfileSync) EXPECT() *MockProfileSync_Expecter {
+	return &MockProfileSync_Expecter{mock: &_m.Mock}
+}
+
+// SaveSkinProfiles provides a mock function with given fields: profiles
+func (_m *MockProfileSync) SaveSkinProfiles(profiles []SkinProfile) error {
+	ret := _m.Called(profiles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSkinProfiles")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]SkinProfile) error); ok {
+		r0 = rf(profiles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProfileSync_SaveSkinProfiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSkinProfiles'
+type MockProfileSync_SaveSkinProfiles_Call struct {
+	*mock.Call
+}
+
+// SaveSkinProfiles is a helper method to define mock.On call
+//   - profiles []SkinProfile
+func (_e *MockProfileSync_Expecter) SaveSkinProfiles(profiles interface{}) *MockProfileSync_SaveSkinProfiles_Call {
+	return &MockProfileSync_SaveSkinProfiles_Call{Call: _e.mock.On("SaveSkinProfiles", profiles)}
+}
+
+func (_c *MockProfileSync_SaveSkinProfiles_Call) Run(run func(profiles []SkinProfile)) *MockProfileSync_SaveSkinProfiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]SkinProfile))
+	})
+	return _c
+}
+
+func (_c *MockProfileSync_SaveSkinProfiles_Call) Return(_a0 error) *MockProfileSync_SaveSkinProfiles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProfileSync_SaveSkinProfiles_Call) RunAndReturn(run func([]SkinProfile) error) *MockProfileSync_SaveSkinProfiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProfileSync creates a new instance of MockProfileSync. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProfileSync(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProfileSync {
+	mock := &MockProfileSync{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
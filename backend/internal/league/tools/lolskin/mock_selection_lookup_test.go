@@ -0,0 +1,88 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package lolskin
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockSelectionLookup is an autogenerated mock type for the SelectionLookup type
+type MockSelectionLookup struct {
+	mock.Mock
+}
+
+type MockSelectionLookup_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSelectionLookup) EXPECT() *MockSelectionLookup_Expecter {
+	return &MockSelectionLookup_Expecter{mock: &_m.Mock}
+}
+
+// GetChampionSkin provides a mock function with given fields: championID
+func (_m *MockSelectionLookup) GetChampionSkin(championID int32) (ChampionSkin, bool) {
+	ret := _m.Called(championID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChampionSkin")
+	}
+
+	var r0 ChampionSkin
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(int32) (ChampionSkin, bool)); ok {
+		return rf(championID)
+	}
+	if rf, ok := ret.Get(0).(func(int32) ChampionSkin); ok {
+		r0 = rf(championID)
+	} else {
+		r0 = ret.Get(0).(ChampionSkin)
+	}
+
+	if rf, ok := ret.Get(1).(func(int32) bool); ok {
+		r1 = rf(championID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockSelectionLookup_GetChampionSkin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChampionSkin'
+type MockSelectionLookup_GetChampionSkin_Call struct {
+	*mock.Call
+}
+
+// GetChampionSkin is a helper method to define mock.On call
+//   - championID int32
+func (_e *MockSelectionLookup_Expecter) GetChampionSkin(championID interface{}) *MockSelectionLookup_GetChampionSkin_Call {
+	return &MockSelectionLookup_GetChampionSkin_Call{Call: _e.mock.On("GetChampionSkin", championID)}
+}
+
+func (_c *MockSelectionLookup_GetChampionSkin_Call) Run(run func(championID int32)) *MockSelectionLookup_GetChampionSkin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int32))
+	})
+	return _c
+}
+
+func (_c *MockSelectionLookup_GetChampionSkin_Call) Return(_a0 ChampionSkin, _a1 bool) *MockSelectionLookup_GetChampionSkin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSelectionLookup_GetChampionSkin_Call) RunAndReturn(run func(int32) (ChampionSkin, bool)) *MockSelectionLookup_GetChampionSkin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSelectionLookup creates a new instance of MockSelectionLookup. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSelectionLookup(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSelectionLookup {
+	mock := &MockSelectionLookup{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,79 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package lolskin
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockSelectionSource is an autogenerated mock type for the SelectionSource type
+type MockSelectionSource struct {
+	mock.Mock
+}
+
+type MockSelectionSource_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSelectionSource) EXPECT() *MockSelectionSource_Expecter {
+	return &MockSelectionSource_Expecter{mock: &_m.Mock}
+}
+
+// GetAllSelections provides a mock function with no fields
+func (_m *MockSelectionSource) GetAllSelections() []ChampionSkin {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllSelections")
+	}
+
+	var r0 []ChampionSkin
+	if rf, ok := ret.Get(0).(func() []ChampionSkin); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ChampionSkin)
+		}
+	}
+
+	return r0
+}
+
+// MockSelectionSource_GetAllSelections_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllSelections'
+type MockSelectionSource_GetAllSelections_Call struct {
+	*mock.Call
+}
+
+// GetAllSelections is a helper method to define mock.On call
+func (_e *MockSelectionSource_Expecter) GetAllSelections() *MockSelectionSource_GetAllSelections_Call {
+	return &MockSelectionSource_GetAllSelections_Call{Call: _e.mock.On("GetAllSelections")}
+}
+
+func (_c *MockSelectionSource_GetAllSelections_Call) Run(run func()) *MockSelectionSource_GetAllSelections_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSelectionSource_GetAllSelections_Call) Return(_a0 []ChampionSkin) *MockSelectionSource_GetAllSelections_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSelectionSource_GetAllSelections_Call) RunAndReturn(run func() []ChampionSkin) *MockSelectionSource_GetAllSelections_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSelectionSource creates a new instance of MockSelectionSource. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSelectionSource(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSelectionSource {
+	mock := &MockSelectionSource{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,133 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package lolskin
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockSkinSource is an autogenerated mock type for the SkinSource type
+type MockSkinSource struct {
+	mock.Mock
+}
+
+type MockSkinSource_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSkinSource) EXPECT() *MockSkinSource_Expecter {
+	return &MockSkinSource_Expecter{mock: &_m.Mock}
+}
+
+// Name provides a mock function with no fields
+func (_m *MockSkinSource) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockSkinSource_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type MockSkinSource_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+func (_e *MockSkinSource_Expecter) Name() *MockSkinSource_Name_Call {
+	return &MockSkinSource_Name_Call{Call: _e.mock.On("Name")}
+}
+
+func (_c *MockSkinSource_Name_Call) Run(run func()) *MockSkinSource_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSkinSource_Name_Call) Return(_a0 string) *MockSkinSource_Name_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSkinSource_Name_Call) RunAndReturn(run func() string) *MockSkinSource_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// URL provides a mock function with given fields: skin
+func (_m *MockSkinSource) URL(skin ResolvedSkin) (string, bool) {
+	ret := _m.Called(skin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for URL")
+	}
+
+	var r0 string
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(ResolvedSkin) (string, bool)); ok {
+		return rf(skin)
+	}
+	if rf, ok := ret.Get(0).(func(ResolvedSkin) string); ok {
+		r0 = rf(skin)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(ResolvedSkin) bool); ok {
+		r1 = rf(skin)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockSkinSource_URL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'URL'
+type MockSkinSource_URL_Call struct {
+	*mock.Call
+}
+
+// URL is a helper method to define mock.On call
+//   - skin ResolvedSkin
+func (_e *MockSkinSource_Expecter) URL(skin interface{}) *MockSkinSource_URL_Call {
+	return &MockSkinSource_URL_Call{Call: _e.mock.On("URL", skin)}
+}
+
+func (_c *MockSkinSource_URL_Call) Run(run func(skin ResolvedSkin)) *MockSkinSource_URL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(ResolvedSkin))
+	})
+	return _c
+}
+
+func (_c *MockSkinSource_URL_Call) Return(_a0 string, _a1 bool) *MockSkinSource_URL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSkinSource_URL_Call) RunAndReturn(run func(ResolvedSkin) (string, bool)) *MockSkinSource_URL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSkinSource creates a new instance of MockSkinSource. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSkinSource(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSkinSource {
+	mock := &MockSkinSource{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
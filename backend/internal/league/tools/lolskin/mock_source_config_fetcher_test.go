@@ -0,0 +1,89 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package lolskin
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockSourceConfigFetcher is an autogenerated mock type for the SourceConfigFetcher type
+type MockSourceConfigFetcher struct {
+	mock.Mock
+}
+
+type MockSourceConfigFetcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSourceConfigFetcher) EXPECT() *MockSourceConfigFetcher_Expecter {
+	return &MockSourceConfigFetcher_Expecter{mock: &_m.Mock}
+}
+
+// GetSkinSources provides a mock function with no fields
+func (_m *MockSourceConfigFetcher) GetSkinSources() (*SkinSourcesConfig, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSkinSources")
+	}
+
+	var r0 *SkinSourcesConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*SkinSourcesConfig, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *SkinSourcesConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*SkinSourcesConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSourceConfigFetcher_GetSkinSources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSkinSources'
+type MockSourceConfigFetcher_GetSkinSources_Call struct {
+	*mock.Call
+}
+
+// GetSkinSources is a helper method to define mock.On call
+func (_e *MockSourceConfigFetcher_Expecter) GetSkinSources() *MockSourceConfigFetcher_GetSkinSources_Call {
+	return &MockSourceConfigFetcher_GetSkinSources_Call{Call: _e.mock.On("GetSkinSources")}
+}
+
+func (_c *MockSourceConfigFetcher_GetSkinSources_Call) Run(run func()) *MockSourceConfigFetcher_GetSkinSources_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSourceConfigFetcher_GetSkinSources_Call) Return(_a0 *SkinSourcesConfig, _a1 error) *MockSourceConfigFetcher_GetSkinSources_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSourceConfigFetcher_GetSkinSources_Call) RunAndReturn(run func() (*SkinSourcesConfig, error)) *MockSourceConfigFetcher_GetSkinSources_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSourceConfigFetcher creates a new instance of MockSourceConfigFetcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSourceConfigFetcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSourceConfigFetcher {
+	mock := &MockSourceConfigFetcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
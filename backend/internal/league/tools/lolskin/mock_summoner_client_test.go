@@ -0,0 +1,149 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package lolskin
+
+import (
+	types "github.com/hex-boost/hex-nexus-app/backend/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSummonerClient is an autogenerated mock type for the SummonerClient type
+type MockSummonerClient struct {
+	mock.Mock
+}
+
+type MockSummonerClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSummonerClient) EXPECT() *MockSummonerClient_Expecter {
+	return &MockSummonerClient_Expecter{mock: &_m.Mock}
+}
+
+// GetLeaverBuster provides a mock function with no fields
+func (_m *MockSummonerClient) GetLeaverBuster() (*types.LeaverBusterResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeaverBuster")
+	}
+
+	var r0 *types.LeaverBusterResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.LeaverBusterResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.LeaverBusterResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.LeaverBusterResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSummonerClient_GetLeaverBuster_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaverBuster'
+type MockSummonerClient_GetLeaverBuster_Call struct {
+	*mock.Call
+}
+
+// GetLeaverBuster is a helper method to define mock.On call
+func (_e *MockSummonerClient_Expecter) GetLeaverBuster() *MockSummonerClient_GetLeaverBuster_Call {
+	return &MockSummonerClient_GetLeaverBuster_Call{Call: _e.mock.On("GetLeaverBuster")}
+}
+
+func (_c *MockSummonerClient_GetLeaverBuster_Call) Run(run func()) *MockSummonerClient_GetLeaverBuster_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSummonerClient_GetLeaverBuster_Call) Return(_a0 *types.LeaverBusterResponse, _a1 error) *MockSummonerClient_GetLeaverBuster_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSummonerClient_GetLeaverBuster_Call) RunAndReturn(run func() (*types.LeaverBusterResponse, error)) *MockSummonerClient_GetLeaverBuster_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRanking provides a mock function with no fields
+func (_m *MockSummonerClient) GetRanking() (*types.RankedStatsRefresh, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRanking")
+	}
+
+	var r0 *types.RankedStatsRefresh
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.RankedStatsRefresh, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.RankedStatsRefresh); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RankedStatsRefresh)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSummonerClient_GetRanking_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRanking'
+type MockSummonerClient_GetRanking_Call struct {
+	*mock.Call
+}
+
+// GetRanking is a helper method to define mock.On call
+func (_e *MockSummonerClient_Expecter) GetRanking() *MockSummonerClient_GetRanking_Call {
+	return &MockSummonerClient_GetRanking_Call{Call: _e.mock.On("GetRanking")}
+}
+
+func (_c *MockSummonerClient_GetRanking_Call) Run(run func()) *MockSummonerClient_GetRanking_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSummonerClient_GetRanking_Call) Return(_a0 *types.RankedStatsRefresh, _a1 error) *MockSummonerClient_GetRanking_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSummonerClient_GetRanking_Call) RunAndReturn(run func() (*types.RankedStatsRefresh, error)) *MockSummonerClient_GetRanking_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSummonerClient creates a new instance of MockSummonerClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSummonerClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSummonerClient {
+	mock := &MockSummonerClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,386 @@
+package lolskin
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/command"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// EventPatcherStatus carries a PatcherStatus every time the patcher changes state or prints a line
+const EventPatcherStatus = "lolskin:patcher:status"
+
+// Patcher states
+const (
+	PatcherIdle            = "idle"
+	PatcherBuildingOverlay = "building_overlay"
+	PatcherRunning         = "running"
+	PatcherWaitingForGame  = "waiting_for_game"
+	PatcherInjected        = "injected"
+	PatcherCrashed         = "crashed"
+	PatcherStopped         = "stopped"
+)
+
+// Patcher output streams
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+var ErrPatcherBusy = errors.New("patcher is already building an overlay")
+
+// PatcherStatus is a state change or an output line of the patcher
+type PatcherStatus struct {
+	State    string    `json:"state"`
+	Message  string    `json:"message,omitempty"`
+	Stream   string    `json:"stream,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	Restarts int       `json:"restarts"`
+	At       time.Time `json:"at"`
+}
+
+// patcherMessages maps the mod-tools runoverlay output to the state it means, checked in order
+var patcherMessages = []struct {
+	match string
+	state string
+}{
+	{"waiting for league match to start", PatcherWaitingForGame},
+	{"waiting for exit", PatcherInjected},
+	{"waiting for league to exit", PatcherInjected},
+}
+
+// ParsePatcherLine returns the state an output line of the patcher moves to, false when it doesn't change it
+func ParsePatcherLine(line string) (string, bool) {
+	lower := strings.ToLower(line)
+	for _, message := range patcherMessages {
+		if strings.Contains(lower, message.match) {
+			return message.state, true
+		}
+	}
+	return "", false
+}
+
+// isErrorLine reports whether a line of the patcher is an error, mod-tools prints some of them to stdout
+func isErrorLine(stream, line string) bool {
+	lower := strings.ToLower(line)
+	return stream == StreamStderr || strings.HasPrefix(lower, "[error]") || strings.HasPrefix(lower, "error")
+}
+
+// Patcher supervises the mod-tools process, it builds the overlay, runs the patcher, restarts it with
+// backoff when it exits on its own and stops it through stdin before killing it
+type Patcher struct {
+	logger      logger.Loggerer
+	command     func(args ...string) *exec.Cmd
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	maxRestarts int
+	// stableAfter is how long a run must last for its restarts to be forgotten
+	stableAfter time.Duration
+	gracePeriod time.Duration
+
+	mutex    sync.Mutex
+	app      AppEmitter
+	status   PatcherStatus
+	stdin    io.WriteCloser
+	process  *exec.Cmd
+	stop     chan struct{}
+	done     chan struct{}
+	stopping bool
+	building bool
+}
+
+func NewPatcher(logger logger.Loggerer, executable string) *Patcher {
+	commander := command.New()
+	return &Patcher{
+		logger: logger,
+		command: func(args ...string) *exec.Cmd {
+			return commander.Exec(executable, args...)
+		},
+		backoff:     time.Second,
+		maxBackoff:  30 * time.Second,
+		maxRestarts: 5,
+		stableAfter: time.Minute,
+		gracePeriod: 2 * time.Second,
+		status:      PatcherStatus{State: PatcherIdle},
+	}
+}
+
+func (p *Patcher) SetApp(app AppEmitter) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.app = app
+}
+
+// Status returns the current state of the patcher
+func (p *Patcher) Status() PatcherStatus {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.status
+}
+
+// Start stops the running patcher, builds the overlay and supervises a new patcher with runArgs. The
+// overlay output is also written to overlayLog when it's set
+func (p *Patcher) Start(overlayArgs, runArgs []string, overlayLog io.Writer) error {
+	p.Stop()
+	if err := p.Build(overlayArgs, overlayLog); err != nil {
+		return err
+	}
+	p.Run(runArgs)
+	return nil
+}
+
+// Build builds an overlay without touching the running patcher, when it fails the patcher keeps running
+// the overlay it was started with
+func (p *Patcher) Build(overlayArgs []string, overlayLog io.Writer) error {
+	p.mutex.Lock()
+	if p.building {
+		p.mutex.Unlock()
+		return ErrPatcherBusy
+	}
+	p.building = true
+	previous := p.status
+	p.status = PatcherStatus{State: PatcherBuildingOverlay, Restarts: previous.Restarts, At: time.Now()}
+	status, app := p.status, p.app
+	p.mutex.Unlock()
+	if app != nil {
+		app.EmitEvent(EventPatcherStatus, status)
+	}
+
+	err := p.buildOverlay(overlayArgs, overlayLog)
+	p.mutex.Lock()
+	p.building = false
+	p.mutex.Unlock()
+	if err != nil {
+		p.setState(previous.State, previous.Restarts, err)
+		return err
+	}
+	return nil
+}
+
+// Run stops the running patcher and supervises a new one with runArgs
+func (p *Patcher) Run(runArgs []string) {
+	p.Stop()
+
+	p.mutex.Lock()
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	p.stopping = false
+	stop, done := p.stop, p.done
+	p.mutex.Unlock()
+
+	go p.supervise(runArgs, stop, done)
+}
+
+// IsRunning reports whether a patcher is being supervised
+func (p *Patcher) IsRunning() bool {
+	p.mutex.Lock()
+	done := p.done
+	p.mutex.Unlock()
+	if done == nil {
+		return false
+	}
+	select {
+	case <-done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (p *Patcher) buildOverlay(args []string, overlayLog io.Writer) error {
+	cmd := p.command(args...)
+	var output strings.Builder
+	if overlayLog != nil {
+		cmd.Stdout = io.MultiWriter(&output, overlayLog)
+	} else {
+		cmd.Stdout = &output
+	}
+	cmd.Stderr = cmd.Stdout
+
+	p.logger.Debug("Building overlay", zap.Strings("args", args))
+	if err := cmd.Run(); err != nil {
+		p.logger.Error("Failed to build overlay", zap.Error(err), zap.String("output", output.String()))
+		return fmt.Errorf("failed to build overlay: %w - %s", err, strings.TrimSpace(output.String()))
+	}
+	p.logger.Info("Overlay built", zap.String("output", output.String()))
+	return nil
+}
+
+// supervise runs the patcher until it's stopped, restarting it when it exits on its own
+func (p *Patcher) supervise(args []string, stop, done chan struct{}) {
+	defer close(done)
+	restarts := 0
+	for {
+		started := time.Now()
+		err := p.run(args, restarts)
+
+		p.mutex.Lock()
+		stopping := p.stopping
+		p.mutex.Unlock()
+		if stopping {
+			p.setState(PatcherStopped, restarts, nil)
+			return
+		}
+
+		if err == nil {
+			err = errors.New("patcher exited on its own")
+		}
+		if time.Since(started) >= p.stableAfter {
+			restarts = 0
+		}
+		if restarts >= p.maxRestarts {
+			p.logger.Error("Patcher keeps crashing, giving up", zap.Int("restarts", restarts), zap.Error(err))
+			p.setState(PatcherCrashed, restarts, err)
+			return
+		}
+		delay := min(p.backoff<<restarts, p.maxBackoff)
+		restarts++
+		p.logger.Error("Patcher crashed, restarting", zap.Error(err), zap.Int("restart", restarts), zap.Duration("delay", delay))
+		p.setState(PatcherCrashed, restarts, err)
+
+		select {
+		case <-time.After(delay):
+		case <-stop:
+			p.setState(PatcherStopped, restarts, nil)
+			return
+		}
+	}
+}
+
+// run starts the patcher once and blocks until it exits
+func (p *Patcher) run(args []string, restarts int) error {
+	cmd := p.command(args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+
+	p.logger.Info("Starting patcher process", zap.Strings("args", args))
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start patcher: %w", err)
+	}
+	p.mutex.Lock()
+	p.process = cmd
+	p.stdin = stdin
+	p.mutex.Unlock()
+	p.setState(PatcherRunning, restarts, nil)
+
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go p.read(stdout, StreamStdout, restarts, &readers)
+	go p.read(stderr, StreamStderr, restarts, &readers)
+	// The pipes must be drained before Wait closes them
+	readers.Wait()
+	err = cmd.Wait()
+
+	p.mutex.Lock()
+	p.process = nil
+	p.stdin = nil
+	p.mutex.Unlock()
+	return err
+}
+
+func (p *Patcher) read(reader io.Reader, stream string, restarts int, readers *sync.WaitGroup) {
+	defer readers.Done()
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		status := PatcherStatus{Message: line, Stream: stream, Restarts: restarts}
+		if isErrorLine(stream, line) {
+			p.logger.Error("Patcher error", zap.String("message", line))
+			status.Error = line
+		} else {
+			p.logger.Info("Patcher output", zap.String("message", line))
+		}
+
+		p.mutex.Lock()
+		if state, ok := ParsePatcherLine(line); ok && !p.stopping {
+			p.status.State = state
+		}
+		status.State = p.status.State
+		status.At = time.Now()
+		p.status = status
+		app := p.app
+		p.mutex.Unlock()
+
+		if app != nil {
+			app.EmitEvent(EventPatcherStatus, status)
+		}
+	}
+}
+
+// Stop asks the patcher to exit through stdin and kills it when it doesn't within the grace period
+func (p *Patcher) Stop() {
+	p.mutex.Lock()
+	if p.done == nil || p.stopping {
+		done := p.done
+		p.mutex.Unlock()
+		if done != nil {
+			<-done
+		}
+		return
+	}
+	p.stopping = true
+	close(p.stop)
+	stdin, done := p.stdin, p.done
+	p.mutex.Unlock()
+
+	if stdin != nil {
+		p.logger.Info("Sending termination signal via stdin")
+		if _, err := stdin.Write([]byte("\n")); err != nil {
+			p.logger.Debug("Failed to write to patcher stdin", zap.Error(err))
+		}
+	}
+	grace := time.After(p.gracePeriod)
+	for {
+		select {
+		case <-done:
+			p.logger.Info("Patcher stopped")
+			return
+		case <-grace:
+			p.mutex.Lock()
+			process := p.process
+			p.mutex.Unlock()
+			if process != nil && process.Process != nil {
+				p.logger.Info("Patcher didn't exit, forcing termination", zap.Int("pid", process.Process.Pid))
+				if err := process.Process.Kill(); err != nil {
+					p.logger.Error("Failed to kill patcher process", zap.Error(err))
+				}
+			}
+			grace = time.After(p.gracePeriod)
+		}
+	}
+}
+
+func (p *Patcher) setState(state string, restarts int, err error) {
+	status := PatcherStatus{State: state, Restarts: restarts, At: time.Now()}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	p.mutex.Lock()
+	p.status = status
+	app := p.app
+	p.mutex.Unlock()
+	if app != nil {
+		app.EmitEvent(EventPatcherStatus, status)
+	}
+}
@@ -0,0 +1,234 @@
+package lolskin
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHelperPatcher isn't a real test, it's the fake mod-tools binary started by fakePatcher
+func TestHelperPatcher(t *testing.T) {
+	mode := os.Getenv("LOLSKIN_FAKE_PATCHER")
+	if mode == "" {
+		return
+	}
+	args := os.Args[slices.Index(os.Args, "--")+1:]
+	if args[0] == "mkoverlay" {
+		if mode == "overlay-fail" || slices.Contains(args, "--mods:broken") {
+			fmt.Fprintln(os.Stderr, "[Error] Failed to parse mod")
+			os.Exit(1)
+		}
+		if len(args) > 2 {
+			os.MkdirAll(args[2], 0755)
+		}
+		fmt.Println("[INFO] Overlay built")
+		os.Exit(0)
+	}
+
+	switch mode {
+	case "crash":
+		fmt.Fprintln(os.Stderr, "[Error] Patcher crashed")
+		os.Exit(2)
+	case "crash-once":
+		marker := os.Getenv("LOLSKIN_FAKE_MARKER")
+		if _, err := os.Stat(marker); err != nil {
+			os.WriteFile(marker, nil, 0644)
+			fmt.Fprintln(os.Stderr, "[Error] Patcher crashed")
+			os.Exit(2)
+		}
+	}
+	fmt.Println("[INFO] Waiting for league match to start")
+	fmt.Println("[INFO] Found League")
+	fmt.Println("[INFO] Waiting for exit")
+	if mode == "stubborn" {
+		time.Sleep(time.Minute)
+	}
+	bufio.NewReader(os.Stdin).ReadString('\n')
+	os.Exit(0)
+}
+
+func fakePatcher(t *testing.T, mode string) func(args ...string) *exec.Cmd {
+	marker := filepath.Join(t.TempDir(), "crashed")
+	return func(args ...string) *exec.Cmd {
+		cmd := exec.Command(os.Args[0], append([]string{"-test.run=^TestHelperPatcher$", "--"}, args...)...)
+		// Race enabled binaries wait a second before exiting by default
+		cmd.Env = append(os.Environ(), "LOLSKIN_FAKE_PATCHER="+mode, "LOLSKIN_FAKE_MARKER="+marker, "GORACE=atexit_sleep_ms=0")
+		return cmd
+	}
+}
+
+// expectStates accepts every status of mockApp, the returned function lists the states in the order
+// they were entered
+func expectStates(mockApp *MockAppEmitter) func() []string {
+	var mutex sync.Mutex
+	var states []string
+	mockApp.EXPECT().EmitEvent(EventPatcherStatus, mock.Anything).Run(func(name string, data ...interface{}) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		state := data[0].(PatcherStatus).State
+		if len(states) == 0 || states[len(states)-1] != state {
+			states = append(states, state)
+		}
+	}).Return().Maybe()
+
+	return func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return slices.Clone(states)
+	}
+}
+
+func waitForState(t *testing.T, patcher *Patcher, state string) {
+	require.Eventually(t, func() bool { return patcher.Status().State == state }, 10*time.Second, 10*time.Millisecond,
+		"patcher never reached %s, last status %+v", state, patcher.Status())
+}
+
+func TestParsePatcherLine(t *testing.T) {
+	tests := []struct {
+		line  string
+		state string
+		ok    bool
+	}{
+		{"[INFO] Waiting for league match to start", PatcherWaitingForGame, true},
+		{"Status: Waiting for exit", PatcherInjected, true},
+		{"[INFO] Found League", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			state, ok := ParsePatcherLine(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.state, state)
+		})
+	}
+}
+
+func TestPatcher(t *testing.T) {
+	newLogger := logger.New("TestPatcher", &config.Config{LogLevel: "error"})
+
+	t.Run("Runs until injected and stops through stdin", func(t *testing.T) {
+		mockApp := NewMockAppEmitter(t)
+		states := expectStates(mockApp)
+		patcher := NewPatcher(newLogger, "mod-tools")
+		patcher.command = fakePatcher(t, "inject")
+		patcher.gracePeriod = 5 * time.Second
+		patcher.SetApp(mockApp)
+		defer patcher.Stop()
+		require.NoError(t, patcher.Start([]string{"mkoverlay"}, []string{"runoverlay"}, nil))
+		waitForState(t, patcher, PatcherInjected)
+
+		started := time.Now()
+		patcher.Stop()
+		assert.Less(t, time.Since(started), patcher.gracePeriod, "the patcher should exit without being killed")
+		assert.Equal(t, PatcherStopped, patcher.Status().State)
+		assert.Equal(t, []string{
+			PatcherBuildingOverlay,
+			PatcherRunning,
+			PatcherWaitingForGame,
+			PatcherInjected,
+			PatcherStopped,
+		}, states())
+	})
+
+	t.Run("Overlay failure is returned", func(t *testing.T) {
+		patcher := NewPatcher(newLogger, "mod-tools")
+		patcher.command = fakePatcher(t, "overlay-fail")
+		defer patcher.Stop()
+		err := patcher.Start([]string{"mkoverlay"}, []string{"runoverlay"}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Failed to parse mod")
+		status := patcher.Status()
+		assert.Equal(t, PatcherIdle, status.State)
+		assert.NotEmpty(t, status.Error)
+	})
+
+	t.Run("Failed rebuild keeps the running patcher", func(t *testing.T) {
+		patcher := NewPatcher(newLogger, "mod-tools")
+		patcher.command = fakePatcher(t, "inject")
+		defer patcher.Stop()
+		require.NoError(t, patcher.Start([]string{"mkoverlay"}, []string{"runoverlay"}, nil))
+		waitForState(t, patcher, PatcherInjected)
+		running := patcher.done
+
+		require.Error(t, patcher.Build([]string{"mkoverlay", "--mods:broken"}, nil))
+		assert.True(t, patcher.IsRunning())
+		assert.Equal(t, running, patcher.done)
+		status := patcher.Status()
+		assert.Equal(t, PatcherInjected, status.State)
+		assert.NotEmpty(t, status.Error)
+	})
+
+	t.Run("Crashed patcher is restarted", func(t *testing.T) {
+		mockApp := NewMockAppEmitter(t)
+		states := expectStates(mockApp)
+		patcher := NewPatcher(newLogger, "mod-tools")
+		patcher.command = fakePatcher(t, "crash-once")
+		patcher.backoff = 10 * time.Millisecond
+		patcher.maxRestarts = 2
+		patcher.SetApp(mockApp)
+		defer patcher.Stop()
+		require.NoError(t, patcher.Start([]string{"mkoverlay"}, []string{"runoverlay"}, nil))
+		waitForState(t, patcher, PatcherInjected)
+		assert.Equal(t, 1, patcher.Status().Restarts)
+		assert.Contains(t, states(), PatcherCrashed)
+	})
+
+	t.Run("Supervisor gives up after the maximum restarts", func(t *testing.T) {
+		patcher := NewPatcher(newLogger, "mod-tools")
+		patcher.command = fakePatcher(t, "crash")
+		patcher.backoff = 10 * time.Millisecond
+		patcher.maxRestarts = 2
+		defer patcher.Stop()
+		require.NoError(t, patcher.Start([]string{"mkoverlay"}, []string{"runoverlay"}, nil))
+		require.Eventually(t, func() bool {
+			select {
+			case <-patcher.done:
+				return true
+			default:
+				return false
+			}
+		}, 10*time.Second, 10*time.Millisecond)
+		status := patcher.Status()
+		assert.Equal(t, PatcherCrashed, status.State)
+		assert.Equal(t, patcher.maxRestarts, status.Restarts)
+	})
+
+	t.Run("Patcher ignoring stdin is killed", func(t *testing.T) {
+		patcher := NewPatcher(newLogger, "mod-tools")
+		patcher.command = fakePatcher(t, "stubborn")
+		defer patcher.Stop()
+		patcher.gracePeriod = 300 * time.Millisecond
+		require.NoError(t, patcher.Start([]string{"mkoverlay"}, []string{"runoverlay"}, nil))
+		waitForState(t, patcher, PatcherInjected)
+
+		started := time.Now()
+		patcher.Stop()
+		assert.GreaterOrEqual(t, time.Since(started), patcher.gracePeriod)
+		assert.Equal(t, PatcherStopped, patcher.Status().State)
+	})
+
+	t.Run("Starting again replaces the running patcher", func(t *testing.T) {
+		patcher := NewPatcher(newLogger, "mod-tools")
+		patcher.command = fakePatcher(t, "inject")
+		defer patcher.Stop()
+		require.NoError(t, patcher.Start([]string{"mkoverlay"}, []string{"runoverlay"}, nil))
+		waitForState(t, patcher, PatcherInjected)
+		first := patcher.done
+
+		require.NoError(t, patcher.Start([]string{"mkoverlay"}, []string{"runoverlay"}, nil))
+		<-first
+		waitForState(t, patcher, PatcherInjected)
+	})
+}
@@ -0,0 +1,415 @@
+package lolskin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultProfileID is the profile every user starts with
+const DefaultProfileID = "default"
+
+const skinProfilesFile = "skin_profiles.json"
+
+var (
+	ErrSkinProfileNotFound = errors.New("skin profile not found")
+	ErrLastSkinProfile     = errors.New("the last skin profile can't be deleted")
+)
+
+// SkinProfile is a named set of skin selections, the active one is the one injected
+type SkinProfile struct {
+	ID         string         `json:"id"`
+	Name       string         `json:"name"`
+	Selections []ChampionSkin `json:"selections"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+}
+
+// Validate checks the profile can be stored, imported profiles are validated the same way
+func (p SkinProfile) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("skin profile name is required")
+	}
+	for _, selection := range p.Selections {
+		if !selection.IsValid() {
+			return fmt.Errorf("invalid skin selection: champion %d skin %d", selection.ChampionID, selection.SkinID)
+		}
+	}
+	return nil
+}
+
+// ProfileState defines the live selections the active profile is loaded into
+type ProfileState interface {
+	GetAllSelections() []ChampionSkin
+	ReplaceAllSelections(selections []ChampionSkin)
+	SetOnChange(onChange func(selections []ChampionSkin))
+}
+
+// ProfileSync defines how the profiles of the logged in user are saved to the backend
+type ProfileSync interface {
+	SaveSkinProfiles(profiles []SkinProfile) error
+}
+
+// userProfiles are the profiles of a Nexus user
+type userProfiles struct {
+	Active   string        `json:"active"`
+	Profiles []SkinProfile `json:"profiles"`
+}
+
+// storedProfiles is the file persisted on disk, the active user is kept so a restart restores their selections
+// before the login is confirmed
+type storedProfiles struct {
+	ActiveUser int                   `json:"activeUser"`
+	Users      map[int]*userProfiles `json:"users"`
+}
+
+// profileSnapshot is a copy of the profiles of a user waiting to be synced
+type profileSnapshot struct {
+	userID   int
+	profiles []SkinProfile
+}
+
+// ProfileManager stores the skin profiles of each Nexus user. Changes to the selections are saved into the
+// active profile and switching profiles replaces the selections
+type ProfileManager struct {
+	logger logger.Loggerer
+	path   string
+	state  ProfileState
+	sync   ProfileSync
+	now    func() time.Time
+
+	mutex      sync.Mutex
+	users      map[int]*userProfiles
+	activeUser int
+	// pending is the latest snapshot to sync, a single worker uploads it so the syncs are never reordered
+	pending *profileSnapshot
+	syncing bool
+}
+
+// NewProfiles stores the profiles in the app data directory, they outlive the mod-tools directory
+func NewProfiles(logger logger.Loggerer, state ProfileState) *ProfileManager {
+	path, err := config.DataPath(skinProfilesFile)
+	if err != nil {
+		logger.Error("Failed to resolve skin profiles path", zap.Error(err))
+	}
+	return NewProfileManager(logger, path, state)
+}
+
+func NewProfileManager(logger logger.Loggerer, path string, state ProfileState) *ProfileManager {
+	m := &ProfileManager{
+		logger: logger,
+		path:   path,
+		state:  state,
+		now:    time.Now,
+		users:  make(map[int]*userProfiles),
+	}
+	data, err := os.ReadFile(m.path)
+	if err == nil {
+		var stored storedProfiles
+		if err := json.Unmarshal(data, &stored); err != nil {
+			logger.Error("Failed to parse skin profiles, starting empty", zap.Error(err))
+		} else if stored.Users != nil {
+			m.users = stored.Users
+			m.activeUser = stored.ActiveUser
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		logger.Error("Failed to read skin profiles", zap.Error(err))
+	}
+	// The selections are loaded before listening to them, loading them isn't a change to save
+	state.ReplaceAllSelections(m.activeLocked().Selections)
+	state.SetOnChange(m.onSelectionsChanged)
+	return m
+}
+
+// SetSync saves every change of the profiles to the backend
+func (m *ProfileManager) SetSync(sync ProfileSync) {
+	m.sync = sync
+}
+
+// SetActiveUser loads the profiles of the Nexus user and applies their active profile, the user id is 0
+// after a logout. Only the active user is saved, the profiles didn't change so they aren't synced
+func (m *ProfileManager) SetActiveUser(userID int) {
+	m.mutex.Lock()
+	m.activeUser = userID
+	active := *m.activeLocked()
+	if err := m.writeLocked(); err != nil {
+		m.logger.Error("Failed to save the active skin profiles user", zap.Error(err))
+	}
+	m.mutex.Unlock()
+
+	m.logger.Info("Skin profiles loaded", zap.Int("userId", userID), zap.String("profile", active.Name))
+	m.state.ReplaceAllSelections(active.Selections)
+}
+
+// List returns the profiles of the active user sorted by name
+func (m *ProfileManager) List() []SkinProfile {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.activeLocked()
+	profiles := append([]SkinProfile{}, m.users[m.activeUser].Profiles...)
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles
+}
+
+// Active returns the active profile of the active user
+func (m *ProfileManager) Active() SkinProfile {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return *m.activeLocked()
+}
+
+// Create adds an empty profile
+func (m *ProfileManager) Create(name string) (SkinProfile, error) {
+	return m.add(SkinProfile{Name: name, Selections: []ChampionSkin{}})
+}
+
+// Duplicate copies a profile under a new name
+func (m *ProfileManager) Duplicate(profileID, name string) (SkinProfile, error) {
+	m.mutex.Lock()
+	source, err := m.findLocked(profileID)
+	m.mutex.Unlock()
+	if err != nil {
+		return SkinProfile{}, err
+	}
+	return m.add(SkinProfile{Name: name, Selections: append([]ChampionSkin{}, source.Selections...)})
+}
+
+// Import adds a profile exported as JSON, it always gets a new ID
+func (m *ProfileManager) Import(data string) (SkinProfile, error) {
+	var profile SkinProfile
+	if err := json.Unmarshal([]byte(data), &profile); err != nil {
+		return SkinProfile{}, fmt.Errorf("invalid skin profile: %w", err)
+	}
+	return m.add(profile)
+}
+
+// Export returns a profile as JSON
+func (m *ProfileManager) Export(profileID string) (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	profile, err := m.findLocked(profileID)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode skin profile: %w", err)
+	}
+	return string(data), nil
+}
+
+// Rename changes the name of a profile
+func (m *ProfileManager) Rename(profileID, name string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	profile, err := m.findLocked(profileID)
+	if err != nil {
+		return err
+	}
+	renamed := *profile
+	renamed.Name = strings.TrimSpace(name)
+	if err := renamed.Validate(); err != nil {
+		return err
+	}
+	*profile = renamed
+	profile.UpdatedAt = m.now()
+	return m.persistLocked()
+}
+
+// Delete removes a profile, when it's the active one the first remaining profile is applied
+func (m *ProfileManager) Delete(profileID string) error {
+	m.mutex.Lock()
+	user := m.users[m.activeUser]
+	if _, err := m.findLocked(profileID); err != nil {
+		m.mutex.Unlock()
+		return err
+	}
+	if len(user.Profiles) == 1 {
+		m.mutex.Unlock()
+		return ErrLastSkinProfile
+	}
+	for i, profile := range user.Profiles {
+		if profile.ID == profileID {
+			user.Profiles = append(user.Profiles[:i], user.Profiles[i+1:]...)
+			break
+		}
+	}
+	wasActive := user.Active == profileID
+	if wasActive {
+		user.Active = user.Profiles[0].ID
+	}
+	active := *m.activeLocked()
+	err := m.persistLocked()
+	m.mutex.Unlock()
+
+	if wasActive {
+		m.state.ReplaceAllSelections(active.Selections)
+	}
+	return err
+}
+
+// Switch makes a profile the active one and applies its selections
+func (m *ProfileManager) Switch(profileID string) error {
+	m.mutex.Lock()
+	profile, err := m.findLocked(profileID)
+	if err != nil {
+		m.mutex.Unlock()
+		return err
+	}
+	m.users[m.activeUser].Active = profile.ID
+	selections := append([]ChampionSkin{}, profile.Selections...)
+	err = m.persistLocked()
+	m.mutex.Unlock()
+
+	m.logger.Info("Skin profile switched", zap.String("profile", profile.Name))
+	m.state.ReplaceAllSelections(selections)
+	return err
+}
+
+func (m *ProfileManager) add(profile SkinProfile) (SkinProfile, error) {
+	profile.ID = uuid.NewString()
+	profile.Name = strings.TrimSpace(profile.Name)
+	if profile.Selections == nil {
+		profile.Selections = []ChampionSkin{}
+	}
+	if err := profile.Validate(); err != nil {
+		return SkinProfile{}, err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.activeLocked()
+	profile.UpdatedAt = m.now()
+	user := m.users[m.activeUser]
+	user.Profiles = append(user.Profiles, profile)
+	return profile, m.persistLocked()
+}
+
+// onSelectionsChanged saves the live selections into the active profile, applying a profile reports its own
+// selections back and isn't a change
+func (m *ProfileManager) onSelectionsChanged(selections []ChampionSkin) {
+	sort.Slice(selections, func(i, j int) bool { return selections[i].ChampionID < selections[j].ChampionID })
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	active := m.activeLocked()
+	if slices.EqualFunc(active.Selections, selections, sameSelection) {
+		return
+	}
+	active.Selections = selections
+	active.UpdatedAt = m.now()
+	if err := m.persistLocked(); err != nil {
+		m.logger.Error("Failed to save skin profile", zap.Error(err))
+	}
+}
+
+// activeLocked returns the active profile of the active user, creating the default profile for new users
+func (m *ProfileManager) activeLocked() *SkinProfile {
+	user, ok := m.users[m.activeUser]
+	if !ok {
+		user = &userProfiles{}
+		m.users[m.activeUser] = user
+	}
+	if len(user.Profiles) == 0 {
+		user.Profiles = []SkinProfile{{ID: DefaultProfileID, Name: "Default", Selections: []ChampionSkin{}, UpdatedAt: m.now()}}
+	}
+	for i := range user.Profiles {
+		if user.Profiles[i].ID == user.Active {
+			return &user.Profiles[i]
+		}
+	}
+	user.Active = user.Profiles[0].ID
+	return &user.Profiles[0]
+}
+
+func (m *ProfileManager) findLocked(profileID string) (*SkinProfile, error) {
+	m.activeLocked()
+	user := m.users[m.activeUser]
+	for i := range user.Profiles {
+		if user.Profiles[i].ID == profileID {
+			return &user.Profiles[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrSkinProfileNotFound, profileID)
+}
+
+// persistLocked writes the profiles of every user and syncs the active user ones
+func (m *ProfileManager) persistLocked() error {
+	if err := m.writeLocked(); err != nil {
+		return err
+	}
+	if m.sync != nil && m.activeUser != 0 {
+		m.pending = &profileSnapshot{userID: m.activeUser, profiles: cloneProfiles(m.users[m.activeUser].Profiles)}
+		if !m.syncing {
+			m.syncing = true
+			go m.syncPending()
+		}
+	}
+	return nil
+}
+
+// syncPending uploads the pending snapshots until none is left, a snapshot queued during an upload replaces
+// the older ones
+func (m *ProfileManager) syncPending() {
+	for {
+		m.mutex.Lock()
+		snapshot := m.pending
+		m.pending = nil
+		if snapshot == nil {
+			m.syncing = false
+			m.mutex.Unlock()
+			return
+		}
+		// The backend saves the profiles of the logged in user, another user's can't be uploaded anymore
+		stale := snapshot.userID != m.activeUser
+		m.mutex.Unlock()
+
+		if stale {
+			continue
+		}
+		if err := m.sync.SaveSkinProfiles(snapshot.profiles); err != nil {
+			m.logger.Error("Failed to sync skin profiles", zap.Error(err))
+		}
+	}
+}
+
+// writeLocked writes the profiles of every user
+func (m *ProfileManager) writeLocked() error {
+	data, err := json.MarshalIndent(storedProfiles{ActiveUser: m.activeUser, Users: m.users}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode skin profiles: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0755); err != nil {
+		return fmt.Errorf("failed to create skin profiles directory: %w", err)
+	}
+	if err := os.WriteFile(m.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write skin profiles: %w", err)
+	}
+	return nil
+}
+
+// cloneProfiles copies the profiles down to their selections, the copy is read outside of the lock
+func cloneProfiles(profiles []SkinProfile) []SkinProfile {
+	clone := make([]SkinProfile, len(profiles))
+	for i, profile := range profiles {
+		clone[i] = profile
+		clone[i].Selections = make([]ChampionSkin, len(profile.Selections))
+		for j, selection := range profile.Selections {
+			if selection.ChromaID != nil {
+				chromaID := *selection.ChromaID
+				selection.ChromaID = &chromaID
+			}
+			clone[i].Selections[j] = selection
+		}
+	}
+	return clone
+}
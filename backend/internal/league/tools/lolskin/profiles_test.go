@@ -0,0 +1,195 @@
+package lolskin
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileManager(t *testing.T) {
+	newLogger := logger.New("TestProfiles", &config.Config{LogLevel: "error"})
+	lux := ChampionSkin{ChampionID: 99, SkinID: 99002}
+	aatrox := ChampionSkin{ChampionID: 266, SkinID: 266001}
+
+	t.Run("Selections are saved into the active profile and survive a restart", func(t *testing.T) {
+		dir := t.TempDir()
+		state := NewState()
+		profiles := NewProfileManager(newLogger, filepath.Join(dir, skinProfilesFile), state)
+		profiles.SetActiveUser(7)
+		state.SetChampionSkin(lux.ChampionID, lux.SkinID, nil)
+
+		assert.Equal(t, DefaultProfileID, profiles.Active().ID)
+		assert.Equal(t, []ChampionSkin{lux}, profiles.Active().Selections)
+
+		reloadedState := NewState()
+		reloaded := NewProfileManager(newLogger, filepath.Join(dir, skinProfilesFile), reloadedState)
+		reloaded.SetActiveUser(7)
+		assert.Equal(t, []ChampionSkin{lux}, reloadedState.GetAllSelections())
+	})
+
+	t.Run("A restart restores the selections of the last user", func(t *testing.T) {
+		dir := t.TempDir()
+		state := NewState()
+		profiles := NewProfileManager(newLogger, filepath.Join(dir, skinProfilesFile), state)
+		profiles.SetActiveUser(7)
+		state.SetChampionSkin(lux.ChampionID, lux.SkinID, nil)
+
+		reloadedState := NewState()
+		// Loading the profiles restores the selections of the last user
+		NewProfileManager(newLogger, filepath.Join(dir, skinProfilesFile), reloadedState)
+		assert.Equal(t, []ChampionSkin{lux}, reloadedState.GetAllSelections())
+	})
+
+	t.Run("A restart after a logout doesn't restore the selections", func(t *testing.T) {
+		dir := t.TempDir()
+		state := NewState()
+		profiles := NewProfileManager(newLogger, filepath.Join(dir, skinProfilesFile), state)
+		profiles.SetActiveUser(7)
+		state.SetChampionSkin(lux.ChampionID, lux.SkinID, nil)
+		profiles.SetActiveUser(0)
+
+		reloadedState := NewState()
+		// Loading the profiles restores the selections of the last user
+		NewProfileManager(newLogger, filepath.Join(dir, skinProfilesFile), reloadedState)
+		assert.Empty(t, reloadedState.GetAllSelections())
+	})
+
+	t.Run("Switching profiles replaces the selections", func(t *testing.T) {
+		state := NewState()
+		profiles := NewProfileManager(newLogger, filepath.Join(t.TempDir(), skinProfilesFile), state)
+		state.SetChampionSkin(lux.ChampionID, lux.SkinID, nil)
+
+		ranked, err := profiles.Create("Ranked")
+		require.NoError(t, err)
+		require.NoError(t, profiles.Switch(ranked.ID))
+		assert.Empty(t, state.GetAllSelections())
+		state.SetChampionSkin(aatrox.ChampionID, aatrox.SkinID, nil)
+
+		require.NoError(t, profiles.Switch(DefaultProfileID))
+		assert.Equal(t, []ChampionSkin{lux}, state.GetAllSelections())
+		assert.ErrorIs(t, profiles.Switch("missing"), ErrSkinProfileNotFound)
+	})
+
+	t.Run("Profiles are kept per user", func(t *testing.T) {
+		state := NewState()
+		profiles := NewProfileManager(newLogger, filepath.Join(t.TempDir(), skinProfilesFile), state)
+		profiles.SetActiveUser(1)
+		state.SetChampionSkin(lux.ChampionID, lux.SkinID, nil)
+
+		profiles.SetActiveUser(2)
+		assert.Empty(t, state.GetAllSelections())
+		assert.Len(t, profiles.List(), 1)
+
+		profiles.SetActiveUser(1)
+		assert.Equal(t, []ChampionSkin{lux}, state.GetAllSelections())
+	})
+
+	t.Run("Duplicate, rename and delete", func(t *testing.T) {
+		state := NewState()
+		profiles := NewProfileManager(newLogger, filepath.Join(t.TempDir(), skinProfilesFile), state)
+		state.SetChampionSkin(lux.ChampionID, lux.SkinID, nil)
+
+		copied, err := profiles.Duplicate(DefaultProfileID, "Copy")
+		require.NoError(t, err)
+		assert.NotEqual(t, DefaultProfileID, copied.ID)
+		assert.Equal(t, []ChampionSkin{lux}, copied.Selections)
+
+		require.NoError(t, profiles.Rename(copied.ID, " Aram "))
+		assert.Error(t, profiles.Rename(copied.ID, " "))
+		names := []string{}
+		for _, profile := range profiles.List() {
+			names = append(names, profile.Name)
+		}
+		assert.Equal(t, []string{"Aram", "Default"}, names)
+
+		require.NoError(t, profiles.Switch(copied.ID))
+		require.NoError(t, profiles.Delete(copied.ID))
+		assert.Equal(t, DefaultProfileID, profiles.Active().ID)
+		assert.ErrorIs(t, profiles.Delete(DefaultProfileID), ErrLastSkinProfile)
+	})
+
+	t.Run("Exported profiles are imported with a new ID", func(t *testing.T) {
+		state := NewState()
+		profiles := NewProfileManager(newLogger, filepath.Join(t.TempDir(), skinProfilesFile), state)
+		state.SetChampionSkin(lux.ChampionID, lux.SkinID, nil)
+
+		exported, err := profiles.Export(DefaultProfileID)
+		require.NoError(t, err)
+		imported, err := profiles.Import(exported)
+		require.NoError(t, err)
+		assert.NotEqual(t, DefaultProfileID, imported.ID)
+		assert.Equal(t, "Default", imported.Name)
+		assert.Equal(t, []ChampionSkin{lux}, imported.Selections)
+		assert.Len(t, profiles.List(), 2)
+
+		_, err = profiles.Import(`{"name":"Broken","selections":[{"championId":99,"skinId":0}]}`)
+		assert.Error(t, err)
+		_, err = profiles.Import("not json")
+		assert.Error(t, err)
+	})
+
+	t.Run("Changes of a logged in user are synced in order, only the latest pending one", func(t *testing.T) {
+		saved := make(chan []SkinProfile, 10)
+		uploading := make(chan struct{}, 10)
+		release := make(chan struct{})
+		mockSync := NewMockProfileSync(t)
+		mockSync.EXPECT().SaveSkinProfiles(mock.Anything).Run(func(profiles []SkinProfile) {
+			uploading <- struct{}{}
+			<-release
+			saved <- profiles
+		}).Return(nil)
+
+		state := NewState()
+		profiles := NewProfileManager(newLogger, filepath.Join(t.TempDir(), skinProfilesFile), state)
+		profiles.SetSync(mockSync)
+		state.SetChampionSkin(lux.ChampionID, lux.SkinID, nil)
+		profiles.SetActiveUser(3)
+		mockSync.AssertNotCalled(t, "SaveSkinProfiles", mock.Anything)
+
+		// The first change is uploading while the next ones are made
+		state.SetChampionSkin(aatrox.ChampionID, aatrox.SkinID, nil)
+		<-uploading
+		state.SetChampionSkin(lux.ChampionID, lux.SkinID, nil)
+		state.SetChampionSkin(lux.ChampionID, 99003, nil)
+		close(release)
+
+		var synced [][]ChampionSkin
+		for len(synced) < 2 {
+			select {
+			case profiles := <-saved:
+				synced = append(synced, profiles[0].Selections)
+			case <-time.After(time.Second):
+				t.Fatalf("expected 2 syncs, got %d", len(synced))
+			}
+		}
+		assert.Equal(t, [][]ChampionSkin{
+			{aatrox},
+			{{ChampionID: 99, SkinID: 99003}, aatrox},
+		}, synced)
+		assert.Never(t, func() bool { return len(saved) > 0 }, 50*time.Millisecond, 5*time.Millisecond)
+	})
+
+	t.Run("Logging in saves the active user without syncing", func(t *testing.T) {
+		mockSync := NewMockProfileSync(t)
+		dir := t.TempDir()
+		state := NewState()
+		profiles := NewProfileManager(newLogger, filepath.Join(dir, skinProfilesFile), state)
+		profiles.SetSync(mockSync)
+		profiles.SetActiveUser(3)
+		profiles.SetActiveUser(0)
+		profiles.SetActiveUser(3)
+		mockSync.AssertNotCalled(t, "SaveSkinProfiles", mock.Anything)
+		assert.Empty(t, state.GetAllSelections())
+
+		reloaded := NewProfileManager(newLogger, filepath.Join(dir, skinProfilesFile), NewState())
+		reloaded.mutex.Lock()
+		defer reloaded.mutex.Unlock()
+		assert.Equal(t, 3, reloaded.activeUser)
+	})
+}
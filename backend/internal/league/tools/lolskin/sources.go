@@ -0,0 +1,452 @@
+package lolskin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	skinSourcesFile   = "skin_sources.json"
+	skinChecksumsFile = "skin_checksums.json"
+)
+
+// Skin source kinds
+const (
+	// SourceCatalog downloads from the URL of the catalog, the GitHub raw repository
+	SourceCatalog = "catalog"
+	// SourceMirror downloads from a base URL serving the same paths as the catalog, like the backend CDN
+	SourceMirror = "mirror"
+	// SourceLocal reads from a directory holding the same paths as the catalog
+	SourceLocal = "local"
+)
+
+// A source that fails sourceFailureThreshold downloads in a row is only tried after the healthy ones
+// until sourceCooldown has passed
+const (
+	sourceFailureThreshold = 3
+	sourceCooldown         = 5 * time.Minute
+)
+
+var ErrInvalidSource = errors.New("invalid skin source")
+
+// SkinSource is a location skins are downloaded from
+type SkinSource interface {
+	Name() string
+	// URL returns where the skin is downloaded from, false when the source doesn't have it
+	URL(skin ResolvedSkin) (string, bool)
+}
+
+// SourceConfig describes a SkinSource, URL is the base URL of a mirror and Path the directory of a local source
+type SourceConfig struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	URL  string `json:"url,omitempty"`
+	Path string `json:"path,omitempty"`
+}
+
+// SourceHealth is the download record of a source
+type SourceHealth struct {
+	Name                string    `json:"name"`
+	Kind                string    `json:"kind"`
+	Healthy             bool      `json:"healthy"`
+	Successes           int       `json:"successes"`
+	Failures            int       `json:"failures"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	LastError           string    `json:"lastError,omitempty"`
+	LastSuccess         time.Time `json:"lastSuccess"`
+	LastFailure         time.Time `json:"lastFailure"`
+	DisabledUntil       time.Time `json:"disabledUntil"`
+}
+
+// SkinSourcesConfig is the remote config of the skin sources. Checksums holds the sha256 of the skins keyed by
+// their SkinPath, the mirrors are verified against it for the skins the catalog has no hash for
+type SkinSourcesConfig struct {
+	Sources   []SourceConfig    `json:"sources"`
+	Checksums map[string]string `json:"checksums,omitempty"`
+}
+
+// SourceConfigFetcher loads the skin sources from the remote config
+type SourceConfigFetcher interface {
+	GetSkinSources() (*SkinSourcesConfig, error)
+}
+
+// DefaultSkinSources downloads from the catalog URLs only
+func DefaultSkinSources() []SourceConfig {
+	return []SourceConfig{{Name: "github", Kind: SourceCatalog}}
+}
+
+// SkinPath is the path of a skin relative to the root of the catalog repository, it starts with the
+// champion key like 99/elementalist_forms/2.fantome
+func SkinPath(skin ResolvedSkin) (string, bool) {
+	u, err := url.Parse(skin.DownloadUrl)
+	if err != nil {
+		return "", false
+	}
+	marker := "/" + strconv.Itoa(skin.ChampionKey) + "/"
+	index := strings.Index(u.Path, marker)
+	if index < 0 {
+		return "", false
+	}
+	return u.Path[index+1:], true
+}
+
+type catalogSource struct {
+	name string
+}
+
+func (s catalogSource) Name() string {
+	return s.name
+}
+
+func (s catalogSource) URL(skin ResolvedSkin) (string, bool) {
+	return skin.DownloadUrl, skin.DownloadUrl != ""
+}
+
+type mirrorSource struct {
+	name    string
+	baseURL string
+}
+
+func (s mirrorSource) Name() string {
+	return s.name
+}
+
+func (s mirrorSource) URL(skin ResolvedSkin) (string, bool) {
+	skinPath, ok := SkinPath(skin)
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSuffix(s.baseURL, "/") + "/" + skinPath, true
+}
+
+type localSource struct {
+	name string
+	dir  string
+}
+
+func (s localSource) Name() string {
+	return s.name
+}
+
+// URL returns a file URL, skins missing from the directory are left to the other sources
+func (s localSource) URL(skin ResolvedSkin) (string, bool) {
+	skinPath, ok := SkinPath(skin)
+	if !ok {
+		return "", false
+	}
+	filePath := filepath.Join(s.dir, filepath.FromSlash(skinPath))
+	if _, err := os.Stat(filePath); err != nil {
+		return "", false
+	}
+	slashed := filepath.ToSlash(filePath)
+	if !strings.HasPrefix(slashed, "/") {
+		slashed = "/" + slashed
+	}
+	return (&url.URL{Scheme: "file", Path: slashed}).String(), true
+}
+
+// NewSkinSource builds the source a config describes
+func NewSkinSource(config SourceConfig) (SkinSource, error) {
+	if strings.TrimSpace(config.Name) == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidSource)
+	}
+	switch config.Kind {
+	case SourceCatalog:
+		return catalogSource{name: config.Name}, nil
+	case SourceMirror:
+		u, err := url.Parse(config.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%w: %s needs an http or https URL", ErrInvalidSource, config.Name)
+		}
+		return mirrorSource{name: config.Name, baseURL: config.URL}, nil
+	case SourceLocal:
+		if !filepath.IsAbs(config.Path) {
+			return nil, fmt.Errorf("%w: %s needs an absolute path", ErrInvalidSource, config.Name)
+		}
+		return localSource{name: config.Name, dir: config.Path}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidSource, config.Kind)
+	}
+}
+
+// SkinSources keeps the ordered sources skins are downloaded from and their health. Downloads try every
+// source that has the skin, the ones failing repeatedly go last until their cooldown passes
+type SkinSources struct {
+	logger        logger.Loggerer
+	path          string
+	checksumsPath string
+	now           func() time.Time
+
+	mutex     sync.Mutex
+	configs   []SourceConfig
+	sources   []SkinSource
+	health    map[string]*SourceHealth
+	checksums map[string]string
+	remote    SourceConfigFetcher
+}
+
+func NewSkinSources(logger logger.Loggerer, dir string) *SkinSources {
+	s := &SkinSources{
+		logger:        logger,
+		path:          filepath.Join(dir, skinSourcesFile),
+		checksumsPath: filepath.Join(dir, skinChecksumsFile),
+		now:           time.Now,
+		health:        make(map[string]*SourceHealth),
+		checksums:     make(map[string]string),
+	}
+	configs := DefaultSkinSources()
+	if data, err := os.ReadFile(s.path); err == nil {
+		var saved []SourceConfig
+		if err := json.Unmarshal(data, &saved); err != nil {
+			logger.Error("Failed to parse skin sources", zap.Error(err))
+		} else {
+			configs = saved
+		}
+	}
+	if err := s.apply(configs); err != nil {
+		logger.Error("Invalid saved skin sources, using the defaults", zap.Error(err))
+		s.apply(DefaultSkinSources())
+	}
+	if data, err := os.ReadFile(s.checksumsPath); err == nil {
+		if err := json.Unmarshal(data, &s.checksums); err != nil {
+			logger.Error("Failed to parse skin checksums", zap.Error(err))
+		}
+	}
+	return s
+}
+
+// SetRemote sets where Refresh loads the sources from
+func (s *SkinSources) SetRemote(remote SourceConfigFetcher) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.remote = remote
+}
+
+// Refresh replaces the sources and the checksums with the ones of the remote config, they're saved so they're
+// used until the next refresh
+func (s *SkinSources) Refresh() error {
+	s.mutex.Lock()
+	remote := s.remote
+	s.mutex.Unlock()
+	if remote == nil {
+		return nil
+	}
+	config, err := remote.GetSkinSources()
+	if err != nil {
+		return fmt.Errorf("failed to load skin sources: %w", err)
+	}
+	if err := s.SetSources(config.Sources); err != nil {
+		return err
+	}
+	s.SetChecksums(config.Checksums)
+	s.logger.Info("Skin sources refreshed",
+		zap.Int("count", len(config.Sources)),
+		zap.Int("checksums", len(config.Checksums)))
+	return nil
+}
+
+// GetSources returns the sources in the order they're tried
+func (s *SkinSources) GetSources() []SourceConfig {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return slices.Clone(s.configs)
+}
+
+// SetSources replaces the sources and saves them, the health of the sources that are kept is preserved
+func (s *SkinSources) SetSources(configs []SourceConfig) error {
+	if err := s.apply(configs); err != nil {
+		return err
+	}
+	s.save()
+	return nil
+}
+
+// ResetSources goes back to the default sources
+func (s *SkinSources) ResetSources() error {
+	return s.SetSources(DefaultSkinSources())
+}
+
+// SetChecksums replaces the sha256 of the skins, keyed by their SkinPath, and saves them
+func (s *SkinSources) SetChecksums(checksums map[string]string) {
+	s.mutex.Lock()
+	s.checksums = maps.Clone(checksums)
+	if s.checksums == nil {
+		s.checksums = make(map[string]string)
+	}
+	data, err := json.MarshalIndent(s.checksums, "", "  ")
+	s.mutex.Unlock()
+	if err != nil {
+		s.logger.Error("Failed to encode skin checksums", zap.Error(err))
+		return
+	}
+	if err := os.WriteFile(s.checksumsPath, data, 0644); err != nil {
+		s.logger.Error("Failed to write skin checksums", zap.Error(err))
+	}
+}
+
+// Checksum returns the sha256 of a skin, the one of the catalog first then the one of the remote config. It's
+// empty when neither has it
+func (s *SkinSources) Checksum(skin ResolvedSkin) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.checksumLocked(skin)
+}
+
+func (s *SkinSources) checksumLocked(skin ResolvedSkin) string {
+	if skin.Sha256 != "" {
+		return skin.Sha256
+	}
+	skinPath, ok := SkinPath(skin)
+	if !ok {
+		return ""
+	}
+	return s.checksums[skinPath]
+}
+
+// GetHealth returns the health of every source in the configured order
+func (s *SkinSources) GetHealth() []SourceHealth {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := s.now()
+	result := make([]SourceHealth, 0, len(s.configs))
+	for _, config := range s.configs {
+		health := *s.health[config.Name]
+		health.Healthy = !now.Before(health.DisabledUntil)
+		result = append(result, health)
+	}
+	return result
+}
+
+// Mirrors returns the locations of a skin, the healthy sources first in the configured order. Only the catalog
+// is trusted without a checksum, the other sources are left out for skins with no Checksum
+func (s *SkinSources) Mirrors(skin ResolvedSkin) []Mirror {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := s.now()
+	checksum := s.checksumLocked(skin)
+	var healthy, disabled []Mirror
+	for _, source := range s.sources {
+		if checksum == "" && s.health[source.Name()].Kind != SourceCatalog {
+			continue
+		}
+		location, ok := source.URL(skin)
+		if !ok {
+			continue
+		}
+		mirror := Mirror{Source: source.Name(), URL: location}
+		if now.Before(s.health[source.Name()].DisabledUntil) {
+			disabled = append(disabled, mirror)
+			continue
+		}
+		healthy = append(healthy, mirror)
+	}
+	// Disabled sources are still tried, a download fails only when every source does
+	return append(healthy, disabled...)
+}
+
+// ReportMirror records the outcome of a download from a source
+func (s *SkinSources) ReportMirror(source string, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	health, ok := s.health[source]
+	if !ok {
+		return
+	}
+	now := s.now()
+	if err == nil {
+		health.Successes++
+		health.ConsecutiveFailures = 0
+		health.LastSuccess = now
+		health.DisabledUntil = time.Time{}
+		return
+	}
+	health.Failures++
+	health.ConsecutiveFailures++
+	health.LastError = err.Error()
+	health.LastFailure = now
+	if health.ConsecutiveFailures >= sourceFailureThreshold {
+		health.DisabledUntil = now.Add(sourceCooldown)
+		s.logger.Info("Skin source disabled after repeated failures",
+			zap.String("source", source),
+			zap.Int("failures", health.ConsecutiveFailures),
+			zap.Time("until", health.DisabledUntil))
+	}
+}
+
+func (s *SkinSources) apply(configs []SourceConfig) error {
+	if len(configs) == 0 {
+		return fmt.Errorf("%w: at least one source is required", ErrInvalidSource)
+	}
+	sources := make([]SkinSource, 0, len(configs))
+	names := make(map[string]bool)
+	for _, config := range configs {
+		if names[config.Name] {
+			return fmt.Errorf("%w: duplicate name %q", ErrInvalidSource, config.Name)
+		}
+		names[config.Name] = true
+		source, err := NewSkinSource(config)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, source)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	health := make(map[string]*SourceHealth, len(configs))
+	for _, config := range configs {
+		previous, ok := s.health[config.Name]
+		if !ok || previous.Kind != config.Kind {
+			previous = &SourceHealth{Name: config.Name}
+		}
+		previous.Kind = config.Kind
+		health[config.Name] = previous
+	}
+	s.configs = slices.Clone(configs)
+	s.sources = sources
+	s.health = health
+	return nil
+}
+
+func (s *SkinSources) save() {
+	data, err := json.MarshalIndent(s.GetSources(), "", "  ")
+	if err != nil {
+		s.logger.Error("Failed to encode skin sources", zap.Error(err))
+		return
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		s.logger.Error("Failed to write skin sources", zap.Error(err))
+	}
+}
+
+// fileTransport serves file URLs with range support so downloads from a local source resume like the others
+type fileTransport struct{}
+
+func (fileTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	filePath := req.URL.Path
+	// Windows paths are written as /C:/skins
+	if len(filePath) >= 3 && filePath[0] == '/' && filePath[2] == ':' {
+		filePath = filePath[1:]
+	}
+	filePath = filepath.FromSlash(filePath)
+	dir, name := filepath.Split(filePath)
+
+	served := req.Clone(req.Context())
+	served.URL = &url.URL{Path: path.Join("/", name)}
+	return http.NewFileTransport(http.Dir(dir)).RoundTrip(served)
+}
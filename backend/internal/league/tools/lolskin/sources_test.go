@@ -0,0 +1,352 @@
+package lolskin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkinPath(t *testing.T) {
+	tests := []struct {
+		name string
+		skin ResolvedSkin
+		path string
+		ok   bool
+	}{
+		{"GitHub raw", ResolvedSkin{ChampionKey: 266, DownloadUrl: "https://raw.githubusercontent.com/koobzaar/lol-skins-developer/main/266/20.fantome"}, "266/20.fantome", true},
+		{"Nested form", ResolvedSkin{ChampionKey: 99, DownloadUrl: "https://raw.githubusercontent.com/koobzaar/lol-skins-developer/main/99/elementalist_forms/2.fantome"}, "99/elementalist_forms/2.fantome", true},
+		{"Other champion", ResolvedSkin{ChampionKey: 1, DownloadUrl: "https://skins.test/99/2.fantome"}, "", false},
+		{"Invalid URL", ResolvedSkin{ChampionKey: 99, DownloadUrl: "://99/2.fantome"}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, ok := SkinPath(tt.skin)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.path, path)
+		})
+	}
+}
+
+func TestSkinSources(t *testing.T) {
+	newLogger := logger.New("TestSources", &config.Config{LogLevel: "error"})
+	skin := ResolvedSkin{ChampionKey: 99, SkinID: 99002, DownloadUrl: "https://skins.test/99/2.fantome", Sha256: "checksum"}
+
+	t.Run("Mirrors follow the configured order", func(t *testing.T) {
+		localDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(localDir, "99"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(localDir, "99", "2.fantome"), []byte("skin"), 0644))
+
+		sources := NewSkinSources(newLogger, t.TempDir())
+		require.NoError(t, sources.SetSources([]SourceConfig{
+			{Name: "local", Kind: SourceLocal, Path: localDir},
+			{Name: "cdn", Kind: SourceMirror, URL: "https://cdn.test/skins/"},
+			{Name: "github", Kind: SourceCatalog},
+		}))
+
+		mirrors := sources.Mirrors(skin)
+		require.Len(t, mirrors, 3)
+		assert.Equal(t, "local", mirrors[0].Source)
+		assert.Contains(t, mirrors[0].URL, "file://")
+		assert.Equal(t, Mirror{Source: "cdn", URL: "https://cdn.test/skins/99/2.fantome"}, mirrors[1])
+		assert.Equal(t, Mirror{Source: "github", URL: skin.DownloadUrl}, mirrors[2])
+
+		missing := ResolvedSkin{ChampionKey: 99, SkinID: 99007, DownloadUrl: "https://skins.test/99/7.fantome", Sha256: "checksum"}
+		assert.Len(t, sources.Mirrors(missing), 2, "a local source only has the files of its directory")
+	})
+
+	t.Run("Only the catalog is used for skins without a checksum", func(t *testing.T) {
+		sources := NewSkinSources(newLogger, t.TempDir())
+		require.NoError(t, sources.SetSources([]SourceConfig{
+			{Name: "cdn", Kind: SourceMirror, URL: "https://cdn.test"},
+			{Name: "github", Kind: SourceCatalog},
+		}))
+
+		unverified := skin
+		unverified.Sha256 = ""
+		assert.Equal(t, []Mirror{{Source: "github", URL: skin.DownloadUrl}}, sources.Mirrors(unverified))
+	})
+
+	t.Run("Checksums of the remote config let the mirrors serve skins the catalog has no hash for", func(t *testing.T) {
+		sources := NewSkinSources(newLogger, t.TempDir())
+		require.NoError(t, sources.SetSources([]SourceConfig{
+			{Name: "cdn", Kind: SourceMirror, URL: "https://cdn.test"},
+			{Name: "github", Kind: SourceCatalog},
+		}))
+		sources.SetChecksums(map[string]string{"99/2.fantome": "remote-checksum"})
+
+		unverified := skin
+		unverified.Sha256 = ""
+		assert.Equal(t, "remote-checksum", sources.Checksum(unverified))
+		assert.Len(t, sources.Mirrors(unverified), 2)
+		assert.Equal(t, "checksum", sources.Checksum(skin), "the hash of the catalog comes first")
+	})
+
+	t.Run("Failing sources go last until their cooldown passes", func(t *testing.T) {
+		sources := NewSkinSources(newLogger, t.TempDir())
+		require.NoError(t, sources.SetSources([]SourceConfig{
+			{Name: "cdn", Kind: SourceMirror, URL: "https://cdn.test"},
+			{Name: "github", Kind: SourceCatalog},
+		}))
+
+		for i := 0; i < sourceFailureThreshold; i++ {
+			sources.ReportMirror("cdn", errors.New("bad status: 429 Too Many Requests"))
+		}
+		mirrors := sources.Mirrors(skin)
+		assert.Equal(t, []string{"github", "cdn"}, []string{mirrors[0].Source, mirrors[1].Source})
+		health := sources.GetHealth()
+		assert.False(t, health[0].Healthy)
+		assert.Equal(t, sourceFailureThreshold, health[0].Failures)
+		assert.Equal(t, "bad status: 429 Too Many Requests", health[0].LastError)
+		assert.True(t, health[1].Healthy)
+
+		now := time.Now().Add(sourceCooldown)
+		sources.now = func() time.Time { return now }
+		assert.Equal(t, "cdn", sources.Mirrors(skin)[0].Source)
+
+		sources.ReportMirror("cdn", nil)
+		health = sources.GetHealth()
+		assert.Equal(t, 0, health[0].ConsecutiveFailures)
+		assert.Equal(t, 1, health[0].Successes)
+	})
+
+	t.Run("Remote config replaces the sources and is saved", func(t *testing.T) {
+		dir := t.TempDir()
+		sources := NewSkinSources(newLogger, dir)
+		assert.Equal(t, DefaultSkinSources(), sources.GetSources())
+		sources.ReportMirror("github", errors.New("timeout"))
+
+		remoteSources := []SourceConfig{
+			{Name: "cdn", Kind: SourceMirror, URL: "https://cdn.test"},
+			{Name: "github", Kind: SourceCatalog},
+		}
+		remoteChecksums := map[string]string{"99/2.fantome": "remote-checksum"}
+		mockRemote := NewMockSourceConfigFetcher(t)
+		mockRemote.EXPECT().GetSkinSources().Return(&SkinSourcesConfig{Sources: remoteSources, Checksums: remoteChecksums}, nil).Once()
+		sources.SetRemote(mockRemote)
+		require.NoError(t, sources.Refresh())
+		assert.Equal(t, remoteSources, sources.GetSources())
+		assert.Equal(t, 1, sources.GetHealth()[1].Failures, "the health of kept sources is preserved")
+		saved := NewSkinSources(newLogger, dir)
+		assert.Equal(t, remoteSources, saved.GetSources())
+		unverified := skin
+		unverified.Sha256 = ""
+		assert.Equal(t, "remote-checksum", saved.Checksum(unverified), "the checksums are saved with the sources")
+
+		mockRemote.EXPECT().GetSkinSources().Return(nil, errors.New("offline")).Once()
+		assert.Error(t, sources.Refresh())
+		mockRemote.EXPECT().GetSkinSources().Return(&SkinSourcesConfig{Sources: []SourceConfig{{Name: "cdn", Kind: SourceMirror, URL: "ftp://cdn.test"}}}, nil).Once()
+		assert.ErrorIs(t, sources.Refresh(), ErrInvalidSource)
+		assert.Len(t, sources.GetSources(), 2, "invalid sources are not applied")
+	})
+
+	t.Run("Invalid sources are rejected", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			configs []SourceConfig
+		}{
+			{"Empty", nil},
+			{"Missing name", []SourceConfig{{Kind: SourceCatalog}}},
+			{"Duplicate name", []SourceConfig{{Name: "github", Kind: SourceCatalog}, {Name: "github", Kind: SourceCatalog}}},
+			{"Unknown kind", []SourceConfig{{Name: "s3", Kind: "s3"}}},
+			{"Mirror without URL", []SourceConfig{{Name: "cdn", Kind: SourceMirror}}},
+			{"Relative local path", []SourceConfig{{Name: "local", Kind: SourceLocal, Path: "skins"}}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				sources := NewSkinSources(newLogger, t.TempDir())
+				assert.ErrorIs(t, sources.SetSources(tt.configs), ErrInvalidSource)
+				assert.Equal(t, DefaultSkinSources(), sources.GetSources())
+			})
+		}
+	})
+
+	t.Run("Unreadable saved sources fall back to the defaults", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, skinSourcesFile), []byte(`[{"name": "s3", "kind": "s3"}]`), 0644))
+		assert.Equal(t, DefaultSkinSources(), NewSkinSources(newLogger, dir).GetSources())
+	})
+}
+
+func TestDownloadFallback(t *testing.T) {
+	newLogger := logger.New("TestSources", &config.Config{LogLevel: "error"})
+	content := bytes.Repeat([]byte("fantome"), 1024)
+
+	t.Run("Next mirror is tried when one fails", func(t *testing.T) {
+		failing := httptest.NewServer(&fileServer{failPath: "/99/2.fantome"})
+		defer failing.Close()
+		working := httptest.NewServer(&fileServer{files: map[string][]byte{"/99/2.fantome": content}})
+		defer working.Close()
+
+		sources := NewSkinSources(newLogger, t.TempDir())
+		require.NoError(t, sources.SetSources([]SourceConfig{
+			{Name: "cdn", Kind: SourceMirror, URL: failing.URL},
+			{Name: "github", Kind: SourceMirror, URL: working.URL},
+		}))
+		mockApp := NewMockAppEmitter(t)
+		expectDownloadStatus(mockApp, "99002", DownloadCompleted, 0)
+		manager := NewDownloadManager(newLogger, 1)
+		manager.SetApp(mockApp)
+		manager.SetReporter(sources)
+
+		skin := ResolvedSkin{ChampionKey: 99, DownloadUrl: "https://skins.test/99/2.fantome", Sha256: checksum(content)}
+		destination := filepath.Join(t.TempDir(), "99002.zip")
+		results := manager.Download(context.Background(), []DownloadRequest{
+			{ID: "99002", Mirrors: sources.Mirrors(skin), Destination: destination, Sha256: checksum(content)},
+		})
+		require.NoError(t, results[0].Err)
+		data, err := os.ReadFile(destination)
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+
+		health := sources.GetHealth()
+		assert.Equal(t, 1, health[0].Failures)
+		assert.Equal(t, 1, health[1].Successes)
+	})
+
+	t.Run("A file missing from a mirror doesn't count against it", func(t *testing.T) {
+		missing := httptest.NewServer(&fileServer{files: map[string][]byte{}})
+		defer missing.Close()
+		working := httptest.NewServer(&fileServer{files: map[string][]byte{"/99/2.fantome": content}})
+		defer working.Close()
+
+		sources := NewSkinSources(newLogger, t.TempDir())
+		require.NoError(t, sources.SetSources([]SourceConfig{
+			{Name: "cdn", Kind: SourceMirror, URL: missing.URL},
+			{Name: "github", Kind: SourceMirror, URL: working.URL},
+		}))
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.Anything).Return().Maybe()
+		manager := NewDownloadManager(newLogger, 1)
+		manager.SetApp(mockApp)
+		manager.SetReporter(sources)
+
+		skin := ResolvedSkin{ChampionKey: 99, DownloadUrl: "https://skins.test/99/2.fantome", Sha256: checksum(content)}
+		results := manager.Download(context.Background(), []DownloadRequest{
+			{ID: "99002", Mirrors: sources.Mirrors(skin), Destination: filepath.Join(t.TempDir(), "99002.zip"), Sha256: checksum(content)},
+		})
+		require.NoError(t, results[0].Err)
+
+		health := sources.GetHealth()
+		assert.Zero(t, health[0].Failures)
+		assert.Empty(t, health[0].LastError)
+		assert.Equal(t, 1, health[1].Successes)
+	})
+
+	t.Run("Download fails when every mirror does", func(t *testing.T) {
+		failing := httptest.NewServer(&fileServer{failPath: "/99/2.fantome"})
+		defer failing.Close()
+		mockApp := NewMockAppEmitter(t)
+		expectDownloadStatus(mockApp, "99002", DownloadFailed, 0)
+		manager := NewDownloadManager(newLogger, 1)
+		manager.SetApp(mockApp)
+
+		results := manager.Download(context.Background(), []DownloadRequest{{
+			ID: "99002",
+			Mirrors: []Mirror{
+				{Source: "cdn", URL: failing.URL + "/99/2.fantome"},
+				{Source: "github", URL: failing.URL + "/99/2.fantome"},
+			},
+			Destination: filepath.Join(t.TempDir(), "99002.zip"),
+		}})
+		require.Error(t, results[0].Err)
+		assert.Contains(t, results[0].Err.Error(), "503")
+	})
+
+	t.Run("Local sources are downloaded from file URLs", func(t *testing.T) {
+		localDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(localDir, "99"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(localDir, "99", "2.fantome"), content, 0644))
+		sources := NewSkinSources(newLogger, t.TempDir())
+		require.NoError(t, sources.SetSources([]SourceConfig{{Name: "local", Kind: SourceLocal, Path: localDir}}))
+		mockApp := NewMockAppEmitter(t)
+		mockApp.EXPECT().EmitEvent(EventDownloadProgress, mock.Anything).Return().Maybe()
+		manager := NewDownloadManager(newLogger, 1)
+		manager.SetApp(mockApp)
+
+		destination := filepath.Join(t.TempDir(), "99002.zip")
+		// A part left from a failed download is resumed from the file
+		require.NoError(t, os.WriteFile(destination+partSuffix, content[:100], 0644))
+		skin := ResolvedSkin{ChampionKey: 99, DownloadUrl: "https://skins.test/99/2.fantome", Sha256: checksum(content)}
+		results := manager.Download(context.Background(), []DownloadRequest{
+			{ID: "99002", Mirrors: sources.Mirrors(skin), Destination: destination, Sha256: checksum(content)},
+		})
+		require.NoError(t, results[0].Err)
+		data, err := os.ReadFile(destination)
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+	})
+}
+
+func TestSkinSources_EmbeddedCatalog(t *testing.T) {
+	// The catalog embedded by main.go, relative to the root of the repository
+	catalog, err := LoadCatalog(os.DirFS(filepath.Join("..", "..", "..", "..", "..")), CatalogPath)
+	require.NoError(t, err)
+	newLogger := logger.New("TestSources", &config.Config{LogLevel: "error"})
+	catalogService := NewCatalogService(newLogger, catalog)
+
+	t.Run("Every skin and chroma has a path the checksums can be keyed by", func(t *testing.T) {
+		for _, champion := range catalog.Catalog {
+			for _, skin := range champion.Skins {
+				_, ok := SkinPath(ResolvedSkin{ChampionKey: champion.ChampionKey, DownloadUrl: skin.DownloadUrl})
+				assert.True(t, ok, "skin %s", skin.DownloadUrl)
+				for _, chroma := range skin.Chromas {
+					_, ok := SkinPath(ResolvedSkin{ChampionKey: champion.ChampionKey, DownloadUrl: chroma.DownloadUrl})
+					assert.True(t, ok, "chroma %s", chroma.DownloadUrl)
+				}
+			}
+		}
+	})
+
+	t.Run("Skins of the catalog are downloaded from a mirror verified by the remote checksums", func(t *testing.T) {
+		packagePath := writeModPackage(t, "266020.zip", map[string]string{"WAD/Aatrox.wad.client": "skin"})
+		content, err := os.ReadFile(packagePath)
+		require.NoError(t, err)
+		cdn := httptest.NewServer(&fileServer{files: map[string][]byte{"/266/20.fantome": content}})
+		defer cdn.Close()
+
+		dir := t.TempDir()
+		sources := NewSkinSources(newLogger, t.TempDir())
+		require.NoError(t, sources.SetSources([]SourceConfig{
+			{Name: "cdn", Kind: SourceMirror, URL: cdn.URL},
+			{Name: "github", Kind: SourceCatalog},
+		}))
+		lolSkin := &LolSkin{
+			logger:    newLogger,
+			catalog:   catalogService,
+			downloads: NewDownloadManager(newLogger, 1),
+			tempDir:   dir,
+		}
+		lolSkin.SetSkinSources(sources)
+
+		resolved, err := catalogService.Resolve(266, 266020, nil)
+		require.NoError(t, err)
+		// Until the remote config has a checksum for it, an unhashed skin is only downloaded from the catalog
+		if resolved.Sha256 == "" {
+			assert.Equal(t, []Mirror{{Source: "github", URL: resolved.DownloadUrl}}, sources.Mirrors(*resolved))
+		}
+		sources.SetChecksums(map[string]string{"266/20.fantome": checksum(content)})
+		if resolved.Sha256 == "" {
+			assert.Equal(t, checksum(content), sources.Checksum(*resolved))
+		}
+		mirrors := sources.Mirrors(*resolved)
+		require.Len(t, mirrors, 2)
+		assert.Equal(t, Mirror{Source: "cdn", URL: cdn.URL + "/266/20.fantome"}, mirrors[0])
+
+		installed := lolSkin.DownloadSkins(context.Background(), []ChampionSkin{{ChampionID: 266, SkinID: 266020}})
+		assert.Equal(t, []string{"266020"}, installed)
+		assert.FileExists(t, filepath.Join(dir, "installed", "266020", "WAD", "Aatrox.wad.client"))
+		assert.Equal(t, 1, sources.GetHealth()[0].Successes)
+		assert.Zero(t, sources.GetHealth()[1].Successes+sources.GetHealth()[1].Failures, "GitHub is not contacted")
+	})
+}
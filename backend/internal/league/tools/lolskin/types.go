@@ -1,9 +1,15 @@
 package lolskin
 
+import (
+	"fmt"
+	"strconv"
+)
+
 type Chroma struct {
 	ChromaId     int      `json:"chromaId"`
 	ChromaColors []string `json:"chromaColors"`
 	DownloadUrl  string   `json:"downloadUrl"`
+	Sha256       string   `json:"sha256,omitempty"`
 	ChromaImage  string   `json:"chromaImage"`
 }
 
@@ -12,6 +18,7 @@ type Skin struct {
 	SkinId           int      `json:"skinId"`
 	Rarity           string   `json:"rarity"`
 	DownloadUrl      string   `json:"downloadUrl"`
+	Sha256           string   `json:"sha256,omitempty"`
 	LoadingScreenUrl string   `json:"loadingScreenUrl"`
 	Chromas          []Chroma `json:"chromas,omitempty"`
 }
@@ -26,5 +33,26 @@ type Champion struct {
 
 type Catalog struct {
 	LastCommitSha string     `json:"lastCommitSha"`
+	LastUpdated   int64      `json:"lastUpdated"`
 	Catalog       []Champion `json:"catalog"`
 }
+
+// ResolvedSkin is a catalog skin, or one of its chromas, ready to be downloaded
+type ResolvedSkin struct {
+	ChampionKey  int    `json:"championKey"`
+	ChampionName string `json:"championName"`
+	SkinID       int    `json:"skinId"`
+	SkinName     string `json:"skinName"`
+	ChromaID     *int   `json:"chromaId,omitempty"`
+	DownloadUrl  string `json:"downloadUrl"`
+	Sha256       string `json:"sha256,omitempty"`
+}
+
+// ModName is the folder the skin is installed to. Skin names aren't used, they can contain the "/" that
+// separates mods in mod-tools and every chroma of a skin shares it
+func (r ResolvedSkin) ModName() string {
+	if r.ChromaID != nil {
+		return fmt.Sprintf("%d-%d", r.SkinID, *r.ChromaID)
+	}
+	return strconv.Itoa(r.SkinID)
+}
@@ -31,16 +31,33 @@ type App interface {
 type SummonerClient interface {
 	GetRanking() (*types.RankedStatsRefresh, error)
 }
-type LolSkin interface {
-	DownloadFantome(championId int32, skinId int32) (string, error)
-	StopRunningPatcher()
-	InjectFantome(fantomePath string) error
-}
-
 type LolSkinState interface {
 	GetChampionSkin(championID int32) (lolskin.ChampionSkin, bool)
 	UpdateSelections(selections []lolskin.ChampionSkin)
 }
+
+// SessionRecorder defines the contract for tracking the usage of a rental session
+type SessionRecorder interface {
+	RecordWallet(wallet types.Wallet)
+	RecordGameflowPhase(phase types.LolChallengesGameflowPhase)
+	RecordGameflowSession(session types.LolGameflowV1Session)
+	RecordRanking(ranking *types.RankedStatsRefresh)
+}
+
+// AccountMonitor defines the contract for reporting the logged in username
+type AccountMonitor interface {
+	OnUsernameDetected(username string)
+}
+
+// LeaverBusterAnalyzer defines the contract for re-reading the leaver buster penalty
+type LeaverBusterAnalyzer interface {
+	Refresh() error
+}
+
+// RestrictionMonitor defines the contract for re-evaluating the account restrictions
+type RestrictionMonitor interface {
+	OnRankedRestriction(party types.PartyRestriction)
+}
 type eventRequest struct {
 	name string
 	data []any
@@ -48,19 +65,21 @@ type eventRequest struct {
 
 // Handler implements WebSocketEventHandler with standard event handling logic
 type Handler struct {
-	logger                   logger.Loggerer
-	accountClient            AccountClient
-	summonerClient           SummonerClient
-	accountState             AccountState
-	lolSkin                  LolSkin
-	isLolSkinEnabled         bool
-	eventCh                  chan eventRequest
-	lolSkinState             LolSkinState
-	previousChampionInjected int
-	app                      App
-	eventMutex               sync.Mutex
-	ctx                      context.Context
-	lolSkinService           *lolskin.Service
+	logger             logger.Loggerer
+	accountClient      AccountClient
+	summonerClient     SummonerClient
+	accountState       AccountState
+	isLolSkinEnabled   bool
+	eventCh            chan eventRequest
+	lolSkinState       LolSkinState
+	app                App
+	eventMutex         sync.Mutex
+	ctx                context.Context
+	lolSkinService     *lolskin.Service
+	sessionRecorder    SessionRecorder
+	accountMonitor     AccountMonitor
+	restrictionMonitor RestrictionMonitor
+	leaverBuster       LeaverBusterAnalyzer
 }
 
 // New creates a new WebSocket event handler
@@ -104,6 +123,18 @@ func (h *Handler) SetApp(app App) {
 	defer h.eventMutex.Unlock()
 	h.app = app
 }
+func (h *Handler) SetSessionRecorder(recorder SessionRecorder) {
+	h.sessionRecorder = recorder
+}
+func (h *Handler) SetAccountMonitor(accountMonitor AccountMonitor) {
+	h.accountMonitor = accountMonitor
+}
+func (h *Handler) SetRestrictionMonitor(restrictionMonitor RestrictionMonitor) {
+	h.restrictionMonitor = restrictionMonitor
+}
+func (h *Handler) SetLeaverBusterAnalyzer(leaverBuster LeaverBusterAnalyzer) {
+	h.leaverBuster = leaverBuster
+}
 func (h *Handler) ProcessAccountUpdate(update *types.PartialSummonerRented) error {
 	if !h.accountState.IsNexusAccount() {
 		h.logger.Info("Logged in account is not Nexus skipping update from websocket")
@@ -138,6 +169,9 @@ func (h *Handler) Wallet(event websocket.LCUWebSocketEvent) {
 	}
 
 	h.logger.Info("Wallet update", zap.Any("data", walletData))
+	if h.sessionRecorder != nil {
+		h.sessionRecorder.RecordWallet(walletData)
+	}
 
 	blueEssence := walletData.LolBlueEssence
 	currentAccount := h.accountState.Get()
@@ -230,6 +264,9 @@ func (h *Handler) GameflowPhase(event websocket.LCUWebSocketEvent) {
 	}
 
 	h.logger.Info("Gameflow phase changed", zap.String("phase", string(gameflowPhase)))
+	if h.sessionRecorder != nil {
+		h.sessionRecorder.RecordGameflowPhase(gameflowPhase)
+	}
 
 	// Check if this is an end-game phase
 	if gameflowPhase == types.LolChallengesGameflowPhaseEndOfGame || gameflowPhase == types.LolChallengesGameflowPhasePreEndOfGame || gameflowPhase == types.LolChallengesGameflowPhaseWaitingForStats {
@@ -241,6 +278,9 @@ func (h *Handler) GameflowPhase(event websocket.LCUWebSocketEvent) {
 			h.logger.Error("Failed to get ranking information", zap.Error(err))
 			return
 		}
+		if h.sessionRecorder != nil {
+			h.sessionRecorder.RecordRanking(ranking)
+		}
 
 		// Get current account state
 		currentAccount := h.accountState.Get()
@@ -276,56 +316,30 @@ func (h *Handler) GameflowPhase(event websocket.LCUWebSocketEvent) {
 		}
 	}
 }
+
+// ChampionPicked follows the local pick in the champ select session, the skin is prefetched while the champion
+// is hovered and injected once it's locked
 func (h *Handler) ChampionPicked(event websocket.LCUWebSocketEvent) {
-	if true {
+	if event.EventType == websocket.EventTypeDelete {
+		h.logger.Debug("Champ select ended")
+		h.lolSkinService.ChampSelectEnded()
 		return
 	}
-	var LolChampSelect types.LolChampSelectGridChampions
-	if err := json.Unmarshal(event.Data, &LolChampSelect); err != nil {
-		h.logger.Error("Failed to parse champion data", zap.Error(err))
+	var session types.LolChampSelectSession
+	if err := json.Unmarshal(event.Data, &session); err != nil {
+		h.logger.Error("Failed to parse champ select session", zap.Error(err))
 		return
 	}
 
-	if LolChampSelect.SelectionStatus.SelectedByMe &&
-		(!LolChampSelect.SelectionStatus.PickIntented &&
-			!LolChampSelect.SelectionStatus.PickIntentedByMe) {
-
-		championId := int32(LolChampSelect.ChampionId)
-		h.logger.Info("Champion picked",
-			zap.String("championName", LolChampSelect.Name),
-			zap.Int32("championId", championId))
-
-		championSkin, found := h.lolSkinState.GetChampionSkin(championId)
-		if !found {
-			h.logger.Info("Champion skin not found for this champion")
-			h.previousChampionInjected = 0 // Reset on error
-
-			return
-		}
-
-		// Download and inject in a single goroutine to ensure proper sequence
-		go func() {
-			fantomePath, err := h.lolSkin.DownloadFantome(championId, championSkin.SkinID)
-			if err != nil {
-				h.logger.Error("Failed to download fantome", zap.Error(err))
-				h.previousChampionInjected = 0 // Reset on error
-
-				return
-			}
-
-			h.logger.Info("Injecting skin for champion",
-				zap.String("championName", LolChampSelect.Name),
-				zap.Int32("skinID", championSkin.SkinID))
-			err = h.lolSkin.InjectFantome(fantomePath)
-			if err != nil {
-				h.logger.Error("Failed to inject fantome", zap.Error(err))
-				h.previousChampionInjected = 0 // Reset on error
-				return
-			}
-
-			h.previousChampionInjected = int(championId)
-		}()
+	championID, locked := session.LocalPick()
+	if championID == 0 {
+		return
 	}
+	if locked {
+		h.lolSkinService.ChampionLocked(championID)
+		return
+	}
+	h.lolSkinService.ChampionHovered(championID)
 }
 
 func (h *Handler) Restriction(event websocket.LCUWebSocketEvent) {
@@ -334,6 +348,17 @@ func (h *Handler) Restriction(event websocket.LCUWebSocketEvent) {
 		h.logger.Error("Failed to parse gameflow phase data", zap.Error(err))
 		return
 	}
+	if h.restrictionMonitor != nil {
+		h.restrictionMonitor.OnRankedRestriction(restriction)
+	}
+	if h.leaverBuster != nil {
+		// The event only carries the games remaining, the full penalty is read from the leaver buster
+		go func() {
+			if err := h.leaverBuster.Refresh(); err != nil {
+				h.logger.Error("Failed to refresh leaver buster penalty", zap.Error(err))
+			}
+		}()
+	}
 
 	// Extract the current punished games count from existing account data
 	account := h.accountState.Get()
@@ -357,6 +382,32 @@ func (h *Handler) Restriction(event websocket.LCUWebSocketEvent) {
 		h.logger.Debug("No change in leaver buster information, skipping update")
 	}
 }
+
+// LoginSession forwards the username of a successful league client login to the account monitor
+func (h *Handler) LoginSession(event websocket.LCUWebSocketEvent) {
+	var loginSession types.LoginSession
+	if err := json.Unmarshal(event.Data, &loginSession); err != nil {
+		h.logger.Error("Failed to parse login session data", zap.Error(err))
+		return
+	}
+	if loginSession.State != "SUCCEEDED" || h.accountMonitor == nil {
+		return
+	}
+	h.accountMonitor.OnUsernameDetected(loginSession.Username)
+}
+
+// GameflowSession records the queue of the current session and re-emits the event to the frontend
+func (h *Handler) GameflowSession(event websocket.LCUWebSocketEvent) {
+	if h.sessionRecorder != nil {
+		var session types.LolGameflowV1Session
+		if err := json.Unmarshal(event.Data, &session); err != nil {
+			h.logger.Error("Failed to parse gameflow session data", zap.Error(err))
+		} else {
+			h.sessionRecorder.RecordGameflowSession(session)
+		}
+	}
+	h.ReemitEvent(event)
+}
 func (h *Handler) ReemitEvent(event websocket.LCUWebSocketEvent) {
 	h.logger.Info("Re-emitting event", zap.String("event", event.EventTopic), zap.String("uri", event.URI))
 	h.eventCh <- eventRequest{
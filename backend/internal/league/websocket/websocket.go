@@ -65,6 +65,8 @@ type Handler interface {
 	ChampionPicked(event LCUWebSocketEvent)
 	Restriction(event LCUWebSocketEvent)
 	ReemitEvent(event LCUWebSocketEvent)
+	GameflowSession(event LCUWebSocketEvent)
+	LoginSession(event LCUWebSocketEvent)
 }
 
 // RouterInterface defines the contract for the event router
@@ -91,6 +93,13 @@ type LCUWebSocketEvent struct {
 // EventType represents possible event types
 type EventType int
 
+// LCUWebSocketEvent.EventType values
+const (
+	EventTypeCreate = 0
+	EventTypeUpdate = 1
+	EventTypeDelete = 2
+)
+
 type Service struct {
 	app            App
 	accountClient  AccountsRepository
@@ -475,12 +484,13 @@ func (s *Service) GetHandlers() []EventHandler {
 		s.manager.NewEventHandler("OnJsonApiEvent_lol-inventory_v1_wallet", s.handler.Wallet),
 		s.manager.NewEventHandler("OnJsonApiEvent_lol-gameflow_v1_gameflow-phase", s.handler.GameflowPhase),
 		s.manager.NewEventHandler("OnJsonApiEvent_lol-inventory_v2_inventory", s.handler.ChampionPurchase),
-		s.manager.NewEventHandler("OnJsonApiEvent_lol-champ-select_v1_grid-champions", s.handler.ChampionPicked),
+		s.manager.NewEventHandler("OnJsonApiEvent_lol-champ-select_v1_session", s.handler.ChampionPicked),
 		s.manager.NewEventHandler("OnJsonApiEvent_lol-champ-select_v1_skin-selector-info", s.handler.ReemitEvent),
 		s.manager.NewEventHandler("OnJsonApiEvent_lol-leaver-buster_v1_ranked-restriction", s.handler.Restriction),
 		s.manager.NewEventHandler("OnJsonApiEvent_lol-lobby-team-builder_champ-select_v1", s.handler.ReemitEvent),
 		s.manager.NewEventHandler("OnJsonApiEvent_lol-summoner_v1_current-summoner", s.handler.ReemitEvent),
-		s.manager.NewEventHandler("OnJsonApiEvent_lol-gameflow_v1_session", s.handler.ReemitEvent),
+		s.manager.NewEventHandler("OnJsonApiEvent_lol-gameflow_v1_session", s.handler.GameflowSession),
+		s.manager.NewEventHandler("OnJsonApiEvent_lol-login_v1_session", s.handler.LoginSession),
 	}
 }
 func (s *Service) SubscribeToLeagueEvents() {
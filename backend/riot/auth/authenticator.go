@@ -7,7 +7,8 @@ import (
 )
 
 type Authenticator interface {
-	LoginWithCaptcha(ctx context.Context, username string, password string, captchaToken string) (string, error)
+	LoginWithCaptcha(ctx context.Context, username string, password *types.Credential, captchaToken string) (string, error)
+	SubmitMultifactorCode(code string, trustDevice bool) error
 	GetAuthenticationState() (*types.RiotIdentityResponse, error)
 	IsAuthStateValid() error
 	Logout() error
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+)
+
+// Stable error codes sent to the frontend, the older ones keep the values the frontend already checks for
+const (
+	CodeMultifactorRequired = "multifactor"
+	CodeMultifactorInvalid  = "multifactor_attempt_failed"
+	CodeInvalidCredentials  = "auth_failure"
+	CodeCaptchaRejected     = "captcha_not_allowed"
+	CodeRateLimited         = "rate_limited"
+	CodePermanentBan        = "permanent_banned"
+	CodeTimedBan            = "timed_banned"
+	CodeClientNotReady      = "client_not_ready"
+	CodeEulaRequired        = "eula"
+	CodeNameChangeRequired  = "name_change_required"
+	CodeAgeRestricted       = "age_restricted"
+)
+
+// Authentication outcomes, match them with errors.Is. The message of each one is its code
+var (
+	ErrMultifactorRequired = errors.New(CodeMultifactorRequired)
+	ErrMultifactorInvalid  = errors.New(CodeMultifactorInvalid)
+	ErrInvalidCredentials  = errors.New(CodeInvalidCredentials)
+	ErrCaptchaRejected     = errors.New(CodeCaptchaRejected)
+	ErrRateLimited         = errors.New(CodeRateLimited)
+	ErrPermanentBan        = errors.New(CodePermanentBan)
+	ErrTimedBan            = errors.New(CodeTimedBan)
+	ErrClientNotReady      = errors.New(CodeClientNotReady)
+	ErrEulaRequired        = errors.New(CodeEulaRequired)
+	ErrNameChangeRequired  = errors.New(CodeNameChangeRequired)
+	ErrAgeRestricted       = errors.New(CodeAgeRestricted)
+)
+
+var kinds = []error{
+	ErrMultifactorRequired,
+	ErrMultifactorInvalid,
+	ErrInvalidCredentials,
+	ErrCaptchaRejected,
+	ErrRateLimited,
+	ErrPermanentBan,
+	ErrTimedBan,
+	ErrClientNotReady,
+	ErrEulaRequired,
+	ErrNameChangeRequired,
+	ErrAgeRestricted,
+}
+
+// Error is a failed Riot authentication, Kind is one of the sentinel errors and the other fields are only
+// set when they apply to it. Use errors.As to read the details
+type Error struct {
+	Kind              error
+	Username          string
+	HTTPStatus        int
+	MultifactorMethod string
+	MultifactorEmail  string
+	CaptchaData       string
+	RetryAfter        time.Duration
+	Restrictions      []types.Restriction
+	RequiredActions   []types.RequiredAction
+	Err               error
+}
+
+// Error starts with the code so the frontend can rely on it
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Code() + ": " + e.Err.Error()
+	}
+	return e.Code()
+}
+
+func (e *Error) Code() string {
+	if e.Kind == nil {
+		return "auth_error"
+	}
+	return e.Kind.Error()
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Code returns the stable code of an authentication error, or an empty string when err is not one
+func Code(err error) string {
+	var authErr *Error
+	if errors.As(err, &authErr) {
+		return authErr.Code()
+	}
+	for _, kind := range kinds {
+		if errors.Is(err, kind) {
+			return kind.Error()
+		}
+	}
+	return ""
+}
+
+// IsPermanent reports whether retrying can't change the outcome, the account itself has to change
+func IsPermanent(err error) bool {
+	return errors.Is(err, ErrMultifactorRequired) ||
+		errors.Is(err, ErrInvalidCredentials) ||
+		errors.Is(err, ErrPermanentBan) ||
+		errors.Is(err, ErrTimedBan) ||
+		errors.Is(err, ErrEulaRequired) ||
+		errors.Is(err, ErrNameChangeRequired) ||
+		errors.Is(err, ErrAgeRestricted)
+}
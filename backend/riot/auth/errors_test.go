@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	t.Run("Matches its kind through wrapping", func(t *testing.T) {
+		err := fmt.Errorf("login failed: %w", &Error{Kind: ErrRateLimited, RetryAfter: 30 * time.Second})
+
+		assert.True(t, errors.Is(err, ErrRateLimited))
+		assert.False(t, errors.Is(err, ErrInvalidCredentials))
+
+		var authErr *Error
+		assert.True(t, errors.As(err, &authErr))
+		assert.Equal(t, 30*time.Second, authErr.RetryAfter)
+	})
+
+	t.Run("Message starts with the code", func(t *testing.T) {
+		cause := errors.New("lockfile not found")
+		err := &Error{Kind: ErrClientNotReady, Err: cause}
+
+		assert.Equal(t, "client_not_ready: lockfile not found", err.Error())
+		assert.True(t, errors.Is(err, cause))
+		assert.Equal(t, CodeMultifactorRequired, (&Error{Kind: ErrMultifactorRequired}).Error())
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		assert.Equal(t, CodePermanentBan, Code(&Error{Kind: ErrPermanentBan}))
+		assert.Equal(t, CodeCaptchaRejected, Code(fmt.Errorf("wrapped: %w", ErrCaptchaRejected)))
+		assert.Empty(t, Code(errors.New("authentication with captcha failed")))
+	})
+
+	t.Run("IsPermanent", func(t *testing.T) {
+		assert.True(t, IsPermanent(&Error{Kind: ErrInvalidCredentials}))
+		assert.True(t, IsPermanent(&Error{Kind: ErrMultifactorRequired}))
+		assert.False(t, IsPermanent(&Error{Kind: ErrRateLimited}))
+		assert.False(t, IsPermanent(&Error{Kind: ErrCaptchaRejected}))
+	})
+}
+
+func TestDetectRequiredActions(t *testing.T) {
+	tests := []struct {
+		name     string
+		signals  RequiredActionSignals
+		expected []string
+		code     string
+	}{
+		{
+			name:    "Nothing pending",
+			signals: RequiredActionSignals{EulaAcceptance: EulaAccepted},
+		},
+		{
+			name:    "Unknown signals are not blocking",
+			signals: RequiredActionSignals{},
+		},
+		{
+			name:     "EULA",
+			signals:  RequiredActionSignals{EulaAcceptance: "AcceptanceRequired"},
+			expected: []string{types.RequiredActionEula},
+			code:     CodeEulaRequired,
+		},
+		{
+			name:     "LCU name change flag",
+			signals:  RequiredActionSignals{NameChangeFlag: true},
+			expected: []string{types.RequiredActionNameChange},
+			code:     CodeNameChangeRequired,
+		},
+		{
+			name:     "Parental gate",
+			signals:  RequiredActionSignals{ParentalConsentRequired: true},
+			expected: []string{types.RequiredActionAgeGate},
+			code:     CodeAgeRestricted,
+		},
+		{
+			name:     "Several actions are reported in completion order",
+			signals:  RequiredActionSignals{EulaAcceptance: "AcceptanceRequired", AgeRestricted: true, NameChangeFlag: true},
+			expected: []string{types.RequiredActionEula, types.RequiredActionAgeGate, types.RequiredActionNameChange},
+			code:     CodeEulaRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions := DetectRequiredActions(tt.signals)
+
+			actionTypes := make([]string, 0, len(actions))
+			for _, action := range actions {
+				actionTypes = append(actionTypes, action.Type)
+			}
+			assert.ElementsMatch(t, tt.expected, actionTypes)
+			if tt.code == "" {
+				assert.NoError(t, RequiredActionError("rented", actions))
+				return
+			}
+			assert.Equal(t, tt.expected, actionTypes)
+			err := RequiredActionError("rented", actions)
+			assert.Equal(t, tt.code, Code(err))
+			assert.True(t, IsPermanent(err))
+			assert.Len(t, RequiredActionRestrictions(nil, actions), len(actions))
+		})
+	}
+}
+
+func TestRequiredActionRestrictions(t *testing.T) {
+	saved := []types.Restriction{
+		{Type: "QUEUE_LOCKOUT", Reason: "leaver"},
+		{Type: types.RequiredActionEula, Reason: "AcceptanceRequired"},
+		{Type: types.RequiredActionNameChange, Reason: "summoner name change flag"},
+	}
+	actions := []types.RequiredAction{{Type: types.RequiredActionEula, Detail: "AcceptanceRequired"}}
+
+	restrictions := RequiredActionRestrictions(saved, actions)
+
+	assert.Equal(t, []types.Restriction{
+		{Type: "QUEUE_LOCKOUT", Reason: "leaver"},
+		{Type: types.RequiredActionEula, Reason: "AcceptanceRequired"},
+	}, restrictions, "other restrictions are kept and the required actions are the ones found now")
+}
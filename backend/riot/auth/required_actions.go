@@ -0,0 +1,81 @@
+package auth
+
+import "github.com/hex-boost/hex-nexus-app/backend/types"
+
+// EulaAccepted is the Riot client agreement acceptance once the EULA and terms of service were accepted
+const EulaAccepted = "Accepted"
+
+// RequiredActionSignals is what the Riot client and the LCU report after the login, empty values are unknown
+type RequiredActionSignals struct {
+	// EulaAcceptance is the Riot client agreement acceptance, e.g. Accepted or AcceptanceRequired
+	EulaAcceptance string
+	// NameChangeFlag is set by the LCU when the summoner must pick a new Riot ID, it's the only signal of a
+	// forced change. A missing game name isn't one, the userinfo can omit it for accounts that are fine
+	NameChangeFlag          bool
+	AgeRestricted           bool
+	ParentalConsentRequired bool
+}
+
+// requiredActionKinds maps each required action to its error
+var requiredActionKinds = map[string]error{
+	types.RequiredActionEula:       ErrEulaRequired,
+	types.RequiredActionAgeGate:    ErrAgeRestricted,
+	types.RequiredActionNameChange: ErrNameChangeRequired,
+}
+
+// DetectRequiredActions returns the actions blocking the account, in the order they must be completed
+func DetectRequiredActions(signals RequiredActionSignals) []types.RequiredAction {
+	var actions []types.RequiredAction
+	if signals.EulaAcceptance != "" && signals.EulaAcceptance != EulaAccepted {
+		actions = append(actions, types.RequiredAction{
+			Type:   types.RequiredActionEula,
+			Source: types.RequiredActionSourceRiotClient,
+			Detail: signals.EulaAcceptance,
+		})
+	}
+	if signals.AgeRestricted || signals.ParentalConsentRequired {
+		detail := "age restricted"
+		if signals.ParentalConsentRequired {
+			detail = "parental consent required"
+		}
+		actions = append(actions, types.RequiredAction{
+			Type:   types.RequiredActionAgeGate,
+			Source: types.RequiredActionSourceRiotClient,
+			Detail: detail,
+		})
+	}
+	if signals.NameChangeFlag {
+		actions = append(actions, types.RequiredAction{
+			Type:   types.RequiredActionNameChange,
+			Source: types.RequiredActionSourceLCU,
+			Detail: "summoner name change flag",
+		})
+	}
+	return actions
+}
+
+// RequiredActionError reports the required actions as a blocking condition, its kind is the first one to complete
+func RequiredActionError(username string, actions []types.RequiredAction) error {
+	if len(actions) == 0 {
+		return nil
+	}
+	return &Error{Kind: requiredActionKinds[actions[0].Type], Username: username, RequiredActions: actions}
+}
+
+// RequiredActionRestrictions merges the actions into the restrictions saved on the account. The saved
+// restrictions are kept, except the required actions that are replaced by the ones found now
+func RequiredActionRestrictions(saved []types.Restriction, actions []types.RequiredAction) []types.Restriction {
+	restrictions := make([]types.Restriction, 0, len(saved)+len(actions))
+	for _, restriction := range saved {
+		if _, ok := requiredActionKinds[restriction.Type]; !ok {
+			restrictions = append(restrictions, restriction)
+		}
+	}
+	for _, action := range actions {
+		restrictions = append(restrictions, types.Restriction{
+			Type:   action.Type,
+			Reason: action.Detail,
+		})
+	}
+	return restrictions
+}
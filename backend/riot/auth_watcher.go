@@ -0,0 +1,110 @@
+package riot
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// authenticationEvents are the Riot client websocket topics published when the signed in account changes
+var authenticationEvents = []string{
+	"OnJsonApiEvent_rso-authenticator_v1_authentication",
+	"OnJsonApiEvent_rso-auth_v1_authorization",
+}
+
+// WatchAuthentication connects to the Riot client websocket and calls onChange whenever the
+// authentication state changes, reconnecting until the context is cancelled
+func (s *Service) WatchAuthentication(ctx context.Context, onChange func()) {
+	reconnectTicker := time.NewTicker(5 * time.Second)
+	defer reconnectTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Debug("Riot client authentication watcher stopped")
+			return
+		case <-reconnectTicker.C:
+			if !s.isProcessRunning() {
+				continue
+			}
+			if err := s.readAuthenticationEvents(ctx, onChange); err != nil {
+				s.logger.Debug("Riot client websocket disconnected", zap.Error(err))
+			}
+		}
+	}
+}
+
+// readAuthenticationEvents blocks while the websocket connection is alive
+func (s *Service) readAuthenticationEvents(ctx context.Context, onChange func()) error {
+	port, authToken, err := s.getCredentials()
+	if err != nil {
+		return err
+	}
+	u := url.URL{Scheme: "wss", Host: "127.0.0.1:" + port}
+	return s.readEvents(ctx, u.String(), authToken, onChange)
+}
+
+// readEvents subscribes to the authentication topics of the websocket at address and calls onChange
+// until the connection closes
+func (s *Service) readEvents(ctx context.Context, address string, authToken string, onChange func()) error {
+	// The Riot client uses a self-signed certificate, a dialer of our own keeps the default one verifying
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:  &tls.Config{InsecureSkipVerify: true},
+	}
+	headers := http.Header{}
+	headers.Add("Authorization", "Basic "+authToken)
+
+	conn, _, err := dialer.DialContext(ctx, address, headers)
+	if err != nil {
+		return fmt.Errorf("failed to connect to riot client websocket: %w", err)
+	}
+	defer conn.Close()
+
+	for _, topic := range authenticationEvents {
+		// WAMP 1.0 subscribe opcode
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`[5, "%s"]`, topic))); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+		}
+	}
+	s.logger.Info("Connected to Riot client websocket")
+
+	// The connection is the only thing blocking the reader, close it to unblock on cancellation
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	// The client was already signed in before we connected
+	onChange()
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		var event []json.RawMessage
+		if err := json.Unmarshal(message, &event); err != nil || len(event) < 3 {
+			continue
+		}
+		var opcode int
+		if err := json.Unmarshal(event[0], &opcode); err != nil || opcode != 8 {
+			continue
+		}
+		onChange()
+	}
+}
@@ -0,0 +1,118 @@
+package riot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRiotWebsocket serves a fake Riot client websocket, every subscription is sent to subscriptions and
+// the messages of events are written once both topics are subscribed
+func newRiotWebsocket(t *testing.T, subscriptions chan<- string, events ...string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Basic token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for range authenticationEvents {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			subscriptions <- string(message)
+		}
+		for _, event := range events {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(event)); err != nil {
+				return
+			}
+		}
+		// Keep the connection open until the client closes it
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func websocketAddress(server *httptest.Server) string {
+	return "wss" + strings.TrimPrefix(server.URL, "https")
+}
+
+func TestReadEvents(t *testing.T) {
+	service := &Service{logger: logger.New("TestAuthWatcher", &config.Config{LogLevel: "error"})}
+
+	t.Run("Authentication events call onChange", func(t *testing.T) {
+		subscriptions := make(chan string, len(authenticationEvents))
+		server := newRiotWebsocket(t, subscriptions,
+			`[8, "OnJsonApiEvent_rso-authenticator_v1_authentication", {"eventType": "Update"}]`,
+			`[0, "welcome"]`,
+			`not json`,
+			`[8, "OnJsonApiEvent_rso-auth_v1_authorization", {"eventType": "Delete"}]`,
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		changes := make(chan struct{}, 10)
+		done := make(chan error, 1)
+		go func() {
+			done <- service.readEvents(ctx, websocketAddress(server), "token", func() { changes <- struct{}{} })
+		}()
+
+		for _, topic := range authenticationEvents {
+			assert.Equal(t, `[5, "`+topic+`"]`, <-subscriptions)
+		}
+		// The initial check and one per authentication event
+		for i := 0; i < 3; i++ {
+			select {
+			case <-changes:
+			case <-time.After(time.Second):
+				t.Fatalf("expected 3 changes, got %d", i)
+			}
+		}
+		assert.Empty(t, changes, "only opcode 8 events are changes")
+
+		cancel()
+		select {
+		case err := <-done:
+			assert.Error(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("readEvents did not return after cancellation")
+		}
+	})
+
+	t.Run("Rejected connection is an error", func(t *testing.T) {
+		server := newRiotWebsocket(t, make(chan string, len(authenticationEvents)))
+
+		err := service.readEvents(context.Background(), websocketAddress(server), "wrong", func() {
+			t.Error("onChange must not be called")
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to connect to riot client websocket")
+	})
+
+	t.Run("Default dialer is left untouched", func(t *testing.T) {
+		server := newRiotWebsocket(t, make(chan string, len(authenticationEvents)))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		service.readEvents(ctx, websocketAddress(server), "token", func() {})
+
+		assert.Nil(t, websocket.DefaultDialer.TLSClientConfig)
+	})
+}
@@ -15,7 +15,7 @@ func getAuthorizationRequestPayload() types.AuthorizationRequest {
 	}
 }
 
-func getRiotIdentityStartPayload() types.RiotIdentityStartPayload {
+func getRiotIdentityStartPayload(locale string) types.RiotIdentityStartPayload {
 	return types.RiotIdentityStartPayload{
 		Apple:        nil,
 		Campaign:     nil,
@@ -24,7 +24,7 @@ func getRiotIdentityStartPayload() types.RiotIdentityStartPayload {
 		Facebook:     nil,
 		Gamecenter:   nil,
 		Google:       nil,
-		Language:     "pt_BR",
+		Language:     locale,
 		MockDeviceId: nil,
 		MockPlatform: nil,
 		Multifactor:  nil,
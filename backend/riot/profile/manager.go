@@ -0,0 +1,257 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// settingsFile holds the profiles and the selections in the app data directory
+const settingsFile = "launch_profiles.json"
+
+var ErrProfileNotFound = errors.New("launch profile not found")
+
+// settings is the file persisted on disk
+type settings struct {
+	Profiles        []Profile         `json:"profiles"`
+	UserProfiles    map[int]string    `json:"userProfiles"`
+	AccountProfiles map[string]string `json:"accountProfiles"`
+}
+
+// Manager stores the launch profiles and which one is selected for each user and rented account. A rented
+// account selection takes precedence over the user selection
+type Manager struct {
+	logger          *logger.Logger
+	path            string
+	mutex           sync.RWMutex
+	profiles        map[string]Profile
+	userProfiles    map[int]string
+	accountProfiles map[string]string
+	activeUser      int
+	activeAccount   string
+}
+
+func New(logger *logger.Logger) *Manager {
+	path, err := config.DataPath(settingsFile)
+	if err != nil {
+		logger.Error("Failed to resolve launch profiles path", zap.Error(err))
+	}
+	return NewManager(logger, path)
+}
+
+func NewManager(logger *logger.Logger, path string) *Manager {
+	m := &Manager{
+		logger:          logger,
+		path:            path,
+		profiles:        map[string]Profile{DefaultID: Default},
+		userProfiles:    make(map[int]string),
+		accountProfiles: make(map[string]string),
+	}
+	if err := m.load(); err != nil {
+		logger.Warn("Failed to load launch profiles, using defaults", zap.Error(err))
+	}
+	return m
+}
+
+// List returns every profile sorted by name, the default profile is always present
+func (m *Manager) List() []Profile {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	profiles := make([]Profile, 0, len(m.profiles))
+	for _, profile := range m.profiles {
+		profiles = append(profiles, profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		if profiles[i].ID == DefaultID || profiles[j].ID == DefaultID {
+			return profiles[i].ID == DefaultID
+		}
+		return profiles[i].Name < profiles[j].Name
+	})
+	return profiles
+}
+
+// Save creates or replaces a profile
+func (m *Manager) Save(profile Profile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.profiles[profile.ID] = profile
+	return m.persist()
+}
+
+// Delete removes a profile, users and accounts that selected it go back to their fallback
+func (m *Manager) Delete(profileID string) error {
+	if profileID == DefaultID {
+		return errors.New("the default launch profile can't be deleted")
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.profiles[profileID]; !ok {
+		return ErrProfileNotFound
+	}
+	delete(m.profiles, profileID)
+	for userID, selected := range m.userProfiles {
+		if selected == profileID {
+			delete(m.userProfiles, userID)
+		}
+	}
+	for username, selected := range m.accountProfiles {
+		if selected == profileID {
+			delete(m.accountProfiles, username)
+		}
+	}
+	return m.persist()
+}
+
+// SelectForUser sets the profile used by the user, an empty profile id clears the selection
+func (m *Manager) SelectForUser(userID int, profileID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if profileID == "" {
+		delete(m.userProfiles, userID)
+		return m.persist()
+	}
+	if _, ok := m.profiles[profileID]; !ok {
+		return ErrProfileNotFound
+	}
+	m.userProfiles[userID] = profileID
+	return m.persist()
+}
+
+// SelectForAccount sets the profile used for a rented account, an empty profile id clears the selection
+func (m *Manager) SelectForAccount(username string, profileID string) error {
+	username = normalizeUsername(username)
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if profileID == "" {
+		delete(m.accountProfiles, username)
+		return m.persist()
+	}
+	if _, ok := m.profiles[profileID]; !ok {
+		return ErrProfileNotFound
+	}
+	m.accountProfiles[username] = profileID
+	return m.persist()
+}
+
+// SetActiveUser sets the Nexus user whose selection applies to the next launches
+func (m *Manager) SetActiveUser(userID int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.activeUser = userID
+}
+
+// SetActiveAccount sets the rented account whose selection applies to the next launches
+func (m *Manager) SetActiveAccount(username string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.activeAccount = normalizeUsername(username)
+}
+
+// Active returns the profile for the active user and rented account
+func (m *Manager) Active() Profile {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.resolve(m.activeUser, m.activeAccount)
+}
+
+// Resolve returns the profile for the given user and rented account
+func (m *Manager) Resolve(userID int, username string) Profile {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.resolve(userID, normalizeUsername(username))
+}
+
+func (m *Manager) resolve(userID int, username string) Profile {
+	if profileID, ok := m.accountProfiles[username]; ok && username != "" {
+		if profile, ok := m.profiles[profileID]; ok {
+			return profile
+		}
+	}
+	if profileID, ok := m.userProfiles[userID]; ok {
+		if profile, ok := m.profiles[profileID]; ok {
+			return profile
+		}
+	}
+	return m.profiles[DefaultID]
+}
+
+func (m *Manager) load() error {
+	if m.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(m.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read launch profiles: %w", err)
+	}
+
+	var stored settings
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return fmt.Errorf("failed to decode launch profiles: %w", err)
+	}
+	for _, profile := range stored.Profiles {
+		if err := profile.Validate(); err != nil {
+			m.logger.Warn("Skipping invalid launch profile", zap.String("id", profile.ID), zap.Error(err))
+			continue
+		}
+		m.profiles[profile.ID] = profile
+	}
+	for userID, profileID := range stored.UserProfiles {
+		m.userProfiles[userID] = profileID
+	}
+	for username, profileID := range stored.AccountProfiles {
+		m.accountProfiles[normalizeUsername(username)] = profileID
+	}
+	return nil
+}
+
+// persist writes the settings, the caller must hold the write lock
+func (m *Manager) persist() error {
+	if m.path == "" {
+		return errors.New("launch profiles path is not set")
+	}
+	stored := settings{
+		Profiles:        make([]Profile, 0, len(m.profiles)),
+		UserProfiles:    m.userProfiles,
+		AccountProfiles: m.accountProfiles,
+	}
+	for _, profile := range m.profiles {
+		stored.Profiles = append(stored.Profiles, profile)
+	}
+	sort.Slice(stored.Profiles, func(i, j int) bool { return stored.Profiles[i].ID < stored.Profiles[j].ID })
+
+	data, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode launch profiles: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0755); err != nil {
+		return fmt.Errorf("failed to create launch profiles directory: %w", err)
+	}
+	if err := os.WriteFile(m.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write launch profiles: %w", err)
+	}
+	return nil
+}
+
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
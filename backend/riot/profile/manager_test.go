@@ -0,0 +1,116 @@
+package profile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+var pbe = Profile{ID: "pbe", Name: "PBE", Locale: "en_US", Patchline: PatchlinePBE, Region: "na"}
+
+func TestProfile(t *testing.T) {
+	t.Run("LaunchArgs", func(t *testing.T) {
+		profile := pbe
+		profile.ExtraArgs = []string{"--allow-multiple-clients"}
+
+		assert.Equal(t, []string{
+			"--launch-product=league_of_legends",
+			"--launch-patchline=pbe",
+			"--locale=en_US",
+			"--region=NA",
+			"--allow-multiple-clients",
+		}, profile.LaunchArgs())
+	})
+
+	t.Run("LaunchArgs keeps the client locale without a profile locale", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"--launch-product=league_of_legends",
+			"--launch-patchline=live",
+		}, Default.LaunchArgs())
+		assert.Equal(t, "pt_BR", Default.Language())
+		assert.Equal(t, "en_US", pbe.Language())
+	})
+
+	t.Run("LaunchArgs maps platform ids to region codes", func(t *testing.T) {
+		profile := Default
+		profile.Region = "eun1"
+
+		assert.Contains(t, profile.LaunchArgs(), "--region=EUNE")
+	})
+
+	t.Run("ProductSettingsPath follows the patchline", func(t *testing.T) {
+		path := pbe.ProductSettingsPath("C:\\ProgramData")
+
+		assert.Equal(t, filepath.Join("C:\\ProgramData", "Riot Games", "Metadata", "league_of_legends.pbe", "league_of_legends.pbe.product_settings.yaml"), path)
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		assert.NoError(t, Default.Validate())
+		assert.Error(t, Profile{ID: "x", Locale: "portuguese", Patchline: PatchlineLive}.Validate())
+		assert.Error(t, Profile{ID: "x", Locale: "pt_BR", Patchline: "beta"}.Validate())
+		assert.Error(t, Profile{ID: "x", Locale: "pt_BR", Patchline: PatchlineLive, ExtraArgs: []string{"--launch-patchline=pbe"}}.Validate())
+		assert.Error(t, Profile{ID: "x", Locale: "pt_BR", Patchline: PatchlineLive, ExtraArgs: []string{"rm"}}.Validate())
+	})
+}
+
+func TestManager(t *testing.T) {
+	cfg := &config.Config{LogLevel: "error"}
+	newLogger := logger.New("TestProfiles", cfg)
+
+	t.Run("Falls back to the default profile", func(t *testing.T) {
+		manager := NewManager(newLogger, filepath.Join(t.TempDir(), "launch_profiles.json"))
+
+		assert.Equal(t, Default, manager.Active())
+	})
+
+	t.Run("Account selection takes precedence over the user selection", func(t *testing.T) {
+		manager := NewManager(newLogger, filepath.Join(t.TempDir(), "launch_profiles.json"))
+		euw := Profile{ID: "euw", Name: "EUW", Locale: "en_GB", Patchline: PatchlineLive, Region: "EUW"}
+		assert.NoError(t, manager.Save(pbe))
+		assert.NoError(t, manager.Save(euw))
+		assert.NoError(t, manager.SelectForUser(7, "euw"))
+		assert.NoError(t, manager.SelectForAccount("Rented", "pbe"))
+
+		assert.Equal(t, euw, manager.Resolve(7, "other"))
+		assert.Equal(t, pbe, manager.Resolve(7, "rented"))
+		assert.Equal(t, Default, manager.Resolve(8, ""))
+
+		manager.SetActiveUser(7)
+		manager.SetActiveAccount(" RENTED ")
+		assert.Equal(t, pbe, manager.Active())
+	})
+
+	t.Run("Rejects selections of unknown profiles", func(t *testing.T) {
+		manager := NewManager(newLogger, filepath.Join(t.TempDir(), "launch_profiles.json"))
+
+		assert.ErrorIs(t, manager.SelectForUser(1, "missing"), ErrProfileNotFound)
+		assert.ErrorIs(t, manager.SelectForAccount("rented", "missing"), ErrProfileNotFound)
+	})
+
+	t.Run("Deleting a profile clears its selections", func(t *testing.T) {
+		manager := NewManager(newLogger, filepath.Join(t.TempDir(), "launch_profiles.json"))
+		assert.NoError(t, manager.Save(pbe))
+		assert.NoError(t, manager.SelectForUser(1, "pbe"))
+
+		assert.NoError(t, manager.Delete("pbe"))
+
+		assert.Equal(t, Default, manager.Resolve(1, ""))
+		assert.Error(t, manager.Delete(DefaultID))
+	})
+
+	t.Run("Persists profiles and selections", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "launch_profiles.json")
+		manager := NewManager(newLogger, path)
+		assert.NoError(t, manager.Save(pbe))
+		assert.NoError(t, manager.SelectForUser(3, "pbe"))
+
+		reloaded := NewManager(newLogger, path)
+
+		assert.Len(t, reloaded.List(), 2)
+		assert.Equal(t, DefaultID, reloaded.List()[0].ID)
+		assert.Equal(t, pbe, reloaded.Resolve(3, ""))
+	})
+}
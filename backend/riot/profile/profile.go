@@ -0,0 +1,98 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hex-boost/hex-nexus-app/backend/riot/region"
+)
+
+const (
+	PatchlineLive = "live"
+	PatchlinePBE  = "pbe"
+
+	DefaultID = "default"
+
+	// authLanguage is the language of the authentication requests when the profile has no locale
+	authLanguage = "pt_BR"
+)
+
+var localePattern = regexp.MustCompile(`^[a-z]{2}_[A-Z]{2}$`)
+
+// Profile controls how the Riot client is launched and authenticated
+type Profile struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Locale    string   `json:"locale"`
+	Patchline string   `json:"patchline"`
+	Region    string   `json:"region,omitempty"`
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+}
+
+// Default launches the live client without overriding the locale the client is installed with
+var Default = Profile{
+	ID:        DefaultID,
+	Name:      "Default",
+	Patchline: PatchlineLive,
+}
+
+func (p Profile) Validate() error {
+	if strings.TrimSpace(p.ID) == "" {
+		return errors.New("profile id is required")
+	}
+	if p.Locale != "" && !localePattern.MatchString(p.Locale) {
+		return fmt.Errorf("invalid locale %q, expected a value like pt_BR", p.Locale)
+	}
+	if p.Patchline != PatchlineLive && p.Patchline != PatchlinePBE {
+		return fmt.Errorf("invalid patchline %q, expected %s or %s", p.Patchline, PatchlineLive, PatchlinePBE)
+	}
+	for _, arg := range p.ExtraArgs {
+		if !strings.HasPrefix(arg, "--") {
+			return fmt.Errorf("invalid client argument %q, arguments must start with --", arg)
+		}
+		if strings.HasPrefix(arg, "--launch-product") || strings.HasPrefix(arg, "--launch-patchline") {
+			return fmt.Errorf("client argument %q is controlled by the profile", arg)
+		}
+	}
+	return nil
+}
+
+// LaunchArgs returns the Riot client arguments for the profile
+func (p Profile) LaunchArgs() []string {
+	args := []string{
+		"--launch-product=league_of_legends",
+		"--launch-patchline=" + p.Patchline,
+	}
+	if p.Locale != "" {
+		args = append(args, "--locale="+p.Locale)
+	}
+	if p.Region != "" {
+		args = append(args, "--region="+regionCode(p.Region))
+	}
+	return append(args, p.ExtraArgs...)
+}
+
+// Language returns the language sent with the authentication requests
+func (p Profile) Language() string {
+	if p.Locale == "" {
+		return authLanguage
+	}
+	return p.Locale
+}
+
+// regionCode accepts a platform id or a region code, the client expects the code e.g. EUW instead of EUW1
+func regionCode(id string) string {
+	if platform, err := region.Resolve(id); err == nil {
+		return platform.Code
+	}
+	return strings.ToUpper(strings.TrimSpace(id))
+}
+
+// ProductSettingsPath returns the product settings file the Riot client writes for the profile patchline
+func (p Profile) ProductSettingsPath(programData string) string {
+	product := "league_of_legends." + p.Patchline
+	return filepath.Join(programData, "Riot Games", "Metadata", product, product+".product_settings.yaml")
+}
@@ -0,0 +1,88 @@
+package region
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var ErrUnknownRegion = errors.New("unknown region")
+
+// Region describes a League platform. KR, PBE and TW2 are not supported yet.
+// The hosts can be found in C:\Riot Games\League of Legends\system.yaml, in the player_platform_edge_url,
+// league_edge_url and discoverous_service_location fields
+type Region struct {
+	// PlatformID is the CPID sent by the Riot client userinfo, e.g. BR1
+	PlatformID string `json:"platformId"`
+	// Code is the short name shown to users and passed to the client --region argument, e.g. BR
+	Code  string `json:"code"`
+	Label string `json:"label"`
+	// Shard is the AWS shard hosting the platform, e.g. usw2
+	Shard   string   `json:"shard"`
+	Aliases []string `json:"aliases,omitempty"`
+	// RSOURL is the player platform edge used by the RSO services
+	RSOURL string `json:"rsoUrl"`
+	// LeagueEdgeURL is the league edge used by the leaver buster and other ledge services
+	LeagueEdgeURL              string `json:"leagueEdgeUrl"`
+	DiscoverousServiceLocation string `json:"discoverousServiceLocation,omitempty"`
+}
+
+// builtin is the table shipped with the app, regions.json can override or extend it
+var builtin = []Region{
+	{PlatformID: "BR1", Code: "BR", Label: "Brazil", Shard: "usw2", RSOURL: "https://usw2-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://br-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-usw2-prod.br1"},
+	{PlatformID: "EUN1", Code: "EUNE", Label: "Europe Nordic & East", Shard: "euc1", Aliases: []string{"EUN"}, RSOURL: "https://euc1-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://eune-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-euc1-prod.eun1"},
+	{PlatformID: "EUW1", Code: "EUW", Label: "Europe West", Shard: "euc1", RSOURL: "https://euc1-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://euw-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-euc1-prod.euw1"},
+	{PlatformID: "JP1", Code: "JP", Label: "Japan", Shard: "apne1", RSOURL: "https://apne1-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://jp-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-apne1-prod.jp1"},
+	{PlatformID: "LA1", Code: "LAN", Label: "Latin America North", Shard: "usw2", RSOURL: "https://usw2-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://lan-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-usw2-prod.la1"},
+	{PlatformID: "LA2", Code: "LAS", Label: "Latin America South", Shard: "usw2", RSOURL: "https://usw2-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://las-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-usw2-prod.la2"},
+	{PlatformID: "ME1", Code: "MENA", Label: "Middle East", Shard: "euc1", Aliases: []string{"ME"}, RSOURL: "https://euc1-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://me1-red.lol.sgp.pvp.net"},
+	{PlatformID: "NA1", Code: "NA", Label: "North America", Shard: "usw2", RSOURL: "https://usw2-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://na-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-usw2-prod.na1"},
+	{PlatformID: "OC1", Code: "OCE", Label: "Oceania", Shard: "apse1", Aliases: []string{"OC"}, RSOURL: "https://apse1-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://oce-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-apse1-prod.oc1"},
+	{PlatformID: "PH2", Code: "PH", Label: "Philippines", Shard: "apse1", RSOURL: "https://apse1-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://ph2-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-euc1-prod.ph2"},
+	{PlatformID: "RU", Code: "RU", Label: "Russia", Shard: "euc1", Aliases: []string{"RU1"}, RSOURL: "https://euc1-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://ru-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-euc1-prod.ru"},
+	{PlatformID: "SG2", Code: "SG", Label: "Singapore", Shard: "apse1", RSOURL: "https://apse1-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://sg2-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-euc1-prod.sg2"},
+	{PlatformID: "TH2", Code: "TH", Label: "Thailand", Shard: "apse1", RSOURL: "https://apse1-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://th2-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-euc1-prod.th2"},
+	{PlatformID: "TR1", Code: "TR", Label: "Turkey", Shard: "euc1", RSOURL: "https://euc1-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://tr-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-euc1-prod.tr1"},
+	{PlatformID: "VN2", Code: "VN", Label: "Vietnam", Shard: "apse1", RSOURL: "https://apse1-red.pp.sgp.pvp.net", LeagueEdgeURL: "https://vn2-red.lol.sgp.pvp.net", DiscoverousServiceLocation: "lolriot.aws-euc1-prod.vn2"},
+}
+
+func (r Region) Validate() error {
+	if strings.TrimSpace(r.PlatformID) == "" {
+		return errors.New("platform id is required")
+	}
+	if strings.TrimSpace(r.Code) == "" {
+		return fmt.Errorf("region %s has no code", r.PlatformID)
+	}
+	for name, host := range map[string]string{"rsoUrl": r.RSOURL, "leagueEdgeUrl": r.LeagueEdgeURL} {
+		if host == "" {
+			continue
+		}
+		parsed, err := url.Parse(host)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return fmt.Errorf("region %s has an invalid %s %q", r.PlatformID, name, host)
+		}
+	}
+	return nil
+}
+
+// LeaverBusterURL returns the restriction info endpoint of the platform
+func (r Region) LeaverBusterURL() (string, error) {
+	if r.LeagueEdgeURL == "" {
+		return "", fmt.Errorf("region %s has no league edge url", r.PlatformID)
+	}
+	return strings.TrimRight(r.LeagueEdgeURL, "/") + "/leaverbuster-ledge/restrictionInfo", nil
+}
+
+// names returns every identifier the region can be resolved from, normalized
+func (r Region) names() []string {
+	names := []string{normalize(r.PlatformID), normalize(r.Code)}
+	for _, alias := range r.Aliases {
+		names = append(names, normalize(alias))
+	}
+	return names
+}
+
+func normalize(id string) string {
+	return strings.ToUpper(strings.TrimSpace(id))
+}
@@ -0,0 +1,149 @@
+package region
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		id              string
+		platformID      string
+		code            string
+		shard           string
+		leaverBusterURL string
+	}{
+		{id: "BR1", platformID: "BR1", code: "BR", shard: "usw2", leaverBusterURL: "https://br-red.lol.sgp.pvp.net/leaverbuster-ledge/restrictionInfo"},
+		{id: "br1", platformID: "BR1", code: "BR", shard: "usw2", leaverBusterURL: "https://br-red.lol.sgp.pvp.net/leaverbuster-ledge/restrictionInfo"},
+		{id: " br ", platformID: "BR1", code: "BR", shard: "usw2", leaverBusterURL: "https://br-red.lol.sgp.pvp.net/leaverbuster-ledge/restrictionInfo"},
+		{id: "EUNE", platformID: "EUN1", code: "EUNE", shard: "euc1", leaverBusterURL: "https://eune-red.lol.sgp.pvp.net/leaverbuster-ledge/restrictionInfo"},
+		{id: "eun1", platformID: "EUN1", code: "EUNE", shard: "euc1", leaverBusterURL: "https://eune-red.lol.sgp.pvp.net/leaverbuster-ledge/restrictionInfo"},
+		{id: "LAN", platformID: "LA1", code: "LAN", shard: "usw2", leaverBusterURL: "https://lan-red.lol.sgp.pvp.net/leaverbuster-ledge/restrictionInfo"},
+		{id: "LA2", platformID: "LA2", code: "LAS", shard: "usw2", leaverBusterURL: "https://las-red.lol.sgp.pvp.net/leaverbuster-ledge/restrictionInfo"},
+		{id: "oce", platformID: "OC1", code: "OCE", shard: "apse1", leaverBusterURL: "https://oce-red.lol.sgp.pvp.net/leaverbuster-ledge/restrictionInfo"},
+		{id: "RU", platformID: "RU", code: "RU", shard: "euc1", leaverBusterURL: "https://ru-red.lol.sgp.pvp.net/leaverbuster-ledge/restrictionInfo"},
+		{id: "ME1", platformID: "ME1", code: "MENA", shard: "euc1", leaverBusterURL: "https://me1-red.lol.sgp.pvp.net/leaverbuster-ledge/restrictionInfo"},
+		{id: "jp1", platformID: "JP1", code: "JP", shard: "apne1", leaverBusterURL: "https://jp-red.lol.sgp.pvp.net/leaverbuster-ledge/restrictionInfo"},
+		{id: "VN", platformID: "VN2", code: "VN", shard: "apse1", leaverBusterURL: "https://vn2-red.lol.sgp.pvp.net/leaverbuster-ledge/restrictionInfo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			region, err := Resolve(tt.id)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.platformID, region.PlatformID)
+			assert.Equal(t, tt.code, region.Code)
+			assert.Equal(t, tt.shard, region.Shard)
+			leaverBusterURL, err := region.LeaverBusterURL()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.leaverBusterURL, leaverBusterURL)
+		})
+	}
+
+	t.Run("Unknown region", func(t *testing.T) {
+		_, err := Resolve("KR")
+
+		assert.True(t, errors.Is(err, ErrUnknownRegion))
+		assert.Equal(t, "KR", PlatformID(" kr"))
+		assert.Equal(t, "kr", Label("kr"))
+	})
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{a: "BR1", b: "br1", expected: true},
+		{a: "BR1", b: "BR", expected: true},
+		{a: "EUN1", b: "eune", expected: true},
+		{a: "OC1", b: "OCE", expected: true},
+		{a: "NA1", b: "BR1", expected: false},
+		{a: "EUW1", b: "EUN1", expected: false},
+		{a: "KR", b: "kr", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"="+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Equal(tt.a, tt.b))
+		})
+	}
+}
+
+func TestBuiltinRegions(t *testing.T) {
+	for _, region := range builtin {
+		t.Run(region.PlatformID, func(t *testing.T) {
+			assert.NoError(t, region.Validate())
+			assert.NotEmpty(t, region.Label)
+			assert.NotEmpty(t, region.Shard)
+			assert.Contains(t, region.RSOURL, region.Shard)
+		})
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	writeOverrides := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "regions.json")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	t.Run("Adds new shards and replaces existing ones", func(t *testing.T) {
+		registry := NewRegistry(builtin)
+		path := writeOverrides(t, `[
+			{"platformId": "KR", "code": "KR", "label": "Korea", "shard": "apne2", "aliases": ["KR1"], "rsoUrl": "https://apne2-red.pp.sgp.pvp.net", "leagueEdgeUrl": "https://kr-red.lol.sgp.pvp.net"},
+			{"platformId": "br1", "code": "BR", "label": "Brasil", "shard": "usw2", "rsoUrl": "https://usw2-red.pp.sgp.pvp.net", "leagueEdgeUrl": "https://br2-red.lol.sgp.pvp.net"}
+		]`)
+
+		loaded, err := registry.LoadOverrides(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, loaded)
+		korea, err := registry.Resolve("kr1")
+		assert.NoError(t, err)
+		assert.Equal(t, "KR", korea.PlatformID)
+		brazil, err := registry.Resolve("BR")
+		assert.NoError(t, err)
+		assert.Equal(t, "Brasil", brazil.Label)
+		assert.Equal(t, "https://br2-red.lol.sgp.pvp.net", brazil.LeagueEdgeURL)
+		assert.Len(t, registry.List(), len(builtin)+1)
+	})
+
+	t.Run("Aliases don't shadow platform ids", func(t *testing.T) {
+		registry := NewRegistry(builtin)
+		path := writeOverrides(t, `[{"platformId": "NA2", "code": "NA2", "label": "North America 2", "aliases": ["NA1"]}]`)
+
+		_, err := registry.LoadOverrides(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "NA1", registry.PlatformID("na1"))
+	})
+
+	t.Run("Missing file is ignored", func(t *testing.T) {
+		registry := NewRegistry(builtin)
+
+		loaded, err := registry.LoadOverrides(filepath.Join(t.TempDir(), "regions.json"))
+
+		assert.NoError(t, err)
+		assert.Zero(t, loaded)
+	})
+
+	t.Run("Invalid entries reject the whole file", func(t *testing.T) {
+		registry := NewRegistry(builtin)
+		path := writeOverrides(t, `[
+			{"platformId": "KR", "code": "KR", "label": "Korea"},
+			{"platformId": "XX1", "code": "XX", "leagueEdgeUrl": "http://insecure.example"}
+		]`)
+
+		_, err := registry.LoadOverrides(path)
+
+		assert.Error(t, err)
+		_, err = registry.Resolve("KR")
+		assert.True(t, errors.Is(err, ErrUnknownRegion))
+	})
+}
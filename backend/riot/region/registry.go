@@ -0,0 +1,147 @@
+package region
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Registry resolves CPIDs, platform ids, codes and aliases to regions
+type Registry struct {
+	mutex   sync.RWMutex
+	regions map[string]Region
+	index   map[string]string
+}
+
+func NewRegistry(regions []Region) *Registry {
+	r := &Registry{
+		regions: make(map[string]Region, len(regions)),
+		index:   make(map[string]string),
+	}
+	for _, region := range regions {
+		r.add(region)
+	}
+	return r
+}
+
+// defaultRegistry is used by the package functions, Service loads the override file into it
+var defaultRegistry = NewRegistry(builtin)
+
+// add inserts or replaces a region, the caller must hold the write lock
+func (r *Registry) add(region Region) {
+	platformID := normalize(region.PlatformID)
+	region.PlatformID = platformID
+	if previous, ok := r.regions[platformID]; ok {
+		for _, name := range previous.names() {
+			if r.index[name] == platformID {
+				delete(r.index, name)
+			}
+		}
+	}
+	r.regions[platformID] = region
+	for _, name := range region.names() {
+		// Platform ids always win over codes and aliases of other regions
+		if owner, ok := r.index[name]; ok && owner == name && owner != platformID {
+			continue
+		}
+		r.index[name] = platformID
+	}
+}
+
+// Resolve returns the region for a CPID, platform id, code or alias, case-insensitive
+func (r *Registry) Resolve(id string) (Region, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	platformID, ok := r.index[normalize(id)]
+	if !ok {
+		return Region{}, fmt.Errorf("%w: %q", ErrUnknownRegion, id)
+	}
+	return r.regions[platformID], nil
+}
+
+// PlatformID returns the canonical platform id, unknown ids are returned trimmed and upper-cased
+func (r *Registry) PlatformID(id string) string {
+	if region, err := r.Resolve(id); err == nil {
+		return region.PlatformID
+	}
+	return normalize(id)
+}
+
+// Label returns the display label, unknown ids are returned as they are
+func (r *Registry) Label(id string) string {
+	if region, err := r.Resolve(id); err == nil {
+		return region.Label
+	}
+	return id
+}
+
+// Equal reports whether both ids point to the same platform, e.g. BR and br1
+func (r *Registry) Equal(a, b string) bool {
+	return r.PlatformID(a) == r.PlatformID(b)
+}
+
+// List returns every region sorted by code
+func (r *Registry) List() []Region {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	regions := make([]Region, 0, len(r.regions))
+	for _, region := range r.regions {
+		regions = append(regions, region)
+	}
+	sort.Slice(regions, func(i, j int) bool { return regions[i].Code < regions[j].Code })
+	return regions
+}
+
+// LoadOverrides merges the regions of an override file into the registry. Entries replace the region with
+// the same platform id or add a new shard, a missing file is not an error
+func (r *Registry) LoadOverrides(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read region overrides: %w", err)
+	}
+
+	var overrides []Region
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return 0, fmt.Errorf("failed to decode region overrides: %w", err)
+	}
+	for _, region := range overrides {
+		if err := region.Validate(); err != nil {
+			return 0, fmt.Errorf("invalid region override: %w", err)
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, region := range overrides {
+		r.add(region)
+	}
+	return len(overrides), nil
+}
+
+func Resolve(id string) (Region, error) {
+	return defaultRegistry.Resolve(id)
+}
+
+func PlatformID(id string) string {
+	return defaultRegistry.PlatformID(id)
+}
+
+func Label(id string) string {
+	return defaultRegistry.Label(id)
+}
+
+func Equal(a, b string) bool {
+	return defaultRegistry.Equal(a, b)
+}
+
+func List() []Region {
+	return defaultRegistry.List()
+}
@@ -0,0 +1,45 @@
+package region
+
+import (
+	"github.com/hex-boost/hex-nexus-app/backend/internal/config"
+	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// overridesFile adds or replaces regions from the app data directory
+const overridesFile = "regions.json"
+
+// Service exposes the regions to the frontend and loads regions.json into the package registry
+type Service struct {
+	logger *logger.Logger
+}
+
+func NewService(logger *logger.Logger) *Service {
+	path, err := config.DataPath(overridesFile)
+	if err != nil {
+		logger.Error("Failed to resolve region overrides path", zap.Error(err))
+		return &Service{logger: logger}
+	}
+	loaded, err := defaultRegistry.LoadOverrides(path)
+	if err != nil {
+		logger.Warn("Failed to load region overrides, using the built-in regions", zap.Error(err))
+	} else if loaded > 0 {
+		logger.Info("Loaded region overrides", zap.Int("count", loaded), zap.String("path", path))
+	}
+	return &Service{logger: logger}
+}
+
+// List returns every known region
+func (s *Service) List() []Region {
+	return List()
+}
+
+// Resolve returns the region for a CPID, platform id, code or alias
+func (s *Service) Resolve(id string) (Region, error) {
+	return Resolve(id)
+}
+
+// Label returns the display label of a server, unknown servers are returned as they are
+func (s *Service) Label(id string) string {
+	return Label(id)
+}
@@ -0,0 +1,144 @@
+package riot
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hex-boost/hex-nexus-app/backend/riot/auth"
+	"github.com/hex-boost/hex-nexus-app/backend/types"
+	"go.uber.org/zap"
+)
+
+// CurrentSummonerSource defines the LCU call used to read the summoner name change flag
+type CurrentSummonerSource interface {
+	GetCurrentSummoner() (*types.CurrentSummoner, error)
+}
+
+// ageRestrictionResponse is returned by the Riot client age restriction endpoint
+type ageRestrictionResponse struct {
+	Restricted              bool `json:"restricted"`
+	ParentalConsentRequired bool `json:"parentalConsentRequired"`
+}
+
+// SetCurrentSummonerSource lets CheckRequiredActions read the LCU once it's running
+func (s *Service) SetCurrentSummonerSource(summoners CurrentSummonerSource) {
+	s.summoners = summoners
+}
+
+// CheckRequiredActions inspects the logged in Riot client and LCU for a pending EULA, an age or parental gate
+// and a forced Riot ID change. The actions found are added to the restrictions of the account so the next
+// renter isn't stuck and returned as an auth.Error
+func (s *Service) CheckRequiredActions(username string) error {
+	signals, err := s.requiredActionSignals()
+	if err != nil {
+		return err
+	}
+	actions := auth.DetectRequiredActions(signals)
+	if len(actions) == 0 {
+		s.logger.Debug("No required actions found")
+		return nil
+	}
+	s.logger.Warn("Account is blocked by required actions", zap.String("username", username), zap.Any("actions", actions))
+
+	// The saved restrictions are replaced by the ones sent, they're read first so the other ones are kept
+	saved, err := s.savedRestrictions(username)
+	if err != nil {
+		s.logger.Error("Error reading the saved restrictions, the required actions aren't saved", zap.Error(err))
+		return auth.RequiredActionError(username, actions)
+	}
+	_, saveErr := s.accountClient.Save(types.PartialSummonerRented{
+		Username: username,
+		Ban: &types.Ban{
+			Restrictions: auth.RequiredActionRestrictions(saved, actions),
+		},
+	})
+	if saveErr != nil {
+		s.logger.Error("Error saving summoner with required actions", zap.Error(saveErr))
+	}
+	return auth.RequiredActionError(username, actions)
+}
+
+// savedRestrictions returns the restrictions the backend has for the rented account
+func (s *Service) savedRestrictions(username string) ([]types.Restriction, error) {
+	rented, err := s.accountClient.GetAllRented()
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range rented {
+		if account.Username == username {
+			return account.Ban.Restrictions, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Service) requiredActionSignals() (auth.RequiredActionSignals, error) {
+	var signals auth.RequiredActionSignals
+
+	var err error
+	if signals.EulaAcceptance, err = s.getEulaAcceptance(); err != nil {
+		if errors.Is(err, auth.ErrClientNotReady) {
+			return signals, err
+		}
+		s.logger.Warn("Failed to read EULA acceptance", zap.Error(err))
+	}
+	ageRestriction, err := s.getAgeRestriction()
+	if err != nil {
+		s.logger.Warn("Failed to read age restriction", zap.Error(err))
+	} else {
+		signals.AgeRestricted = ageRestriction.Restricted
+		signals.ParentalConsentRequired = ageRestriction.ParentalConsentRequired
+	}
+
+	// The LCU only runs once the Riot client actions are done, it's checked when it's already up
+	if s.summoners != nil {
+		summoner, err := s.summoners.GetCurrentSummoner()
+		if err != nil {
+			s.logger.Debug("LCU not available for required actions", zap.Error(err))
+		} else {
+			signals.NameChangeFlag = summoner.NameChangeFlag
+		}
+	}
+	return signals, nil
+}
+
+func (s *Service) getEulaAcceptance() (string, error) {
+	s.clientMutex.RLock()
+	defer s.clientMutex.RUnlock()
+	if s.client == nil {
+		return "", &auth.Error{Kind: auth.ErrClientNotReady}
+	}
+	var acceptance string
+	resp, err := s.client.R().SetResult(&acceptance).Get("/eula/v1/agreement/acceptance")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.IsError() {
+		return "", fmt.Errorf("failed to get EULA acceptance: %d - %s", resp.StatusCode(), resp.String())
+	}
+	return acceptance, nil
+}
+
+func (s *Service) getAgeRestriction() (*ageRestrictionResponse, error) {
+	s.clientMutex.RLock()
+	defer s.clientMutex.RUnlock()
+	if s.client == nil {
+		return nil, &auth.Error{Kind: auth.ErrClientNotReady}
+	}
+	var restriction ageRestrictionResponse
+	resp, err := s.client.R().SetResult(&restriction).Get("/age-restriction/v1/age-restriction/products/league_of_legends")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return &restriction, nil
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get age restriction: %d - %s", resp.StatusCode(), resp.String())
+	}
+	return &restriction, nil
+}
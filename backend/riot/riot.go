@@ -12,9 +12,12 @@ import (
 	"errors"
 	"fmt"
 	"github.com/hex-boost/hex-nexus-app/backend/internal/league/account"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/restriction"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -23,7 +26,9 @@ import (
 	"github.com/hex-boost/hex-nexus-app/backend/pkg/command"
 	"github.com/hex-boost/hex-nexus-app/backend/pkg/logger"
 	"github.com/hex-boost/hex-nexus-app/backend/pkg/sysquery"
+	"github.com/hex-boost/hex-nexus-app/backend/riot/auth"
 	"github.com/hex-boost/hex-nexus-app/backend/riot/captcha"
+	"github.com/hex-boost/hex-nexus-app/backend/riot/profile"
 	"github.com/hex-boost/hex-nexus-app/backend/types"
 	"go.uber.org/zap"
 )
@@ -34,6 +39,17 @@ func getFunctionName() string {
 	return runtime.FuncForPC(pc).Name()
 }
 
+// openCredential is the only way to read a password, it's claimed before any other package can
+var openCredential = mustClaimCredentialOpener()
+
+func mustClaimCredentialOpener() types.CredentialOpener {
+	opener, err := types.ClaimCredentialOpener()
+	if err != nil {
+		panic(err)
+	}
+	return opener
+}
+
 type Service struct {
 	client      *resty.Client
 	clientMutex sync.RWMutex // Add this mutex
@@ -41,6 +57,10 @@ type Service struct {
 	// Add a dedicated mutex for authentication operations
 	authMutex sync.RWMutex
 
+	// pendingMultifactor is the login waiting for its multifactor code
+	pendingMultifactor *types.MultifactorChallenge
+	multifactorMutex   sync.Mutex
+
 	logger        *logger.Logger
 	captcha       *captcha.Captcha
 	ctx           context.Context
@@ -48,6 +68,13 @@ type Service struct {
 	cmd           *command.Command
 	sysquery      *sysquery.SysQuery
 	accountClient *account.Client
+	profiles      LaunchProfiles
+	summoners     CurrentSummonerSource
+}
+
+// LaunchProfiles defines the source of the launch profile for the current user and rented account
+type LaunchProfiles interface {
+	Active() profile.Profile
 }
 
 func NewService(logger *logger.Logger, captcha *captcha.Captcha, accountClient *account.Client) *Service {
@@ -63,6 +90,18 @@ func NewService(logger *logger.Logger, captcha *captcha.Captcha, accountClient *
 	}
 }
 
+func (s *Service) SetLaunchProfiles(profiles LaunchProfiles) {
+	s.profiles = profiles
+}
+
+// LaunchProfile returns the profile applied to the launch, the authentication and the product settings
+func (s *Service) LaunchProfile() profile.Profile {
+	if s.profiles == nil {
+		return profile.Default
+	}
+	return s.profiles.Active()
+}
+
 func (s *Service) ResetRestyClient() {
 	s.clientMutex.Lock()
 	defer s.clientMutex.Unlock()
@@ -252,39 +291,59 @@ func (s *Service) getCredentials() (port string, authToken string, err error) {
 
 	return "", "", fmt.Errorf("unable to extract credentials from either lockfile or process (PID: %d)", riotClientPid)
 }
-func (s *Service) LoginWithCaptcha(ctx context.Context, username, password, captchaToken string) (string, error) {
+func (s *Service) LoginWithCaptcha(ctx context.Context, username string, password *types.Credential, captchaToken string) (string, error) {
 	s.clientMutex.RLock()
 	defer s.clientMutex.RUnlock()
+	if s.client == nil {
+		return "", &auth.Error{Kind: auth.ErrClientNotReady, Username: username}
+	}
+	s.setPendingMultifactor(nil)
 
 	s.logger.Sugar().Infof("Authenticating with captcha token of length %d", len(captchaToken))
 
-	authPayload := types.Authentication{
-		Campaign: nil,
-		Language: "pt_BR",
-		Remember: false,
-		RiotIdentity: types.RiotIdentity{
-			Captcha:  fmt.Sprintf("hcaptcha %s", captchaToken),
-			Password: password,
-			State:    nil,
-			Username: username,
-		},
-		Type: "auth",
+	// The password is only decrypted while the request body is built, the body is zeroed once sent
+	var body []byte
+	err := openCredential(password, func(secret []byte) error {
+		var err error
+		body, err = json.Marshal(types.Authentication{
+			Campaign: nil,
+			Language: s.LaunchProfile().Language(),
+			Remember: false,
+			RiotIdentity: types.RiotIdentity{
+				Captcha:  fmt.Sprintf("hcaptcha %s", captchaToken),
+				Password: string(secret),
+				State:    nil,
+				Username: username,
+			},
+			Type: "auth",
+		})
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to build authentication request: %w", err)
 	}
 
 	var loginResult types.RiotIdentityResponse
+	// The request is aborted with the context, a login that timed out never completes in the background
 	req := s.client.R().
-		SetBody(authPayload).
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(body).
 		SetResult(&loginResult)
 
 	s.logger.Sugar().Debugf("Preparing to send authentication request with captcha for username: %s", username)
 
 	// Create a channel to handle async response
 	done := make(chan error, 1)
+	var resp *resty.Response
 
 	// Execute request in goroutine to handle context cancellation
 	go func() {
 		var err error
-		resp, err := req.Put("/rso-authenticator/v1/authentication")
+		resp, err = req.Put("/rso-authenticator/v1/authentication")
+		for i := range body {
+			body[i] = 0
+		}
 		if err == nil {
 			s.logger.Sugar().Debugf("Authentication API response received: status %d, size %d bytes",
 				resp.StatusCode(), string(resp.Body()))
@@ -306,56 +365,171 @@ func (s *Service) LoginWithCaptcha(ctx context.Context, username, password, capt
 
 	s.logger.Sugar().Debugf("Processing authentication response type: %s", loginResult.Type)
 
+	if resp.StatusCode() == http.StatusTooManyRequests || loginResult.Error == auth.CodeRateLimited {
+		s.logger.Warn("Authentication rate limited", zap.Int("status", resp.StatusCode()))
+		return "", &auth.Error{
+			Kind:       auth.ErrRateLimited,
+			Username:   username,
+			HTTPStatus: resp.StatusCode(),
+			RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After")),
+		}
+	}
 	if loginResult.Type == "multifactor" {
 		s.logger.Info("multifactor required for authentication")
-		return "", errors.New("multifactor")
+		s.setPendingMultifactor(&types.MultifactorChallenge{
+			Username:  username,
+			Method:    loginResult.Multifactor.Method,
+			Email:     loginResult.Multifactor.Email,
+			StartedAt: time.Now(),
+		})
+		return "", &auth.Error{
+			Kind:              auth.ErrMultifactorRequired,
+			Username:          username,
+			HTTPStatus:        resp.StatusCode(),
+			MultifactorMethod: loginResult.Multifactor.Method,
+			MultifactorEmail:  loginResult.Multifactor.Email,
+		}
 	}
 	if loginResult.Type == "success" {
 		tokenPreview := fmt.Sprintf("%s...%s", loginResult.Success.LoginToken[:10], loginResult.Success.LoginToken[len(loginResult.Success.LoginToken)-10:])
 		s.logger.Sugar().Infof("Authentication with captcha successful, login token: %s", tokenPreview)
 
-		s.logger.Debug("Starting completeAuthentication with login token")
-		err := s.completeAuthentication(loginResult.Success.LoginToken)
-		s.logger.Sugar().Debugf("completeAuthentication finished: %v", err)
-		if err != nil {
-			s.logger.Sugar().Errorf("Failed to complete authentication with login token: %v", err)
-			return "", fmt.Errorf("complete authentication failed: %w", err)
-		}
-
-		s.logger.Debug("Starting getAuthorization")
-		authResult, err := s.getAuthorization()
-
-		if err != nil {
-			s.logger.Sugar().Errorf("Failed to get authorization after successful authentication: %v", err)
-			return "", fmt.Errorf("authorization failed: %w", err)
+		if err := s.finishAuthentication(loginResult.Success.LoginToken); err != nil {
+			return "", err
 		}
-
-		keys := make([]string, 0)
-		if authResult != nil {
-			for k := range authResult {
-				keys = append(keys, k)
-			}
-			s.logger.Sugar().Debugf("getAuthorization finished with error: %v, authResult keys: %v", err, keys)
-		} else {
-			s.logger.Sugar().Debugf("getAuthorization finished with error: %v, authResult is nil", err)
-		}
-
-		s.logger.Info("Full authentication flow completed successfully")
 		return "", nil
 	}
-	if loginResult.Type == "auth" && loginResult.Error == "auth_failure" {
+	if loginResult.Type == "auth" && loginResult.Error == auth.CodeInvalidCredentials {
 		s.logger.Sugar().Errorf("Authentication failed with auth_failure: %+v", loginResult)
-		return "", errors.New(loginResult.Error)
+		return "", &auth.Error{Kind: auth.ErrInvalidCredentials, Username: username, HTTPStatus: resp.StatusCode()}
 	}
-	if loginResult.Error == "captcha_not_allowed" {
+	if loginResult.Error == auth.CodeCaptchaRejected {
 		s.logger.Sugar().Errorf("Captcha not allowed: %+v", loginResult.Captcha)
-		return loginResult.Captcha.Hcaptcha.Data, errors.New(loginResult.Error)
+		return loginResult.Captcha.Hcaptcha.Data, &auth.Error{
+			Kind:        auth.ErrCaptchaRejected,
+			Username:    username,
+			HTTPStatus:  resp.StatusCode(),
+			CaptchaData: loginResult.Captcha.Hcaptcha.Data,
+		}
 	}
 
 	s.logger.Sugar().Errorf("Authentication with captcha failed with unknown error: %+v", loginResult)
 	return "", errors.New("authentication with captcha failed")
 }
 
+// finishAuthentication exchanges the login token for a session, the caller must hold clientMutex
+func (s *Service) finishAuthentication(loginToken string) error {
+	s.logger.Debug("Starting completeAuthentication with login token")
+	err := s.completeAuthentication(loginToken)
+	s.logger.Sugar().Debugf("completeAuthentication finished: %v", err)
+	if err != nil {
+		s.logger.Sugar().Errorf("Failed to complete authentication with login token: %v", err)
+		return fmt.Errorf("complete authentication failed: %w", err)
+	}
+
+	s.logger.Debug("Starting getAuthorization")
+	authResult, err := s.getAuthorization()
+
+	if err != nil {
+		s.logger.Sugar().Errorf("Failed to get authorization after successful authentication: %v", err)
+		return fmt.Errorf("authorization failed: %w", err)
+	}
+
+	keys := make([]string, 0)
+	if authResult != nil {
+		for k := range authResult {
+			keys = append(keys, k)
+		}
+		s.logger.Sugar().Debugf("getAuthorization finished with error: %v, authResult keys: %v", err, keys)
+	} else {
+		s.logger.Sugar().Debugf("getAuthorization finished with error: %v, authResult is nil", err)
+	}
+
+	s.logger.Info("Full authentication flow completed successfully")
+	return nil
+}
+
+// GetPendingMultifactor returns the login waiting for its multifactor code, or nil when there is none
+func (s *Service) GetPendingMultifactor() *types.MultifactorChallenge {
+	s.multifactorMutex.Lock()
+	defer s.multifactorMutex.Unlock()
+	if s.pendingMultifactor == nil {
+		return nil
+	}
+	challenge := *s.pendingMultifactor
+	return &challenge
+}
+
+func (s *Service) setPendingMultifactor(challenge *types.MultifactorChallenge) {
+	s.multifactorMutex.Lock()
+	defer s.multifactorMutex.Unlock()
+	s.pendingMultifactor = challenge
+}
+
+// SubmitMultifactorCode completes the pending login with the code sent to the account email. A wrong code
+// keeps the login pending so the code can be submitted again
+func (s *Service) SubmitMultifactorCode(code string, trustDevice bool) error {
+	challenge := s.GetPendingMultifactor()
+	if challenge == nil {
+		return errors.New("no multifactor authentication pending")
+	}
+
+	s.clientMutex.RLock()
+	defer s.clientMutex.RUnlock()
+	if s.client == nil {
+		return &auth.Error{Kind: auth.ErrClientNotReady, Username: challenge.Username}
+	}
+
+	s.logger.Info("Submitting multifactor code", zap.String("method", challenge.Method), zap.Bool("trustDevice", trustDevice))
+	var result types.RiotIdentityResponse
+	resp, err := s.client.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(types.MultifactorInput{Otp: strings.TrimSpace(code), RememberDevice: trustDevice}).
+		SetResult(&result).
+		Put("/rso-authenticator/v1/authentication/multifactor")
+	if err != nil {
+		s.logger.Error("Multifactor request failed", zap.Error(err))
+		return fmt.Errorf("multifactor request failed: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusTooManyRequests || result.Error == auth.CodeRateLimited {
+		return &auth.Error{
+			Kind:       auth.ErrRateLimited,
+			Username:   challenge.Username,
+			HTTPStatus: resp.StatusCode(),
+			RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After")),
+		}
+	}
+	if result.Type == "multifactor" {
+		s.logger.Warn("Multifactor code rejected", zap.String("error", result.Error))
+		return &auth.Error{
+			Kind:              auth.ErrMultifactorInvalid,
+			Username:          challenge.Username,
+			HTTPStatus:        resp.StatusCode(),
+			MultifactorMethod: challenge.Method,
+			MultifactorEmail:  challenge.Email,
+		}
+	}
+	if result.Type != "success" {
+		s.logger.Sugar().Errorf("Multifactor authentication failed: status %d, type %s, error %s",
+			resp.StatusCode(), result.Type, result.Error)
+		s.setPendingMultifactor(nil)
+		return fmt.Errorf("multifactor authentication failed: %s", result.Error)
+	}
+
+	s.setPendingMultifactor(nil)
+	return s.finishAuthentication(result.Success.LoginToken)
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
 func (s *Service) completeAuthentication(loginToken string) error {
 	s.authMutex.Lock()
 	defer s.authMutex.Unlock()
@@ -482,6 +656,7 @@ func (s *Service) getAuthorization() (map[string]interface{}, error) {
 }
 
 func (s *Service) Logout() error {
+	s.setPendingMultifactor(nil)
 	res, err := s.client.R().Delete("/rso-authenticator/v1/authentication")
 	if err != nil {
 		s.logger.Sugar().Errorf("Error logging out: %v", err)
@@ -518,9 +693,11 @@ func (s *Service) Launch() error {
 	if clientInstalls.RcDefault == "" {
 		return errors.New("could not find Riot client path in installs file")
 	}
-	args := []string{"--launch-product=league_of_legends", "--launch-patchline=live"}
+	launchProfile := s.LaunchProfile()
+	args := launchProfile.LaunchArgs()
 	s.logger.Info("Launching Riot client",
 		zap.String("path", clientInstalls.RcDefault),
+		zap.String("profile", launchProfile.ID),
 		zap.Strings("args", args))
 
 	_, err = s.cmd.Start(clientInstalls.RcDefault, args...)
@@ -577,7 +754,7 @@ func (s *Service) GetAuthenticationState() (*types.RiotIdentityResponse, error)
 
 	if s.client == nil {
 		s.clientMutex.RUnlock()
-		return nil, errors.New("client is not initialized")
+		return nil, &auth.Error{Kind: auth.ErrClientNotReady}
 	}
 
 	var getCurrentAuthResult types.RiotIdentityResponse
@@ -662,7 +839,7 @@ func (s *Service) getCaptchaData() (string, error) {
 	}
 	var startAuthResult types.RiotIdentityResponse
 	startAuthRes, err := s.client.R().
-		SetBody(getRiotIdentityStartPayload()).
+		SetBody(getRiotIdentityStartPayload(s.LaunchProfile().Language())).
 		SetResult(&startAuthResult).
 		Post("/rso-authenticator/v1/authentication/riot-identity/start")
 	if err != nil {
@@ -690,7 +867,7 @@ func (s *Service) CheckAccountBanned(username string) error {
 		err := s.InitializeClient()
 		if err != nil {
 			s.logger.Sugar().Errorf("Failed to initialize client: %v", err)
-			return err
+			return &auth.Error{Kind: auth.ErrClientNotReady, Username: username, Err: err}
 		}
 	}
 	ticker := time.NewTicker(500 * time.Millisecond)
@@ -711,18 +888,31 @@ func (s *Service) CheckAccountBanned(username string) error {
 				s.logger.Sugar().Warnf("Account has %d restrictions: %+v",
 					len(userInfo.Ban.Restrictions), userInfo.Ban.Restrictions)
 
-				for _, restriction := range userInfo.Ban.Restrictions {
-					if restriction.Type == "PERMANENT_BAN" && (restriction.Scope == "riot" || restriction.Scope == "lol" || restriction.Scope == "") {
-						_, saveErr := s.accountClient.Save(types.PartialSummonerRented{
-							Username: username,
-							Ban:      &userInfo.Ban,
-						})
-						if saveErr != nil {
-							s.logger.Sugar().Errorf("Error saving summoner with ban restriction: %v", saveErr)
-							return saveErr
+				now := time.Now()
+				for _, accountRestriction := range restriction.FromBan(userInfo.Ban) {
+					if !restriction.BlocksLeague(accountRestriction, now) {
+						continue
+					}
+					_, saveErr := s.accountClient.Save(types.PartialSummonerRented{
+						Username: username,
+						Ban:      &userInfo.Ban,
+					})
+					if saveErr != nil {
+						s.logger.Sugar().Errorf("Error saving summoner with ban restriction: %v", saveErr)
+						return saveErr
+					}
+					banErr := &auth.Error{
+						Kind:         auth.ErrPermanentBan,
+						Username:     username,
+						Restrictions: userInfo.Ban.Restrictions,
+					}
+					if accountRestriction.Kind == types.RestrictionTimedBan {
+						banErr.Kind = auth.ErrTimedBan
+						if accountRestriction.ExpiresAt != nil {
+							banErr.RetryAfter = accountRestriction.ExpiresAt.Sub(now)
 						}
-						return fmt.Errorf("permanent_banned")
 					}
+					return banErr
 				}
 				return nil
 			}
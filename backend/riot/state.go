@@ -7,6 +7,7 @@ import (
 	"go.uber.org/zap"
 	"time"
 
+	"github.com/hex-boost/hex-nexus-app/backend/riot/auth"
 	"github.com/hex-boost/hex-nexus-app/backend/types"
 )
 
@@ -72,7 +73,7 @@ func (s *Service) GetUserinfo() (*types.UserInfo, error) {
 	s.clientMutex.RLock()
 	defer s.clientMutex.RUnlock()
 	if s.client == nil {
-		return nil, errors.New("client is not initialized")
+		return nil, &auth.Error{Kind: auth.ErrClientNotReady}
 	}
 	var rawResponse types.RCUUserinfo
 	resp, err := s.client.R().SetResult(&rawResponse).Get("/rso-auth/v1/authorization/userinfo")
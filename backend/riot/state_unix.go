@@ -3,6 +3,6 @@
 
 package riot
 
-func findWindow(className, windowName string) uintptr {
+func findWindow(windowName string) uintptr {
 	return 0
 }
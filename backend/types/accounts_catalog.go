@@ -0,0 +1,92 @@
+package types
+
+import "encoding/json"
+
+// AccountsQuery holds the filters applied by the backend when listing available accounts
+type AccountsQuery struct {
+	Server   string `json:"server,omitempty"`
+	Tier     string `json:"tier,omitempty"`
+	Search   string `json:"search,omitempty"`
+	Sort     string `json:"sort,omitempty"`
+	PageSize int    `json:"pageSize,omitempty"`
+}
+
+// AccountsFilter holds the filters applied locally to the cached accounts
+type AccountsFilter struct {
+	Server        string   `json:"server,omitempty"`
+	Tiers         []string `json:"tiers,omitempty"`
+	ChampionIDs   []int    `json:"championIds,omitempty"`
+	SkinIDs       []int    `json:"skinIds,omitempty"`
+	ExcludeBanned bool     `json:"excludeBanned,omitempty"`
+}
+
+// Pagination is the pagination metadata returned by the backend
+type Pagination struct {
+	Page      int `json:"page"`
+	PageSize  int `json:"pageSize"`
+	PageCount int `json:"pageCount"`
+	Total     int `json:"total"`
+}
+
+// AccountsPageResponse is a single page of available accounts
+type AccountsPageResponse struct {
+	Data []SummonerBase `json:"data"`
+	Meta struct {
+		Pagination Pagination `json:"pagination"`
+	} `json:"meta"`
+}
+
+// AccountsCatalogPage is what the catalog returns to the frontend
+type AccountsCatalogPage struct {
+	Accounts []SummonerBase `json:"accounts"`
+	Loaded   int            `json:"loaded"`
+	Total    int            `json:"total"`
+	HasMore  bool           `json:"hasMore"`
+}
+
+// OwnedChampionIDs returns the champions owned by the account as a set
+func (s *SummonerBase) OwnedChampionIDs() map[int]struct{} {
+	return idSet(s.LCUchampions)
+}
+
+// OwnedSkinIDs returns the skins owned by the account as a set
+func (s *SummonerBase) OwnedSkinIDs() map[int]struct{} {
+	return idSet(s.LCUskins)
+}
+
+// HasLeaverBusterPenalty reports whether the account still has leaver buster games or an active penalty
+func (s *SummonerBase) HasLeaverBusterPenalty() bool {
+	if s.LeaverBuster == nil {
+		return false
+	}
+	data, err := json.Marshal(s.LeaverBuster)
+	if err != nil {
+		return false
+	}
+	var leaverBuster LeaverBusterResponse
+	if err := json.Unmarshal(data, &leaverBuster); err != nil {
+		return false
+	}
+	entry := leaverBuster.LeaverBusterEntryDto
+	return entry.PunishedGamesRemaining > 0 ||
+		entry.LeaverPenalty.HasActivePenalty ||
+		leaverBuster.RankedRestrictionEntryDto.RestrictedGamesRemaining > 0
+}
+
+// idSet converts the owned ids, which are decoded as []interface{} from the backend
+func idSet(owned interface{}) map[int]struct{} {
+	set := make(map[int]struct{})
+	switch list := owned.(type) {
+	case []int:
+		for _, id := range list {
+			set[id] = struct{}{}
+		}
+	case []interface{}:
+		for _, id := range list {
+			if value, ok := id.(float64); ok {
+				set[int(value)] = struct{}{}
+			}
+		}
+	}
+	return set
+}
@@ -0,0 +1,73 @@
+package types
+
+// LolChampSelectSession is the part of /lol-champ-select/v1/session used to follow the local pick
+type LolChampSelectSession struct {
+	LocalPlayerCellId int                        `json:"localPlayerCellId"`
+	Actions           [][]LolChampSelectAction   `json:"actions"`
+	MyTeam            []LolChampSelectTeamMember `json:"myTeam"`
+}
+
+// LolChampSelectAction is a pick or ban turn of a player
+type LolChampSelectAction struct {
+	ID           int    `json:"id"`
+	ActorCellId  int    `json:"actorCellId"`
+	ChampionId   int32  `json:"championId"`
+	Completed    bool   `json:"completed"`
+	IsInProgress bool   `json:"isInProgress"`
+	Type         string `json:"type"`
+}
+
+// LolChampSelectTeamMember is a player of the local team
+type LolChampSelectTeamMember struct {
+	CellId             int   `json:"cellId"`
+	ChampionId         int32 `json:"championId"`
+	ChampionPickIntent int32 `json:"championPickIntent"`
+}
+
+// LocalPick returns the champion the local player has hovered or locked, 0 when there is none
+func (s LolChampSelectSession) LocalPick() (championID int32, locked bool) {
+	var picked int32
+	hasPickTurn := false
+	for _, turn := range s.Actions {
+		for _, action := range turn {
+			if action.Type != "pick" || action.ActorCellId != s.LocalPlayerCellId {
+				continue
+			}
+			hasPickTurn = true
+			if action.ChampionId == 0 {
+				continue
+			}
+			if action.Completed {
+				picked = action.ChampionId
+			} else if action.IsInProgress {
+				championID = action.ChampionId
+			}
+		}
+	}
+	member, ok := s.localMember()
+	// The team entry follows trades, bench swaps and the champions given without a pick turn like in ARAM.
+	// During the pick turn it holds the hovered champion, so it's only trusted once the pick is completed
+	if ok && member.ChampionId != 0 && (picked != 0 || !hasPickTurn) {
+		return member.ChampionId, true
+	}
+	if picked != 0 {
+		return picked, true
+	}
+	if championID != 0 {
+		return championID, false
+	}
+	// Before their turn the player can only declare the champion they intend to pick
+	if ok {
+		return member.ChampionPickIntent, false
+	}
+	return 0, false
+}
+
+func (s LolChampSelectSession) localMember() (LolChampSelectTeamMember, bool) {
+	for _, member := range s.MyTeam {
+		if member.CellId == s.LocalPlayerCellId {
+			return member, true
+		}
+	}
+	return LolChampSelectTeamMember{}, false
+}
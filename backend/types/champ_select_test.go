@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLolChampSelectSession_LocalPick(t *testing.T) {
+	pick := func(championID int32, completed, inProgress bool) [][]LolChampSelectAction {
+		return [][]LolChampSelectAction{{
+			{ActorCellId: 3, Type: "ban", ChampionId: 157, Completed: true},
+			{ActorCellId: 3, Type: "pick", ChampionId: championID, Completed: completed, IsInProgress: inProgress},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		session  LolChampSelectSession
+		champion int32
+		locked   bool
+	}{
+		{
+			name:    "Nothing picked",
+			session: LolChampSelectSession{LocalPlayerCellId: 3, Actions: pick(0, false, false), MyTeam: []LolChampSelectTeamMember{{CellId: 3}}},
+		},
+		{
+			name:     "Pick intent before the turn",
+			session:  LolChampSelectSession{LocalPlayerCellId: 3, Actions: pick(0, false, false), MyTeam: []LolChampSelectTeamMember{{CellId: 3, ChampionPickIntent: 99}}},
+			champion: 99,
+		},
+		{
+			name:     "Hovered during the turn",
+			session:  LolChampSelectSession{LocalPlayerCellId: 3, Actions: pick(99, false, true), MyTeam: []LolChampSelectTeamMember{{CellId: 3, ChampionId: 99}}},
+			champion: 99,
+		},
+		{
+			name:     "Locked pick",
+			session:  LolChampSelectSession{LocalPlayerCellId: 3, Actions: pick(99, true, false), MyTeam: []LolChampSelectTeamMember{{CellId: 3, ChampionId: 99}}},
+			champion: 99,
+			locked:   true,
+		},
+		{
+			name:     "Traded pick follows the team",
+			session:  LolChampSelectSession{LocalPlayerCellId: 3, Actions: pick(99, true, false), MyTeam: []LolChampSelectTeamMember{{CellId: 3, ChampionId: 266}}},
+			champion: 266,
+			locked:   true,
+		},
+		{
+			name:     "ARAM champion without a pick turn",
+			session:  LolChampSelectSession{LocalPlayerCellId: 3, MyTeam: []LolChampSelectTeamMember{{CellId: 1, ChampionId: 99}, {CellId: 3, ChampionId: 266}}},
+			champion: 266,
+			locked:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			champion, locked := test.session.LocalPick()
+			assert.Equal(t, test.champion, champion)
+			assert.Equal(t, test.locked, locked)
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package types
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	// ErrRedactedCredential is returned when a serialized credential is decoded, the secret itself was never written
+	ErrRedactedCredential = errors.New("credential is redacted")
+	// ErrOpenerClaimed is returned when the credential opener was already handed out
+	ErrOpenerClaimed = errors.New("credential opener already claimed")
+)
+
+var (
+	credentialKey     []byte
+	credentialKeyErr  error
+	credentialKeyOnce sync.Once
+	openerClaimed     atomic.Bool
+)
+
+// CredentialOpener decrypts the secret of a credential for the duration of fn, the plaintext is zeroed once fn
+// returns
+type CredentialOpener func(c *Credential, fn func(secret []byte) error) error
+
+// ClaimCredentialOpener hands out the only way to decrypt a credential. The riot package claims it when it's
+// initialized, every later call fails so no other package can read a secret
+func ClaimCredentialOpener() (CredentialOpener, error) {
+	if !openerClaimed.CompareAndSwap(false, true) {
+		return nil, ErrOpenerClaimed
+	}
+	return (*Credential).open, nil
+}
+
+// credentialCipher returns the AES-GCM cipher used for every credential, its key is generated per process
+// and never leaves memory
+func credentialCipher() (cipher.AEAD, error) {
+	credentialKeyOnce.Do(func() {
+		credentialKey = make([]byte, 32)
+		_, credentialKeyErr = rand.Read(credentialKey)
+	})
+	if credentialKeyErr != nil {
+		return nil, credentialKeyErr
+	}
+	block, err := aes.NewCipher(credentialKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Credential holds an encrypted secret such as an account password. It never prints or serializes
+// its value and is only opened by the riot login, through the CredentialOpener it claims. The key is random per process, so this keeps
+// the plaintext out of logs, JSON and long lived memory but doesn't protect it from the process itself
+type Credential struct {
+	mutex      sync.Mutex
+	nonce      []byte
+	ciphertext []byte
+}
+
+// NewCredential encrypts the secret and zeroes the given buffer
+func NewCredential(secret []byte) (*Credential, error) {
+	defer zero(secret)
+	c := &Credential{}
+	if err := c.seal(secret); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Credential) seal(secret []byte) error {
+	aead, err := credentialCipher()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.nonce = nonce
+	c.ciphertext = aead.Seal(nil, nonce, secret, nil)
+	return nil
+}
+
+func (c *Credential) open(fn func(secret []byte) error) error {
+	if c == nil {
+		return errors.New("credential is empty")
+	}
+	aead, err := credentialCipher()
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	if c.ciphertext == nil {
+		c.mutex.Unlock()
+		return errors.New("credential was destroyed")
+	}
+	secret, err := aead.Open(nil, c.nonce, c.ciphertext, nil)
+	c.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	defer zero(secret)
+
+	return fn(secret)
+}
+
+// Clone returns an independent copy of the credential, used when a secret must outlive a consumer that
+// destroys it. The sealed secret is copied as is, it's never decrypted
+func (c *Credential) Clone() (*Credential, error) {
+	if c == nil {
+		return nil, errors.New("credential is empty")
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.ciphertext == nil {
+		return nil, errors.New("credential was destroyed")
+	}
+	return &Credential{
+		nonce:      bytes.Clone(c.nonce),
+		ciphertext: bytes.Clone(c.ciphertext),
+	}, nil
+}
+
+// Destroyed reports whether the secret was discarded
+func (c *Credential) Destroyed() bool {
+	if c == nil {
+		return true
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.ciphertext == nil
+}
+
+// Destroy discards the encrypted secret, the credential can't be opened afterwards
+func (c *Credential) Destroy() {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	zero(c.ciphertext)
+	c.ciphertext = nil
+	c.nonce = nil
+}
+
+func (c *Credential) String() string {
+	return redacted
+}
+
+func (c *Credential) GoString() string {
+	return redacted
+}
+
+func (c *Credential) MarshalJSON() ([]byte, error) {
+	return json.Marshal(redacted)
+}
+
+// UnmarshalJSON encrypts the secret sent by the frontend as soon as it is decoded, a redacted credential
+// is rejected so a serialized one can't replace the real secret
+func (c *Credential) UnmarshalJSON(data []byte) error {
+	buffer, err := decodeJSONString(data)
+	if err != nil {
+		return err
+	}
+	defer zero(buffer)
+	if bytes.Equal(buffer, []byte(redacted)) {
+		return ErrRedactedCredential
+	}
+	return c.seal(buffer)
+}
+
+func (c *Credential) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	encoder.AddString("value", redacted)
+	return nil
+}
+
+func zero(buffer []byte) {
+	for i := range buffer {
+		buffer[i] = 0
+	}
+}
+
+// decodeJSONString decodes a JSON string into a buffer that can be zeroed, decoding into a string would
+// leave an immutable copy of the secret in memory
+func decodeJSONString(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return nil, errors.New("credential must be a JSON string")
+	}
+	data = data[1 : len(data)-1]
+	// A decoded string is never longer than its JSON, appending never copies the secret to a new array
+	buffer := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\\' {
+			buffer = append(buffer, data[i])
+			continue
+		}
+		i++
+		if i == len(data) {
+			zero(buffer)
+			return nil, errors.New("credential has an invalid escape")
+		}
+		switch data[i] {
+		case '"', '\\', '/':
+			buffer = append(buffer, data[i])
+		case 'b':
+			buffer = append(buffer, '\b')
+		case 'f':
+			buffer = append(buffer, '\f')
+		case 'n':
+			buffer = append(buffer, '\n')
+		case 'r':
+			buffer = append(buffer, '\r')
+		case 't':
+			buffer = append(buffer, '\t')
+		case 'u':
+			r, ok := decodeRune(data[i+1:])
+			if !ok {
+				zero(buffer)
+				return nil, errors.New("credential has an invalid escape")
+			}
+			i += 4
+			// Characters outside the BMP are written as a surrogate pair
+			if utf16.IsSurrogate(r) && i+6 < len(data) && data[i+1] == '\\' && data[i+2] == 'u' {
+				if low, ok := decodeRune(data[i+3:]); ok {
+					if decoded := utf16.DecodeRune(r, low); decoded != utf8.RuneError {
+						r = decoded
+						i += 6
+					}
+				}
+			}
+			buffer = utf8.AppendRune(buffer, r)
+		default:
+			zero(buffer)
+			return nil, errors.New("credential has an invalid escape")
+		}
+	}
+	return buffer, nil
+}
+
+// decodeRune parses the 4 hex digits of a \\u escape
+func decodeRune(data []byte) (rune, bool) {
+	if len(data) < 4 {
+		return 0, false
+	}
+	value, err := strconv.ParseUint(string(data[:4]), 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	return rune(value), true
+}
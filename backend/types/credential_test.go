@@ -0,0 +1,141 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func openCredential(t *testing.T, credential *Credential) string {
+	t.Helper()
+	var value string
+	require.NoError(t, credential.open(func(secret []byte) error {
+		value = string(secret)
+		return nil
+	}))
+	return value
+}
+
+func TestCredential(t *testing.T) {
+	t.Run("Secret is opened and the buffers are zeroed", func(t *testing.T) {
+		buffer := []byte("password")
+		credential, err := NewCredential(buffer)
+		require.NoError(t, err)
+		assert.Equal(t, make([]byte, len(buffer)), buffer, "the given buffer is zeroed")
+
+		var opened []byte
+		require.NoError(t, credential.open(func(secret []byte) error {
+			assert.Equal(t, "password", string(secret))
+			opened = secret
+			return nil
+		}))
+		assert.Equal(t, make([]byte, len(opened)), opened, "the plaintext is zeroed once fn returns")
+	})
+
+	t.Run("Opener is handed out once", func(t *testing.T) {
+		claimed := openerClaimed.Load()
+		defer openerClaimed.Store(claimed)
+		openerClaimed.Store(false)
+
+		opener, err := ClaimCredentialOpener()
+		require.NoError(t, err)
+		credential, err := NewCredential([]byte("password"))
+		require.NoError(t, err)
+		require.NoError(t, opener(credential, func(secret []byte) error {
+			assert.Equal(t, "password", string(secret))
+			return nil
+		}))
+
+		_, err = ClaimCredentialOpener()
+		assert.ErrorIs(t, err, ErrOpenerClaimed)
+	})
+
+	t.Run("Open returns the error of fn", func(t *testing.T) {
+		credential, err := NewCredential([]byte("password"))
+		require.NoError(t, err)
+		assert.EqualError(t, credential.open(func([]byte) error { return fmt.Errorf("login failed") }), "login failed")
+	})
+
+	t.Run("Destroyed and empty credentials can't be opened", func(t *testing.T) {
+		credential, err := NewCredential([]byte("password"))
+		require.NoError(t, err)
+		clone, err := credential.Clone()
+		require.NoError(t, err)
+
+		credential.Destroy()
+		assert.True(t, credential.Destroyed())
+		assert.Error(t, credential.open(func([]byte) error { return nil }))
+		assert.False(t, clone.Destroyed())
+		assert.Equal(t, "password", openCredential(t, clone), "a clone outlives the original")
+		_, err = credential.Clone()
+		assert.Error(t, err, "a destroyed credential can't be cloned")
+
+		var empty *Credential
+		assert.Error(t, empty.open(func([]byte) error { return nil }))
+		empty.Destroy()
+	})
+
+	t.Run("Secret is never printed or serialized", func(t *testing.T) {
+		credential, err := NewCredential([]byte("hunter2"))
+		require.NoError(t, err)
+		summoner := PartialSummonerRented{Password: credential}
+
+		data, err := json.Marshal(summoner)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "hunter2")
+		assert.Contains(t, string(data), redacted)
+		assert.Equal(t, redacted, fmt.Sprintf("%v", credential))
+		assert.Equal(t, redacted, fmt.Sprintf("%#v", credential))
+
+		encoder := zapcore.NewMapObjectEncoder()
+		require.NoError(t, credential.MarshalLogObject(encoder))
+		assert.Equal(t, redacted, encoder.Fields["value"])
+	})
+
+	t.Run("Decoded secrets are sealed", func(t *testing.T) {
+		tests := []struct {
+			name string
+			json string
+			want string
+		}{
+			{"Plain", `"password"`, "password"},
+			{"Escapes", `"pa\"ss\\wo\/rd\n\t"`, "pa\"ss\\wo/rd\n\t"},
+			{"Unicode", `"s\u00e9nha\u4e2d"`, "sénha中"},
+			{"Surrogate pair", `"\ud83d\ude00!"`, "😀!"},
+			{"Raw UTF-8", `"sénha"`, "sénha"},
+			{"Empty", `""`, ""},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var credential Credential
+				require.NoError(t, json.Unmarshal([]byte(tt.json), &credential))
+				assert.Equal(t, tt.want, openCredential(t, &credential))
+			})
+		}
+	})
+
+	t.Run("Invalid secrets are rejected", func(t *testing.T) {
+		for _, data := range []string{`1`, `null`, `"\x"`, `"\u12"`, `"\uzzzz"`} {
+			var credential Credential
+			assert.Error(t, credential.UnmarshalJSON([]byte(data)), data)
+		}
+	})
+
+	t.Run("Redacted credential doesn't replace the secret", func(t *testing.T) {
+		credential, err := NewCredential([]byte("password"))
+		require.NoError(t, err)
+		data, err := json.Marshal(PartialSummonerRented{Username: "nexus1", Password: credential})
+		require.NoError(t, err)
+
+		var decoded PartialSummonerRented
+		assert.ErrorIs(t, json.Unmarshal(data, &decoded), ErrRedactedCredential)
+
+		var same Credential
+		assert.ErrorIs(t, same.UnmarshalJSON([]byte(`"[REDACTED]"`)), ErrRedactedCredential)
+		assert.Error(t, same.open(func([]byte) error { return nil }), "nothing was sealed")
+	})
+}
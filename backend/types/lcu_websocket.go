@@ -18,7 +18,8 @@ type LCUInventoryItem struct {
 	Wins           int           `json:"wins"`
 }
 type Wallet struct {
-	LolBlueEssence int `json:"lol_blue_essence"`
+	LolBlueEssence int  `json:"lol_blue_essence"`
+	RP             *int `json:"RP,omitempty"`
 }
 
 type LolInventoryV2 []LolInventoryItem
@@ -0,0 +1,73 @@
+package types
+
+import "time"
+
+// Leaver buster statuses, from the least to the most severe
+const (
+	LeaverBusterClean            = "clean"
+	LeaverBusterWarning          = "warning"
+	LeaverBusterRankedRestricted = "ranked_restricted"
+	LeaverBusterQueueDelay       = "queue_delay"
+	LeaverBusterLockedOut        = "locked_out"
+)
+
+// LeaverBusterPenalty is the typed state parsed from a LeaverBusterResponse
+type LeaverBusterPenalty struct {
+	Status string `json:"status"`
+	// Tier is the leaver level, it goes up with every punishment and decays with clean games
+	Tier           int  `json:"tier"`
+	LeaverScore    int  `json:"leaverScore"`
+	PunishmentStep int  `json:"punishmentStep"`
+	Tainted        bool `json:"tainted"`
+
+	WarningSentAt       *time.Time `json:"warningSentAt,omitempty"`
+	WarningAcknowledged bool       `json:"warningAcknowledged"`
+	AckNeeded           bool       `json:"ackNeeded"`
+
+	QueueDelayMinutes      int        `json:"queueDelayMinutes"`
+	PunishedGamesRemaining int        `json:"punishedGamesRemaining"`
+	QueueLockoutUntil      *time.Time `json:"queueLockoutUntil,omitempty"`
+
+	RankedRestrictedGamesRemaining int        `json:"rankedRestrictedGamesRemaining"`
+	RankedRestrictedUntil          *time.Time `json:"rankedRestrictedUntil,omitempty"`
+
+	TotalPunishedGamesPlayed int        `json:"totalPunishedGamesPlayed"`
+	LastPunishmentGameID     int64      `json:"lastPunishmentGameId,omitempty"`
+	LastPunishmentAt         *time.Time `json:"lastPunishmentAt,omitempty"`
+
+	NextQueue LeaverBusterForecast `json:"nextQueue"`
+}
+
+// LeaverBusterForecast predicts what happens when the account joins its next queue
+type LeaverBusterForecast struct {
+	CanQueue      bool       `json:"canQueue"`
+	AvailableAt   *time.Time `json:"availableAt,omitempty"`
+	DelayMinutes  int        `json:"delayMinutes"`
+	RankedAllowed bool       `json:"rankedAllowed"`
+	Message       string     `json:"message"`
+}
+
+// LeaverBusterTierChange is a leaver level change seen during a session
+type LeaverBusterTierChange struct {
+	From int       `json:"from"`
+	To   int       `json:"to"`
+	At   time.Time `json:"at"`
+}
+
+// LeaverBusterIncident is a punishment incurred during a session
+type LeaverBusterIncident struct {
+	GameID int64     `json:"gameId"`
+	At     time.Time `json:"at"`
+	Tier   int       `json:"tier"`
+}
+
+// LeaverBusterSession is the penalty progression of a rental session, used to attribute who caused it
+type LeaverBusterSession struct {
+	StartTier          int                      `json:"startTier"`
+	EndTier            int                      `json:"endTier"`
+	PunishedGamesAdded int                      `json:"punishedGamesAdded"`
+	RankedGamesAdded   int                      `json:"rankedGamesAdded"`
+	WarningsReceived   int                      `json:"warningsReceived"`
+	Incidents          []LeaverBusterIncident   `json:"incidents"`
+	TierHistory        []LeaverBusterTierChange `json:"tierHistory"`
+}
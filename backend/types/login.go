@@ -0,0 +1,49 @@
+package types
+
+// Login step statuses sent in LoginProgress and LoginStepResult
+const (
+	LoginStepStarted   = "started"
+	LoginStepRetrying  = "retrying"
+	LoginStepSucceeded = "succeeded"
+	LoginStepFailed    = "failed"
+	LoginStepSkipped   = "skipped"
+)
+
+// LoginRequest holds the credentials of the rented account to log into
+type LoginRequest struct {
+	Username string      `json:"username"`
+	Password *Credential `json:"password"`
+}
+
+// LoginProgress is emitted every time a login step starts, retries, succeeds or fails
+type LoginProgress struct {
+	Step           string `json:"step"`
+	Status         string `json:"status"`
+	Attempt        int    `json:"attempt"`
+	MaxAttempts    int    `json:"maxAttempts"`
+	ElapsedMs      int64  `json:"elapsedMs"`
+	TotalElapsedMs int64  `json:"totalElapsedMs"`
+	ErrorCode      string `json:"errorCode,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// LoginStepResult is the outcome of a single login step
+type LoginStepResult struct {
+	Step      string `json:"step"`
+	Status    string `json:"status"`
+	Attempts  int    `json:"attempts"`
+	ElapsedMs int64  `json:"elapsedMs"`
+	ErrorCode string `json:"errorCode,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// LoginResult is the outcome of a login pipeline run, FailedStep and ErrorCode are only set on failure
+type LoginResult struct {
+	Username   string            `json:"username"`
+	Success    bool              `json:"success"`
+	FailedStep string            `json:"failedStep,omitempty"`
+	ErrorCode  string            `json:"errorCode,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	ElapsedMs  int64             `json:"elapsedMs"`
+	Steps      []LoginStepResult `json:"steps"`
+}
@@ -0,0 +1,293 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	application "github.com/wailsapp/wails/v3/pkg/application"
+	events "github.com/wailsapp/wails/v3/pkg/events"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebviewWindower is an autogenerated mock type for the WebviewWindower type
+type WebviewWindower struct {
+	mock.Mock
+}
+
+type WebviewWindower_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebviewWindower) EXPECT() *WebviewWindower_Expecter {
+	return &WebviewWindower_Expecter{mock: &_m.Mock}
+}
+
+// Focus provides a mock function with no fields
+func (_m *WebviewWindower) Focus() {
+	_m.Called()
+}
+
+// WebviewWindower_Focus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Focus'
+type WebviewWindower_Focus_Call struct {
+	*mock.Call
+}
+
+// Focus is a helper method to define mock.On call
+func (_e *WebviewWindower_Expecter) Focus() *WebviewWindower_Focus_Call {
+	return &WebviewWindower_Focus_Call{Call: _e.mock.On("Focus")}
+}
+
+func (_c *WebviewWindower_Focus_Call) Run(run func()) *WebviewWindower_Focus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *WebviewWindower_Focus_Call) Return() *WebviewWindower_Focus_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *WebviewWindower_Focus_Call) RunAndReturn(run func()) *WebviewWindower_Focus_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Hide provides a mock function with no fields
+func (_m *WebviewWindower) Hide() application.Window {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Hide")
+	}
+
+	var r0 application.Window
+	if rf, ok := ret.Get(0).(func() application.Window); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(application.Window)
+		}
+	}
+
+	return r0
+}
+
+// WebviewWindower_Hide_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Hide'
+type WebviewWindower_Hide_Call struct {
+	*mock.Call
+}
+
+// Hide is a helper method to define mock.On call
+func (_e *WebviewWindower_Expecter) Hide() *WebviewWindower_Hide_Call {
+	return &WebviewWindower_Hide_Call{Call: _e.mock.On("Hide")}
+}
+
+func (_c *WebviewWindower_Hide_Call) Run(run func()) *WebviewWindower_Hide_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *WebviewWindower_Hide_Call) Return(_a0 application.Window) *WebviewWindower_Hide_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebviewWindower_Hide_Call) RunAndReturn(run func() application.Window) *WebviewWindower_Hide_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OnWindowEvent provides a mock function with given fields: eventType, callback
+func (_m *WebviewWindower) OnWindowEvent(eventType events.WindowEventType, callback func(*application.WindowEvent)) func() {
+	ret := _m.Called(eventType, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OnWindowEvent")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(events.WindowEventType, func(*application.WindowEvent)) func()); ok {
+		r0 = rf(eventType, callback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// WebviewWindower_OnWindowEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OnWindowEvent'
+type WebviewWindower_OnWindowEvent_Call struct {
+	*mock.Call
+}
+
+// OnWindowEvent is a helper method to define mock.On call
+//   - eventType events.WindowEventType
+//   - callback func(*application.WindowEvent)
+func (_e *WebviewWindower_Expecter) OnWindowEvent(eventType interface{}, callback interface{}) *WebviewWindower_OnWindowEvent_Call {
+	return &WebviewWindower_OnWindowEvent_Call{Call: _e.mock.On("OnWindowEvent", eventType, callback)}
+}
+
+func (_c *WebviewWindower_OnWindowEvent_Call) Run(run func(eventType events.WindowEventType, callback func(*application.WindowEvent))) *WebviewWindower_OnWindowEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(events.WindowEventType), args[1].(func(*application.WindowEvent)))
+	})
+	return _c
+}
+
+func (_c *WebviewWindower_OnWindowEvent_Call) Return(_a0 func()) *WebviewWindower_OnWindowEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebviewWindower_OnWindowEvent_Call) RunAndReturn(run func(events.WindowEventType, func(*application.WindowEvent)) func()) *WebviewWindower_OnWindowEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegisterHook provides a mock function with given fields: eventType, callback
+func (_m *WebviewWindower) RegisterHook(eventType events.WindowEventType, callback func(*application.WindowEvent)) func() {
+	ret := _m.Called(eventType, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterHook")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(events.WindowEventType, func(*application.WindowEvent)) func()); ok {
+		r0 = rf(eventType, callback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// WebviewWindower_RegisterHook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterHook'
+type WebviewWindower_RegisterHook_Call struct {
+	*mock.Call
+}
+
+// RegisterHook is a helper method to define mock.On call
+//   - eventType events.WindowEventType
+//   - callback func(*application.WindowEvent)
+func (_e *WebviewWindower_Expecter) RegisterHook(eventType interface{}, callback interface{}) *WebviewWindower_RegisterHook_Call {
+	return &WebviewWindower_RegisterHook_Call{Call: _e.mock.On("RegisterHook", eventType, callback)}
+}
+
+func (_c *WebviewWindower_RegisterHook_Call) Run(run func(eventType events.WindowEventType, callback func(*application.WindowEvent))) *WebviewWindower_RegisterHook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(events.WindowEventType), args[1].(func(*application.WindowEvent)))
+	})
+	return _c
+}
+
+func (_c *WebviewWindower_RegisterHook_Call) Return(_a0 func()) *WebviewWindower_RegisterHook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebviewWindower_RegisterHook_Call) RunAndReturn(run func(events.WindowEventType, func(*application.WindowEvent)) func()) *WebviewWindower_RegisterHook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reload provides a mock function with no fields
+func (_m *WebviewWindower) Reload() {
+	_m.Called()
+}
+
+// WebviewWindower_Reload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reload'
+type WebviewWindower_Reload_Call struct {
+	*mock.Call
+}
+
+// Reload is a helper method to define mock.On call
+func (_e *WebviewWindower_Expecter) Reload() *WebviewWindower_Reload_Call {
+	return &WebviewWindower_Reload_Call{Call: _e.mock.On("Reload")}
+}
+
+func (_c *WebviewWindower_Reload_Call) Run(run func()) *WebviewWindower_Reload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *WebviewWindower_Reload_Call) Return() *WebviewWindower_Reload_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *WebviewWindower_Reload_Call) RunAndReturn(run func()) *WebviewWindower_Reload_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Show provides a mock function with no fields
+func (_m *WebviewWindower) Show() application.Window {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Show")
+	}
+
+	var r0 application.Window
+	if rf, ok := ret.Get(0).(func() application.Window); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(application.Window)
+		}
+	}
+
+	return r0
+}
+
+// WebviewWindower_Show_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Show'
+type WebviewWindower_Show_Call struct {
+	*mock.Call
+}
+
+// Show is a helper method to define mock.On call
+func (_e *WebviewWindower_Expecter) Show() *WebviewWindower_Show_Call {
+	return &WebviewWindower_Show_Call{Call: _e.mock.On("Show")}
+}
+
+func (_c *WebviewWindower_Show_Call) Run(run func()) *WebviewWindower_Show_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *WebviewWindower_Show_Call) Return(_a0 application.Window) *WebviewWindower_Show_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebviewWindower_Show_Call) RunAndReturn(run func() application.Window) *WebviewWindower_Show_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebviewWindower creates a new instance of WebviewWindower. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebviewWindower(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebviewWindower {
+	mock := &WebviewWindower{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
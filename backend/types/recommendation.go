@@ -0,0 +1,28 @@
+package types
+
+// RecommendationRequest describes what the user wants from the account to rent
+type RecommendationRequest struct {
+	Server              string `json:"server,omitempty"`
+	MinTier             string `json:"minTier,omitempty"`
+	ChampionIDs         []int  `json:"championIds,omitempty"`
+	SkinIDs             []int  `json:"skinIds,omitempty"`
+	ExcludeLeaverBuster bool   `json:"excludeLeaverBuster,omitempty"`
+	Limit               int    `json:"limit,omitempty"`
+}
+
+// RecommendationReason explains how a single requirement contributed to the score
+type RecommendationReason struct {
+	Requirement string  `json:"requirement"`
+	Met         bool    `json:"met"`
+	Detail      string  `json:"detail"`
+	Points      float64 `json:"points"`
+	MaxPoints   float64 `json:"maxPoints"`
+}
+
+// AccountRecommendation is an account scored against a RecommendationRequest
+type AccountRecommendation struct {
+	Account SummonerBase           `json:"account"`
+	Score   float64                `json:"score"`
+	Matches []RecommendationReason `json:"matches"`
+	Missing []RecommendationReason `json:"missing"`
+}
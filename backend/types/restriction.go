@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+// Normalized restriction kinds, every raw restriction type reported by Riot maps to one of them
+const (
+	RestrictionPermanentBan       = "PERMANENT_BAN"
+	RestrictionTimedBan           = "TIMED_BAN"
+	RestrictionChat               = "CHAT_RESTRICTION"
+	RestrictionRanked             = "RANKED_RESTRICTION"
+	RestrictionQueueDelay         = "QUEUE_DELAY"
+	RestrictionMultifactor        = "MFA_REQUIRED"
+	RestrictionInvalidCredentials = "INVALID_CREDENTIALS"
+	RestrictionRequiredAction     = "REQUIRED_ACTION"
+	RestrictionUnknown            = "UNKNOWN"
+)
+
+// AccountRestriction is a restriction normalized from userinfo, the leaver buster or LCU events
+type AccountRestriction struct {
+	Kind string `json:"kind"`
+	// Scope is the product the restriction applies to, e.g. riot or lol, empty means the whole account
+	Scope string `json:"scope,omitempty"`
+	// Source is where the restriction was read from, e.g. userinfo or leaver_buster
+	Source  string `json:"source"`
+	RawType string `json:"rawType,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	// ExpiresAt is nil for restrictions without an expiry
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+	GamesRemaining int        `json:"gamesRemaining,omitempty"`
+}
+
+// RestrictionChange is emitted when restrictions appear or lift during a session
+type RestrictionChange struct {
+	Username string               `json:"username"`
+	Appeared []AccountRestriction `json:"appeared"`
+	Lifted   []AccountRestriction `json:"lifted"`
+	Active   []AccountRestriction `json:"active"`
+}
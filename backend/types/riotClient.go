@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 type RiotIdentityStartPayload struct {
 	Apple        interface{} `json:"apple"`
 	Campaign     interface{} `json:"campaign"`
@@ -130,3 +132,37 @@ type RiotIdentityResponse struct {
 		Type     string      `json:"type"`
 	} `json:"validation_captcha"`
 }
+
+// MultifactorInput is the body sent to complete a multifactor authentication
+type MultifactorInput struct {
+	Otp            string `json:"otp"`
+	RememberDevice bool   `json:"rememberDevice"`
+}
+
+// MultifactorChallenge describes a login waiting for its multifactor code
+type MultifactorChallenge struct {
+	Username  string    `json:"username"`
+	Method    string    `json:"method"`
+	Email     string    `json:"email"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// Required action types, they are also the restriction types saved on the account
+const (
+	RequiredActionEula       = "EULA_REQUIRED"
+	RequiredActionNameChange = "NAME_CHANGE_REQUIRED"
+	RequiredActionAgeGate    = "AGE_GATE"
+)
+
+// Required action sources
+const (
+	RequiredActionSourceRiotClient = "riot_client"
+	RequiredActionSourceLCU        = "lcu"
+)
+
+// RequiredAction is something the account must complete in the Riot client before it can play
+type RequiredAction struct {
+	Type   string `json:"type"`
+	Source string `json:"source"`
+	Detail string `json:"detail,omitempty"`
+}
@@ -0,0 +1,19 @@
+package types
+
+import "time"
+
+// SessionSummary describes what a renter did on a Nexus account during a single session
+type SessionSummary struct {
+	ID                string         `json:"id"`
+	Username          string         `json:"username"`
+	StartedAt         time.Time      `json:"startedAt"`
+	EndedAt           *time.Time     `json:"endedAt,omitempty"`
+	GamesByQueue      map[string]int `json:"gamesByQueue"`
+	InGameSeconds     int64          `json:"inGameSeconds"`
+	InClientSeconds   int64          `json:"inClientSeconds"`
+	BlueEssenceDelta  int            `json:"blueEssenceDelta"`
+	RiotPointsDelta   int            `json:"riotPointsDelta"`
+	LeaguePointsDelta map[string]int `json:"leaguePointsDelta"`
+	// LeaverBuster is nil when the leaver buster wasn't read during the session
+	LeaverBuster *LeaverBusterSession `json:"leaverBuster,omitempty"`
+}
@@ -15,7 +15,7 @@ type CurrenciesPointer struct {
 }
 type PartialSummonerRented struct {
 	Username         string                `json:"username,omitempty"`
-	Password         *string               `json:"password,omitempty"`
+	Password         *Credential           `json:"password,omitempty"`
 	GameName         *string               `json:"gamename,omitempty"`
 	Type             *string               `json:"type,omitempty"`
 	LeaverBuster     *LeaverBusterResponse `json:"leaverBuster,omitempty"`
@@ -12,7 +12,12 @@ import (
 	"github.com/hex-boost/hex-nexus-app/backend/internal/league"
 	"github.com/hex-boost/hex-nexus-app/backend/internal/league/account"
 	"github.com/hex-boost/hex-nexus-app/backend/internal/league/lcu"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/leaverbuster"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/login"
 	"github.com/hex-boost/hex-nexus-app/backend/internal/league/manager"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/recommendation"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/restriction"
+	"github.com/hex-boost/hex-nexus-app/backend/internal/league/session"
 	"github.com/hex-boost/hex-nexus-app/backend/pkg/sysquery"
 	"log/slog"
 	"strings"
@@ -33,6 +38,8 @@ import (
 	"github.com/hex-boost/hex-nexus-app/backend/protocol"
 	"github.com/hex-boost/hex-nexus-app/backend/riot"
 	"github.com/hex-boost/hex-nexus-app/backend/riot/captcha"
+	"github.com/hex-boost/hex-nexus-app/backend/riot/profile"
+	"github.com/hex-boost/hex-nexus-app/backend/riot/region"
 	"github.com/hex-boost/hex-nexus-app/backend/stripe"
 	"github.com/hex-boost/hex-nexus-app/backend/watchdog"
 	"log"
@@ -247,7 +254,10 @@ func Run(assets, csLolDLL, modToolsExe, catalog embed.FS, icon16 []byte, icon256
 	mainLogger.Debug("Initializing clients")
 	baseClient := client.NewBaseClient(appInstance.Log().Repo(), cfg)
 	httpClient := client.NewHTTPClient(baseClient)
+	regionService := region.NewService(appInstance.Log().Riot())
 	accountClient := account.NewClient(appInstance.Log().Web(), cfg, httpClient)
+	accountsCatalog := account.NewCatalog(appInstance.Log().Web(), accountClient)
+	recommendationService := recommendation.NewService(appInstance.Log().Web(), accountClient)
 	summonerClient := summoner.NewClient(appInstance.Log().League(), lcuConn)
 
 	mainLogger.Debug("Initializing summoner service")
@@ -258,12 +268,29 @@ func Run(assets, csLolDLL, modToolsExe, catalog embed.FS, icon16 []byte, icon256
 
 	mainLogger.Debug("Initializing riot service")
 	riotService := riot.NewService(appInstance.Log().Riot(), captchaService, accountClient)
+	launchProfiles := profile.New(appInstance.Log().Riot())
+	riotService.SetLaunchProfiles(launchProfiles)
+	accountClient.AddUserListener(launchProfiles)
+	baseClient.OnLogin(func() {
+		if _, err := accountClient.UserMe(); err != nil {
+			mainLogger.Error("Failed to get the logged in user", zap.Error(err))
+		}
+	})
+	baseClient.OnLogout(accountClient.ClearUser)
+	riotService.SetCurrentSummonerSource(summonerClient)
 
 	mainLogger.Debug("Initializing league service")
 	leagueService := league.NewService(appInstance.Log().Riot(), accountClient, summonerService, lcuConn, accountState, riotService)
 
+	mainLogger.Debug("Initializing skin catalog")
+	skinCatalog, err := lolskin.LoadCatalog(catalog, lolskin.CatalogPath)
+	if err != nil {
+		mainLogger.Error("Failed to load skin catalog", zap.Error(err))
+	}
+	catalogService := lolskin.NewCatalogService(appInstance.Log().League(), skinCatalog)
+
 	mainLogger.Debug("Initializing lolskin injector")
-	lolskinInjector := lolskin.New(appInstance.Log().League(), leagueService.GetPath(), catalog, csLolDLL, modToolsExe)
+	lolskinInjector := lolskin.New(appInstance.Log().League(), leagueService.GetPath(), catalogService, csLolDLL, modToolsExe)
 
 	mainLogger.Debug("Initializing updater")
 	newUpdaterUtils := updaterUtils.New(appInstance.Log().Wails())
@@ -293,14 +320,42 @@ func Run(assets, csLolDLL, modToolsExe, catalog embed.FS, icon16 []byte, icon256
 
 	mainLogger.Debug("Initializing client monitor")
 	clientMonitor := league.NewMonitor(appInstance.Log().League(), accountMonitor, leagueService, riotService, captchaService, accountState, riotService, accountClient)
+	loginPipeline := login.NewPipeline(appInstance.Log().League(), riotService, clientMonitor, leagueService)
+	loginPipeline.SetProfileSelector(launchProfiles)
 
 	mainLogger.Debug("Initializing lolskin services")
 	lolSkinState := lolskin.NewState()
 	lolSkinService := lolskin.NewService(appInstance.Log().League(), accountState, accountClient, lolskinInjector, lolSkinState)
+	skinCache := lolskin.NewCache(appInstance.Log().League(), lolskinInjector.GetTempDir(), catalogService, lolSkinState)
+	lolSkinService.SetCache(skinCache)
+	skinProfiles := lolskin.NewProfiles(appInstance.Log().League(), lolSkinState)
+	skinProfiles.SetSync(accountClient)
+	accountClient.AddUserListener(skinProfiles)
+	lolSkinService.SetProfiles(skinProfiles)
+	customMods := lolskin.NewCustomMods(appInstance.Log().League(), lolskinInjector.GetTempDir(), catalogService)
+	modConflicts := lolskin.NewConflictResolver(appInstance.Log().League(), lolskinInjector.GetTempDir(), catalogService, lolSkinState)
+	lolskinInjector.SetConflictResolver(modConflicts)
+	modConflicts.SetGlobalMods(customMods)
+	lolSkinService.SetGlobalMods(customMods)
+	skinSources := lolskin.NewSkinSources(appInstance.Log().League(), lolskinInjector.GetTempDir())
+	skinSources.SetRemote(accountClient)
+	lolskinInjector.SetSkinSources(skinSources)
 
 	mainLogger.Debug("Initializing websocket services")
 	websocketHandler := handler.New(appInstance.Log().League(), accountState, accountClient, summonerClient, lolSkinState, lolSkinService)
 	websocketRouter := websocket.NewRouter(appInstance.Log().League())
+
+	sessionRecorder := session.New(appInstance.Log().League(), accountClient, accountState)
+	accountMonitor.SetSessionRecorder(sessionRecorder)
+	websocketHandler.SetSessionRecorder(sessionRecorder)
+	websocketHandler.SetAccountMonitor(accountMonitor)
+	restrictionMonitor := restriction.NewMonitor(appInstance.Log().League(), riotService, accountState, accountClient)
+	leaverBusterService := leaverbuster.NewService(appInstance.Log().League(), summonerClient, accountState)
+	leaverBusterService.SetSessionRecorder(sessionRecorder)
+	websocketHandler.SetLeaverBusterAnalyzer(leaverBusterService)
+	restrictionMonitor.SetLeaverBusterSource(leaverBusterService)
+	summonerService.SetLeaverBusterSource(leaverBusterService)
+	websocketHandler.SetRestrictionMonitor(restrictionMonitor)
 	websocketManager := websocket.NewManager()
 	websocketService := websocket.NewService(appInstance.Log().League(), accountMonitor, leagueService, lcuConn, accountClient, websocketRouter, websocketHandler, websocketManager)
 	mainLogger.Debug("Initializing logger service for frontend")
@@ -377,9 +432,16 @@ func Run(assets, csLolDLL, modToolsExe, catalog embed.FS, icon16 []byte, icon256
 			application.NewService(summonerService),
 			application.NewService(leagueService),
 			application.NewService(clientMonitor),
+			application.NewService(restrictionMonitor),
+			application.NewService(leaverBusterService),
+			application.NewService(loginPipeline),
+			application.NewService(launchProfiles),
+			application.NewService(regionService),
 			application.NewService(lcuConn),
 			application.NewService(baseClient),
 			application.NewService(accountClient),
+			application.NewService(accountsCatalog),
+			application.NewService(recommendationService),
 			application.NewService(logService),
 			application.NewService(accountMonitor),
 			application.NewService(leagueManager),
@@ -388,11 +450,18 @@ func Run(assets, csLolDLL, modToolsExe, catalog embed.FS, icon16 []byte, icon256
 			application.NewService(updateManager),
 			application.NewService(accountState),
 			application.NewService(lolskinInjector),
+			application.NewService(catalogService),
 			application.NewService(lolSkinState),
+			application.NewService(skinProfiles),
+			application.NewService(customMods),
+			application.NewService(modConflicts),
+			application.NewService(skinSources),
 			application.NewService(websocketHandler),
 			application.NewService(summonerClient),
 			application.NewService(websocketService),
 			application.NewService(lolSkinService),
+			application.NewService(skinCache),
+			application.NewService(sessionRecorder),
 		},
 		Assets: application.AssetOptions{
 			Handler: application.BundledAssetFileServer(assets),
@@ -466,6 +535,7 @@ func Run(assets, csLolDLL, modToolsExe, catalog embed.FS, icon16 []byte, icon256
 		//}
 		mainApp.Logger.Info("Forced close requested, shutting down")
 		clientMonitor.Stop()
+		restrictionMonitor.Stop()
 		//gameOverlayManager.Stop() // Stop the overlay manager
 		if updateManager != nil {
 			mainApp.Logger.Info("Stopping update manager error %v", updateManager.ReleaseMutex())
@@ -483,6 +553,15 @@ func Run(assets, csLolDLL, modToolsExe, catalog embed.FS, icon16 []byte, icon256
 		websocketService.SubscribeToLeagueEvents()
 		accountMonitor.Start(mainWindow)
 		clientMonitor.Start(mainApp)
+		restrictionMonitor.Start(mainApp)
+		leaverBusterService.SetApp(mainApp)
+		lolskinInjector.SetApp(mainApp)
+		go func() {
+			if err := skinSources.Refresh(); err != nil {
+				mainLogger.Error("Failed to refresh skin sources", zap.Error(err))
+			}
+		}()
+		loginPipeline.SetApp(mainApp)
 		leagueManager.SetApp(mainApp)
 		//gameOverlayManager.Start()
 